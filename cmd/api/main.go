@@ -3,19 +3,32 @@ package main
 
 import (
 	"context"
+	"flag"
 	"fmt"
 	"io"
+	"net/http"
 	"os"
+	"os/signal"
+	"syscall"
 	"time"
 
 	"github.com/valdinei-santos/cpf-backend/cmd/api/routes"
 	_ "github.com/valdinei-santos/cpf-backend/cmd/api/routes"
-	_ "github.com/valdinei-santos/cpf-backend/cmd/api/stats"
+	"github.com/valdinei-santos/cpf-backend/internal/infra/auth"
 	"github.com/valdinei-santos/cpf-backend/internal/infra/config"
 	"github.com/valdinei-santos/cpf-backend/internal/infra/database"
+	"github.com/valdinei-santos/cpf-backend/internal/infra/hashchain"
+	"github.com/valdinei-santos/cpf-backend/internal/infra/keyring"
 	"github.com/valdinei-santos/cpf-backend/internal/infra/logger"
+	"github.com/valdinei-santos/cpf-backend/internal/infra/metrics"
+	"github.com/valdinei-santos/cpf-backend/internal/infra/migration"
+	"github.com/valdinei-santos/cpf-backend/internal/infra/rbac"
+	"github.com/valdinei-santos/cpf-backend/internal/infra/secrets"
+	"github.com/valdinei-santos/cpf-backend/internal/infra/tracing"
+	"github.com/valdinei-santos/cpf-backend/internal/infra/worker"
 
 	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 // @title CPF Management API
@@ -23,39 +36,220 @@ import (
 // @description Está API gerencia CPFs.
 // @host localhost:8889
 // // @host previg-dev03.previg.org.br:8889
-// @BasePath /api/v1/cliente
+// @BasePath /api/v1
 func main() {
-	fmt.Println("Iniciando...")
-	config, err := config.LoadConfig()
+	configFlags := config.RegisterFlags(flag.CommandLine)
+	printConfig := flag.Bool("print-config", false, "exibe a configuração efetiva, com os segredos ocultos, e termina")
+	flag.Parse()
+
+	config, err := config.LoadConfig(configFlags)
 	if err != nil {
-		fmt.Printf("Erro ao carregar variáveis do .env: %v", err)
+		fmt.Printf("Erro ao carregar a configuração: %v\n", err)
 		os.Exit(1)
 	}
+	if *printConfig {
+		config.Print(os.Stdout)
+		return
+	}
+	fmt.Println("Iniciando...")
 
-	log := logger.NewSlogILogger()
+	log := logger.NewSlogILogger(config.Log)
 	fmt.Println("Iniciou Log...")
+	log.Info("Configuração carregada", "config", config.Redacted())
+
+	// Rotinas em segundo plano (JWKS, recarga de segredos, checkpoints), encerradas no shutdown
+	workers := worker.NewGroup(log)
+
+	// Carrega as chaves de criptografia dos campos sensíveis (documento/telefone)
+	cipher, err := keyring.NewFieldCipher(config.Crypto.KeyringFile)
+	if err != nil {
+		fmt.Printf("Erro ao carregar o arquivo de chaves: %v", err)
+		os.Exit(1)
+	}
+	if config.Crypto.KeyringFile == "" {
+		log.Warn("KEYRING_FILE não configurado, documento e telefone serão gravados sem criptografia")
+	}
+
+	// Chave que assina os checkpoints da cadeia de auditoria
+	signer, err := hashchain.LoadSigner(config.Audit.SigningKey)
+	if err != nil {
+		fmt.Printf("Erro ao carregar a chave de assinatura da auditoria: %v", err)
+		os.Exit(1)
+	}
+	if signer == nil {
+		log.Warn("AUDIT_SIGNING_KEY não configurado, a cadeia de auditoria não terá checkpoints assinados")
+	} else {
+		log.Info("Chave de assinatura da auditoria carregada", "key_id", signer.KeyID(), "public_key", signer.PublicKey())
+	}
+
+	// Autenticação JWT, as chaves do emissor são recarregadas periodicamente do JWKS
+	var jwtVerifier *auth.JWTVerifier
+	if config.Auth.JWKS != "" {
+		provider, err := auth.NewJWKSProvider(config.Auth.JWKS, config.Auth.JWKSRefresh, log)
+		if err != nil {
+			fmt.Printf("Erro ao carregar o JWKS: %v", err)
+			os.Exit(1)
+		}
+		workers.Go("jwks", provider.Run)
+		jwtVerifier = auth.NewJWTVerifier(provider, config.Auth.Issuer, config.Auth.Audience, config.Auth.Leeway)
+	} else {
+		log.Warn("AUTH_JWKS não configurado, apenas as chaves de API autenticam")
+	}
+
+	// Permissões de cada papel. As requisições sem credencial são recusadas, exceto com
+	// AUTH_ALLOW_ANONYMOUS no perfil dev
+	policy, err := rbac.LoadPolicy(config.RBAC.File)
+	if err != nil {
+		fmt.Printf("Erro ao carregar o arquivo de papéis: %v", err)
+		os.Exit(1)
+	}
+	authz := rbac.NewAuthorizer(policy, config.Auth.AllowAnonymous, log)
+	if config.Auth.AllowAnonymous {
+		log.Warn("AUTH_ALLOW_ANONYMOUS habilitado, requisições sem credencial têm acesso aos dados dos clientes")
+	}
+
+	// Métricas do /metrics e do /status, os monitores do driver medem os comandos e o pool do MongoDB
+	appMetrics := metrics.New()
+
+	// Rastreamento (OpenTelemetry) das requisições, casos de uso e comandos do MongoDB
+	shutdownTracing, err := tracing.Setup(context.Background(), config.Tracing)
+	if err != nil {
+		fmt.Printf("Erro ao configurar o rastreamento: %v", err)
+		os.Exit(1)
+	}
+	if config.Tracing.Exporter != tracing.ExporterNone {
+		log.Info("Rastreamento habilitado", "exporter", config.Tracing.Exporter, "sample_ratio", config.Tracing.SampleRatio)
+	}
 
 	// Conecta ao banco de dados
-	client, err := database.ConnectDB(log)
+	client, err := database.ConnectDB(log, config.Mongo, options.Client().
+		SetMonitor(database.CommandMonitors(appMetrics.CommandMonitor(), tracing.MongoMonitor())).
+		SetPoolMonitor(appMetrics.PoolMonitor()))
 	if err != nil {
 		fmt.Printf("Erro ao conectar no database: %v", err)
 		os.Exit(1)
 	}
-	db := client.Database("cpf_management")
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-	defer cancel()
-	defer database.DisconnectDB(log, client, ctx)
+	db := client.Database(config.Mongo.Database)
 	fmt.Println("Iniciou Database...")
 
-	gin.DefaultWriter = io.Discard // Desabilita o log padrão do gin jogando para o io.Discard
-	router := gin.Default()
+	// Migrações do banco. Sem MIGRATION_ON_START elas são aplicadas pelo comando migrate
+	migrator, err := migration.New(migration.Env{
+		DB:          db,
+		Tenants:     database.NewTenants(db, config.Tenant.Mode, config.Tenant.Allowed, log),
+		Collections: config.Mongo.Collections,
+	}, migration.All(), config.Migration, log)
+	if err != nil {
+		fmt.Printf("Erro nas migrações: %v", err)
+		os.Exit(1)
+	}
+	if config.Migration.OnStart {
+		applied, err := migrator.Up(context.Background(), 0)
+		if err != nil {
+			fmt.Printf("Erro ao aplicar as migrações: %v", err)
+			os.Exit(1)
+		}
+		if len(applied) > 0 {
+			log.Info("Migrações aplicadas", "versions", applied)
+		}
+	} else if pending, err := migrator.Pending(context.Background()); err != nil {
+		log.Warn("Erro ao consultar as migrações pendentes", "err", err.Error())
+	} else if len(pending) > 0 {
+		log.Warn("Existem migrações pendentes, rode o comando migrate up", "versions", pending)
+	}
+
+	// Recarga dos segredos lidos de arquivos, trocados sem reiniciar a API
+	if config.Secrets.ReloadInterval > 0 {
+		watcher := secrets.NewWatcher(config.Secrets.ReloadInterval, log)
+		if reloader, ok := cipher.(secrets.Reloader); ok {
+			watcher.Watch(config.Crypto.KeyringFile, reloader)
+		}
+		if signer != nil {
+			watcher.Watch(config.Audit.SigningKey, secrets.ReloaderFunc(func() error {
+				if err := signer.Reload(); err != nil {
+					return err
+				}
+				log.Info("Chave de assinatura da auditoria recarregada", "key_id", signer.KeyID(), "public_key", signer.PublicKey())
+				return nil
+			}))
+		}
+		// O driver do MongoDB não permite trocar a credencial das conexões já abertas no pool
+		for _, file := range []string{config.Mongo.URIFile, config.Mongo.PasswordFile} {
+			if file == "" {
+				continue
+			}
+			watcher.Watch(file, secrets.ReloaderFunc(func() error {
+				log.Warn("Credencial do MongoDB alterada, reinicie a API para usá-la", "arquivo", file)
+				return nil
+			}))
+		}
+		workers.Go("secrets_reload", watcher.Run)
+	}
+
+	gin.DefaultWriter = io.Discard // Desabilita as mensagens do gin, as requisições vão para o log de acesso (HTTP_LOG_*)
+	router := gin.New()
 	router.SetTrustedProxies(nil)
-	routes.InitRoutes(&router.RouterGroup, log, db)
+	router.HandleMethodNotAllowed = true // 405 com o header Allow quando o path existe com outro método
+	routes.InitRoutes(&router.RouterGroup, routes.Dependencies{
+		Log:      log,
+		LogLevel: log,
+		DB:       db,
+		Config:   config,
+		Cipher:   cipher,
+		Signer:   signer,
+		JWT:      jwtVerifier,
+		Authz:    authz,
+		Workers:  workers,
+		Metrics:  appMetrics,
+	})
+	// Depois das rotas, para que passem pelos middlewares globais (id da requisição, log de acesso...)
+	router.NoRoute(routes.NoRouteHandler)
+	router.NoMethod(routes.NoMethodHandler)
 
-	log.Info("start cpf-mamagement", "PORT:", config.Port)
-	err = router.Run(":" + config.Port)
-	if err != nil {
-		fmt.Printf("Erro ao iniciar a API na porta %v: %v", config.Port, err)
-		log.Error("Erro ao iniciar a API na porta " + config.Port + " - " + err.Error())
+	server := &http.Server{
+		Addr:              ":" + config.Server.Port,
+		Handler:           router,
+		ReadTimeout:       config.Server.ReadTimeout,
+		ReadHeaderTimeout: config.Server.ReadHeaderTimeout,
+		WriteTimeout:      config.Server.WriteTimeout,
+		IdleTimeout:       config.Server.IdleTimeout,
+	}
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	serverErr := make(chan error, 1)
+	go func() {
+		log.Info("start cpf-mamagement", "PORT:", config.Server.Port)
+		serverErr <- server.ListenAndServe()
+	}()
+
+	exitCode := 0
+	select {
+	case err := <-serverErr:
+		fmt.Printf("Erro ao iniciar a API na porta %v: %v", config.Server.Port, err)
+		log.Error("Erro ao iniciar a API na porta " + config.Server.Port + " - " + err.Error())
+		exitCode = 1
+	case <-ctx.Done():
+		log.Info("Sinal de encerramento recebido, aguardando as requisições em andamento", "timeout", config.Server.ShutdownTimeout.String())
 	}
+	stop() // Um segundo sinal encerra o processo imediatamente
+
+	// Encerramento: para de aceitar conexões e espera as requisições em andamento, encerra os
+	// workers, grava o log pendente e só então fecha a conexão com o banco
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), config.Server.ShutdownTimeout)
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		log.Error("Prazo de encerramento esgotado, requisições em andamento interrompidas", "err", err.Error())
+		server.Close()
+	}
+	if err := workers.Stop(shutdownCtx); err != nil {
+		log.Error("Erro ao encerrar os workers", "err", err.Error())
+	}
+	if err := shutdownTracing(shutdownCtx); err != nil {
+		log.Error("Erro ao enviar os traces pendentes", "err", err.Error())
+	}
+	cancel()
+	log.Info("API encerrada")
+	log.Close()
+
+	disconnectCtx, cancelDisconnect := context.WithTimeout(context.Background(), 5*time.Second)
+	database.DisconnectDB(log, client, disconnectCtx)
+	cancelDisconnect()
+	os.Exit(exitCode)
 }
@@ -0,0 +1,64 @@
+package routes
+
+import (
+	"net/http"
+
+	"github.com/valdinei-santos/cpf-backend/internal/infra/hashchain"
+	"github.com/valdinei-santos/cpf-backend/internal/infra/logger"
+	"github.com/valdinei-santos/cpf-backend/internal/infra/problem"
+	"github.com/valdinei-santos/cpf-backend/internal/infra/ratelimit"
+	"github.com/valdinei-santos/cpf-backend/internal/infra/tenant"
+	apikeyErr "github.com/valdinei-santos/cpf-backend/internal/modules/apikey/domain/domainerr"
+	clienteErr "github.com/valdinei-santos/cpf-backend/internal/modules/cliente/domain/domainerr"
+)
+
+// registerErrors registra no pacote problem o status HTTP e o código dos erros dos módulos e da
+// infraestrutura. Os códigos são o contrato com os clientes da API e não devem ser alterados; os
+// erros comuns (globalerr) já vêm registrados no pacote problem. Cada código novo precisa da
+// mensagem nos catálogos do pacote i18n (pt-BR e en-US).
+func registerErrors() {
+	problem.Register(
+		// Cliente
+		problem.Entry{Err: clienteErr.ErrClienteNomeInvalid, Status: http.StatusBadRequest, Code: "cliente.nome_invalid"},
+		problem.Entry{Err: clienteErr.ErrClienteDocumentoInvalid, Status: http.StatusBadRequest, Code: "cliente.documento_invalid"},
+		problem.Entry{Err: clienteErr.ErrClienteTelefoneInvalid, Status: http.StatusBadRequest, Code: "cliente.telefone_invalid"},
+		problem.Entry{Err: clienteErr.ErrClienteBloqueadoInvalid, Status: http.StatusBadRequest, Code: "cliente.bloqueado_invalid"},
+		problem.Entry{Err: clienteErr.ErrClienteIDInvalid, Status: http.StatusBadRequest, Code: "cliente.id_invalid"},
+		problem.Entry{Err: clienteErr.ErrClienteUUIDInvalid, Status: http.StatusBadRequest, Code: "cliente.uuid_invalid"},
+		problem.Entry{Err: clienteErr.ErrClienteNotFound, Status: http.StatusNotFound, Code: "cliente.not_found"},
+		problem.Entry{Err: clienteErr.ErrClienteNotFoundMany, Status: http.StatusNotFound, Code: "cliente.none_found"},
+		problem.Entry{Err: clienteErr.ErrDuplicatekey, Status: http.StatusConflict, Code: "cliente.duplicate"},
+
+		// Consentimento, log de acesso e auditoria do cliente
+		problem.Entry{Err: clienteErr.ErrConsentimentoFinalidadeInvalid, Status: http.StatusBadRequest, Code: "consentimento.finalidade_invalid"},
+		problem.Entry{Err: clienteErr.ErrConsentimentoBaseLegalInvalid, Status: http.StatusBadRequest, Code: "consentimento.base_legal_invalid"},
+		problem.Entry{Err: clienteErr.ErrConsentimentoCanalInvalid, Status: http.StatusBadRequest, Code: "consentimento.canal_invalid"},
+		problem.Entry{Err: clienteErr.ErrConsentimentoVersaoInvalid, Status: http.StatusBadRequest, Code: "consentimento.versao_politica_invalid"},
+		problem.Entry{Err: clienteErr.ErrConsentimentoNotFound, Status: http.StatusNotFound, Code: "consentimento.not_found"},
+		problem.Entry{Err: clienteErr.ErrConsentimentoJaRevogado, Status: http.StatusConflict, Code: "consentimento.already_revoked"},
+		problem.Entry{Err: clienteErr.ErrAcessoOperadorInvalid, Status: http.StatusBadRequest, Code: "acesso.operador_required"},
+		problem.Entry{Err: clienteErr.ErrAuditoriaCadeiaInvalid, Status: http.StatusBadRequest, Code: "auditoria.cadeia_invalid"},
+
+		// Chave de API
+		problem.Entry{Err: apikeyErr.ErrApiKeyNomeInvalid, Status: http.StatusBadRequest, Code: "apikey.nome_invalid"},
+		problem.Entry{Err: apikeyErr.ErrApiKeyOwnerInvalid, Status: http.StatusBadRequest, Code: "apikey.owner_invalid"},
+		problem.Entry{Err: apikeyErr.ErrApiKeyScopeInvalid, Status: http.StatusBadRequest, Code: "apikey.scopes_invalid"},
+		problem.Entry{Err: apikeyErr.ErrApiKeyExpiracaoInvalid, Status: http.StatusBadRequest, Code: "apikey.expires_at_invalid"},
+		problem.Entry{Err: apikeyErr.ErrApiKeyTenantInvalid, Status: http.StatusBadRequest, Code: "apikey.tenant_invalid"},
+		problem.Entry{Err: apikeyErr.ErrApiKeyIDInvalid, Status: http.StatusBadRequest, Code: "apikey.id_invalid"},
+		problem.Entry{Err: apikeyErr.ErrApiKeyNotFound, Status: http.StatusNotFound, Code: "apikey.not_found"},
+		problem.Entry{Err: apikeyErr.ErrApiKeyRevogada, Status: http.StatusConflict, Code: "apikey.revoked"},
+		problem.Entry{Err: apikeyErr.ErrApiKeyExpirada, Status: http.StatusUnauthorized, Code: "apikey.expired"},
+		problem.Entry{Err: apikeyErr.ErrApiKeyInvalida, Status: http.StatusUnauthorized, Code: "apikey.invalid"},
+
+		// Infraestrutura
+		problem.Entry{Err: tenant.ErrTenantRequired, Status: http.StatusBadRequest, Code: "tenant.required"},
+		problem.Entry{Err: tenant.ErrTenantInvalid, Status: http.StatusBadRequest, Code: "tenant.invalid"},
+		problem.Entry{Err: tenant.ErrTenantMismatch, Status: http.StatusForbidden, Code: "tenant.mismatch"},
+		problem.Entry{Err: tenant.ErrTenantNoClaim, Status: http.StatusForbidden, Code: "tenant.no_claim"},
+		problem.Entry{Err: tenant.ErrTenantUnknown, Status: http.StatusForbidden, Code: "tenant.unknown"},
+		problem.Entry{Err: ratelimit.ErrQuotaExceeded, Status: http.StatusTooManyRequests, Code: "quota_exceeded"},
+		problem.Entry{Err: hashchain.ErrChainConflict, Status: http.StatusConflict, Code: "auditoria.chain_conflict"},
+		problem.Entry{Err: logger.ErrLevelInvalid, Status: http.StatusBadRequest, Code: "log.level_invalid"},
+	)
+}
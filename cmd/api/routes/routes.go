@@ -1,109 +1,306 @@
 package routes
 
 import (
+	"context"
 	"net/http"
 	"time"
 
-	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
 	files "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
-	"github.com/valdinei-santos/cpf-backend/cmd/api/stats"
 	_ "github.com/valdinei-santos/cpf-backend/docs" // swagger docs
+	"github.com/valdinei-santos/cpf-backend/internal/domain/globalerr"
+	"github.com/valdinei-santos/cpf-backend/internal/infra/accesslog"
+	"github.com/valdinei-santos/cpf-backend/internal/infra/auth"
+	"github.com/valdinei-santos/cpf-backend/internal/infra/config"
+	"github.com/valdinei-santos/cpf-backend/internal/infra/hashchain"
+	"github.com/valdinei-santos/cpf-backend/internal/infra/health"
+	"github.com/valdinei-santos/cpf-backend/internal/infra/keyring"
 	"github.com/valdinei-santos/cpf-backend/internal/infra/logger"
+	"github.com/valdinei-santos/cpf-backend/internal/infra/metrics"
+	"github.com/valdinei-santos/cpf-backend/internal/infra/problem"
+	"github.com/valdinei-santos/cpf-backend/internal/infra/ratelimit"
+	"github.com/valdinei-santos/cpf-backend/internal/infra/rbac"
+	"github.com/valdinei-santos/cpf-backend/internal/infra/recovery"
+	"github.com/valdinei-santos/cpf-backend/internal/infra/requestinfo"
+	"github.com/valdinei-santos/cpf-backend/internal/infra/tenant"
+	"github.com/valdinei-santos/cpf-backend/internal/infra/timeout"
+	"github.com/valdinei-santos/cpf-backend/internal/infra/tracing"
+	"github.com/valdinei-santos/cpf-backend/internal/infra/worker"
+	"github.com/valdinei-santos/cpf-backend/internal/modules/apikey"
+	apikeyCreate "github.com/valdinei-santos/cpf-backend/internal/modules/apikey/usecases/create"
+	apikeyGetall "github.com/valdinei-santos/cpf-backend/internal/modules/apikey/usecases/getall"
+	apikeyRevoke "github.com/valdinei-santos/cpf-backend/internal/modules/apikey/usecases/revoke"
+	apikeyRotate "github.com/valdinei-santos/cpf-backend/internal/modules/apikey/usecases/rotate"
 	"github.com/valdinei-santos/cpf-backend/internal/modules/cliente"
+	"github.com/valdinei-santos/cpf-backend/internal/modules/cliente/usecases/accesslist"
+	"github.com/valdinei-santos/cpf-backend/internal/modules/cliente/usecases/auditverify"
+	"github.com/valdinei-santos/cpf-backend/internal/modules/cliente/usecases/consentgrant"
+	"github.com/valdinei-santos/cpf-backend/internal/modules/cliente/usecases/consenthistory"
+	"github.com/valdinei-santos/cpf-backend/internal/modules/cliente/usecases/consentlist"
+	"github.com/valdinei-santos/cpf-backend/internal/modules/cliente/usecases/consentrevoke"
+	"github.com/valdinei-santos/cpf-backend/internal/modules/cliente/usecases/consentstatus"
+	clienteCreate "github.com/valdinei-santos/cpf-backend/internal/modules/cliente/usecases/create"
+	clienteDelete "github.com/valdinei-santos/cpf-backend/internal/modules/cliente/usecases/delete"
+	clienteGet "github.com/valdinei-santos/cpf-backend/internal/modules/cliente/usecases/get"
+	clienteGetall "github.com/valdinei-santos/cpf-backend/internal/modules/cliente/usecases/getall"
+	clienteUpdate "github.com/valdinei-santos/cpf-backend/internal/modules/cliente/usecases/update"
 	"go.mongodb.org/mongo-driver/mongo"
 )
 
-func InitRoutes(router *gin.RouterGroup, log logger.ILogger, db *mongo.Database) {
+// Dependencies agrupa as dependências de infraestrutura criadas no main e usadas pelas rotas
+type Dependencies struct {
+	Log      logger.ILogger
+	LogLevel logger.ILevelController // nil desabilita a alteração do nível do log em execução
+	DB       *mongo.Database
+	Config   *config.Config
+	Cipher   keyring.IFieldCipher
+	Signer   *hashchain.Signer // nil desabilita os checkpoints da auditoria
+	JWT      *auth.JWTVerifier // nil desabilita a autenticação
+	Authz    *rbac.Authorizer
+	Workers  *worker.Group // Rotinas em segundo plano, encerradas no shutdown
+	Metrics  *metrics.Metrics
+}
 
-	router.Use(cors.New(cors.Config{
-		//AllowAllOrigins: true,
-		AllowOrigins:     []string{"http://192.168.37.143:8888", "http://localhost:8888", "http://127.0.0.1:8888"},
-		AllowMethods:     []string{"GET", "POST", "PUT", "PATCH", "DELETE", "HEAD", "OPTIONS"},
-		AllowHeaders:     []string{"Origin", "Content-Length", "Content-Type", "Authorization"},
-		AllowCredentials: true,
-		MaxAge:           12 * time.Hour,
-	}))
-	// ---------------------------
+func InitRoutes(router *gin.RouterGroup, deps Dependencies) {
+	log := deps.Log
+	registerErrors()
+
+	clienteModule := cliente.NewModuleCliente(log, deps.DB, deps.Config, deps.Cipher, deps.Signer, deps.Authz, deps.Workers)
+	apiKeyModule := apikey.NewModuleApiKey(log, deps.DB, deps.Config)
+	router.Use(requestinfo.Middleware()) // Primeiro, para que o id da requisição esteja no contexto de todos os middlewares e no log
+	router.Use(tracing.Middleware())     // Em seguida, para que o trace id esteja no contexto dos demais middlewares
+	if deps.Config.HTTPLog.Enabled {
+		// Uma linha por requisição, incluindo as recusadas pelos middlewares
+		router.Use(accesslog.Middleware(deps.Config.HTTPLog, log))
+	}
+	router.Use(deps.Metrics.Middleware()) // Antes de todas as rotas, para medir também as recusadas pelos middlewares
+	router.Use(recovery.Middleware(log))  // Depois do log de acesso, do trace e das métricas, que registram o 500 do pânico
 
-	clienteModule := cliente.NewModuleCliente(log, db)
-	router.Use(AccessCounterMiddleware) // Adicionar o Middleware de Contagem antes de todas as rotas
-	router.GET("/status", GetStatusHandler)
-	router.GET("/ping", GetPingHandler)
-	router.GET("/swagger/*any", ginSwagger.WrapHandler(files.Handler))
+	// Cada grupo tem a sua política de CORS (CORS_PUBLIC_* e CORS_*)
+	public := router.Group("")
+	public.Use(deps.Config.CORS.Public.Middleware())
+	public.GET("/status", GetStatusHandler(deps.Metrics))
+	public.GET("/metrics", GetMetricsHandler(deps.Metrics))
+	public.GET("/ping", GetPingHandler)
+	public.GET("/health/live", GetHealthLiveHandler(deps.Metrics))
+	public.GET("/health/ready", GetHealthReadyHandler(newHealthChecks(deps)))
+	public.GET("/swagger/*any", ginSwagger.WrapHandler(files.Handler))
 
 	v1 := router.Group("/api/v1")
+	v1.Use(deps.Config.CORS.API.Middleware())
+	// Prazo de cada operação, antes da autenticação e do rate limit que também consultam o banco
+	v1.Use(timeout.Middleware(timeout.Policy{
+		Read:  deps.Config.Timeouts.Read,
+		Write: deps.Config.Timeouts.Write,
+		Routes: map[string]time.Duration{
+			"GET /api/v1/cliente/auditoria/verificar": deps.Config.Timeouts.AuditVerify,
+		},
+	}))
+	store := newRateLimitStore(deps)
+	if deps.Config.RateLimit.IP.Enabled() {
+		// Antes da autenticação, para limitar as tentativas com chave de API ou token inválidos, que
+		// também consultam o banco
+		v1.Use(ratelimit.Middleware(ratelimit.NewIPLimiter(store, deps.Config.RateLimit.IP), ratelimit.IPPrincipal, log))
+	}
+	v1.Use(auth.APIKeyMiddleware(apiKeyModule.Authenticator, log))
+	if deps.JWT != nil {
+		// /status, /ping e /swagger continuam públicos
+		v1.Use(auth.JWTMiddleware(deps.JWT, deps.Config.Auth.RolesClaim, log))
+	}
+	if limiter := ratelimit.NewLimiter(store, deps.Config.RateLimit.Read, deps.Config.RateLimit.Write, deps.Config.RateLimit.DailyQuota); limiter.Enabled() {
+		// Depois da autenticação, para limitar por chave de API ou usuário em vez do IP
+		v1.Use(ratelimit.Middleware(limiter, ratelimit.Principal, log))
+	}
+	// Cada rota exige a permissão declarada no pacote do caso de uso. Os papéis que concedem cada
+	// permissão vêm do RBAC_FILE
+	authz := deps.Authz
 	prod := v1.Group("/cliente")
+	if mode := deps.Config.Tenant.Mode; mode != "" && mode != tenant.ModeNone {
+		// Os dados de clientes e consentimentos ficam restritos ao tenant da credencial. O X-Tenant-ID
+		// só é aceito de quem tem a permissão tenant:cross
+		prod.Use(tenant.Middleware(deps.Config.Tenant.Claim, deps.Config.Tenant.Allowed, authz, log))
+	}
 
 	prod.OPTIONS("", func(c *gin.Context) {
 		c.Status(204)
 	})
 
-	prod.POST("", func(c *gin.Context) {
-		// Para saber algumas informações que vem no Header que o CORS se importa
-		// origin := c.GetHeader("Origin")
-		// host := c.GetHeader("X-Forwarded-Host")
-		// realHost := c.Request.Host
-		// fmt.Printf("CORS DEBUG: Origin Header: %s\n", origin)
-		// fmt.Printf("CORS DEBUG: X-Forwarded-Host Header: %s\n", host)
-		// fmt.Printf("CORS DEBUG: Request Host (Go): %s\n", realHost)
-
-		log.Info("### Start endpoint " + c.Request.Method + " " + c.Request.URL.Path)
-		clienteModule.Controller.Create(c)
-	})
+	prod.POST("", authz.Require(clienteCreate.Permission), clienteModule.Controller.Create)
 
-	prod.DELETE("/:id", func(c *gin.Context) {
-		log.Info("### Start endpoint " + c.Request.Method + " " + c.Request.URL.Path)
-		clienteModule.Controller.Delete(c)
-	})
+	prod.DELETE("/:id", authz.Require(clienteDelete.Permission), clienteModule.Controller.Delete)
 
-	prod.GET("/:id", func(c *gin.Context) {
-		log.Info("### Start endpoint " + c.Request.Method + " " + c.Request.URL.Path)
-		clienteModule.Controller.Get(c)
-	})
+	prod.GET("/:id", authz.Require(clienteGet.Permission), clienteModule.Controller.Get)
 
-	prod.GET("", func(c *gin.Context) {
-		log.Info("### Start endpoint " + c.Request.Method + " " + c.Request.URL.Path)
-		clienteModule.Controller.GetAll(c)
-	})
+	prod.GET("", authz.Require(clienteGetall.Permission), clienteModule.Controller.GetAll)
 
 	prod.OPTIONS("/:id", func(c *gin.Context) {
 		// Isso garante que o Preflight Request seja recebido e respondido com 204.
 		c.Status(204)
 	})
 
-	prod.PUT("/:id", func(c *gin.Context) {
-		log.Info("### Start endpoint " + c.Request.Method + " " + c.Request.URL.Path)
-		clienteModule.Controller.Update(c)
-	})
+	prod.PUT("/:id", authz.Require(clienteUpdate.Permission), clienteModule.Controller.Update)
+
+	// Consentimentos (LGPD) do cliente
+	prod.POST("/:id/consentimento", authz.Require(consentgrant.Permission), clienteModule.ConsentimentoController.Grant)
+
+	prod.GET("/:id/consentimento", authz.Require(consentstatus.Permission), clienteModule.ConsentimentoController.Status)
+
+	prod.GET("/:id/consentimento/historico", authz.Require(consenthistory.Permission), clienteModule.ConsentimentoController.History)
+
+	prod.POST("/:id/consentimento/:finalidade/revogar", authz.Require(consentrevoke.Permission), clienteModule.ConsentimentoController.Revoke)
+
+	prod.GET("/consentimento/:finalidade", authz.Require(consentlist.Permission), clienteModule.ConsentimentoController.List)
+
+	// Log de acesso aos dados dos clientes (LGPD)
+	prod.GET("/acessos", authz.Require(accesslist.Permission), clienteModule.AcessoController.ByOperador)
+
+	prod.GET("/:id/acessos", authz.Require(accesslist.Permission), clienteModule.AcessoController.ByCliente)
+
+	// Verificação da cadeia de hashes da auditoria
+	prod.GET("/auditoria/verificar", authz.Require(auditverify.Permission), clienteModule.AuditoriaController.Verify)
+
+	// Administração das chaves de API, sempre com um admin autenticado
+	apiKeys := v1.Group("/apikey", authz.RequireRole(rbac.RoleAdmin))
+	apiKeys.POST("", authz.Require(apikeyCreate.Permission), apiKeyModule.Controller.Create)
+
+	apiKeys.GET("", authz.Require(apikeyGetall.Permission), apiKeyModule.Controller.GetAll)
+
+	apiKeys.POST("/:id/rotacionar", authz.Require(apikeyRotate.Permission), apiKeyModule.Controller.Rotate)
+
+	apiKeys.POST("/:id/revogar", authz.Require(apikeyRevoke.Permission), apiKeyModule.Controller.Revoke)
+
+	// Administração da API em execução, também restrita a um admin autenticado
+	if deps.LogLevel != nil {
+		admin := v1.Group("/admin", authz.RequireRole(rbac.RoleAdmin))
+		admin.GET("/log/level", authz.Require(PermissionLogLevel), GetLogLevelHandler(deps.LogLevel))
+		admin.PUT("/log/level", authz.Require(PermissionLogLevel), PutLogLevelHandler(deps.LogLevel, log))
+	}
+}
+
+// PermissionLogLevel - permissão exigida para consultar e alterar o nível do log
+const PermissionLogLevel rbac.Permission = "log:manage"
 
+// newRateLimitStore cria o armazenamento dos contadores do rate limit: na memória ou, com
+// RATE_LIMIT_STORE=mongo, na collection ratelimit compartilhada entre as instâncias
+func newRateLimitStore(deps Dependencies) ratelimit.Store {
+	cfg := deps.Config
+	if cfg.RateLimit.Store != ratelimit.StoreMongo {
+		return ratelimit.NewMemoryStore()
+	}
+	store := ratelimit.NewMongoStore(deps.DB, cfg.Mongo.Collections.RateLimit)
+	if err := store.EnsureIndexes(context.Background()); err != nil {
+		deps.Log.Error("Erro ao criar os índices da collection ratelimit", "err", err.Error())
+	}
+	return store
 }
 
-// AccessCounterMiddleware -- Middleware para Contar Acessos
-func AccessCounterMiddleware(c *gin.Context) {
-	// Usa o path da rota para contagem. Ex: /api/v1/cliente
-	stats.GlobalStats.Increment(c.Request.URL.Path)
-	c.Next() // Processa o restante da requisição
+// newHealthChecks cria os verificadores da readiness. O MongoDB é crítico: sem ele a API não atende.
+// Os workers não são, a API continua atendendo sem os checkpoints ou a recarga dos segredos.
+func newHealthChecks(deps Dependencies) *health.Registry {
+	cfg := deps.Config
+	checks := health.NewRegistry(cfg.Health.Timeout, cfg.Health.CacheTTL, deps.Log)
+	checks.Register("mongodb", true, health.Mongo(deps.DB.Client()))
+	checks.Register("workers", false, health.Workers(deps.Workers))
+	return checks
 }
 
 // @Summary      Retorna o status da API
-// @Description  Retorna o status da API
+// @Description  Resumo das métricas do /metrics: requisições por rota e status, latência, comandos e pool do MongoDB e runtime do Go
 // @Tags         util
 // @Accept       json
 // @Produce      json
+// @Param        Accept-Language header string false "Idioma das mensagens de erro: pt-BR (padrão) ou en-US" Enums(pt-BR, en-US)
 // @Success      200 {object} map[string]interface{}
-// @Failure      400 {object} dto.OutputDefault
+// @Failure      500 {object} problem.Problem
 // @Router       /status [get]
-func GetStatusHandler(c *gin.Context) {
-	statsData := stats.GlobalStats.GetStats()
-	uptime := time.Since(stats.GlobalStats.StartTime).Round(time.Second).String()
+func GetStatusHandler(m *metrics.Metrics) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		summary, err := m.Summary()
+		if err != nil {
+			problem.Write(c, err)
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{
+			"status":  "success",
+			"uptime":  summary.Uptime,
+			"http":    summary.HTTP,
+			"mongo":   summary.Mongo,
+			"runtime": summary.Runtime,
+		})
+	}
+}
 
-	c.JSON(http.StatusOK, gin.H{
-		"status":        "success",
-		"uptime":        uptime,
-		"access_counts": statsData,
-	})
+// @Summary      Métricas da API
+// @Description  Métricas no formato texto do Prometheus: requisições HTTP por rota, método e status, latência das requisições e dos comandos do MongoDB, pool de conexões e runtime do Go
+// @Tags         util
+// @Produce      plain
+// @Success      200 {string} string
+// @Router       /metrics [get]
+func GetMetricsHandler(m *metrics.Metrics) gin.HandlerFunc {
+	return m.Handler()
+}
+
+// @Summary      Liveness da API
+// @Description  Retorna 200 enquanto o processo estiver atendendo, sem verificar as dependências
+// @Tags         util
+// @Produce      json
+// @Success      200 {object} map[string]interface{}
+// @Router       /health/live [get]
+func GetHealthLiveHandler(m *metrics.Metrics) gin.HandlerFunc {
+	return health.Live(m.StartTime())
+}
+
+// @Summary      Readiness da API
+// @Description  Verifica as dependências (MongoDB e workers) e retorna a situação e a latência de cada uma. O resultado fica em cache por HEALTH_CACHE_TTL
+// @Tags         util
+// @Produce      json
+// @Success      200 {object} map[string]interface{} "status up ou degraded"
+// @Failure      503 {object} map[string]interface{} "verificação crítica com falha"
+// @Router       /health/ready [get]
+func GetHealthReadyHandler(checks *health.Registry) gin.HandlerFunc {
+	return checks.Ready
+}
+
+// @Summary      Nível do log
+// @Description  Retorna o nível atual do log desta instância
+// @Tags         admin
+// @Produce      json
+// @Param        Accept-Language header string false "Idioma das mensagens de erro: pt-BR (padrão) ou en-US" Enums(pt-BR, en-US)
+// @Success      200 {object} logger.LevelBody
+// @Failure      401 {object} problem.Problem
+// @Failure      403 {object} problem.Problem
+// @Router       /admin/log/level [get]
+func GetLogLevelHandler(lc logger.ILevelController) gin.HandlerFunc {
+	return logger.LevelHandler(lc)
+}
+
+// @Summary      Altera o nível do log
+// @Description  Altera o nível do log desta instância sem reiniciar a API. Vale até o próximo reinício, quando volta o LOG_LEVEL. Exige a permissão log:manage
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Param        body body logger.LevelBody true "Novo nível: debug, info, warn ou error"
+// @Param        Accept-Language header string false "Idioma das mensagens de erro: pt-BR (padrão) ou en-US" Enums(pt-BR, en-US)
+// @Success      200 {object} logger.LevelBody
+// @Failure      400 {object} problem.Problem
+// @Failure      401 {object} problem.Problem
+// @Failure      403 {object} problem.Problem
+// @Failure      422 {object} problem.Problem "Nível inválido"
+// @Router       /admin/log/level [put]
+func PutLogLevelHandler(lc logger.ILevelController, log logger.ILogger) gin.HandlerFunc {
+	return logger.SetLevelHandler(lc, log)
+}
+
+// NoRouteHandler responde 404 no formato padrão de erro para os paths sem rota
+func NoRouteHandler(c *gin.Context) {
+	problem.Abort(c, globalerr.ErrRouteNotFound)
+}
+
+// NoMethodHandler responde 405 no formato padrão de erro quando o path existe com outro método.
+// O gin preenche o header Allow com os métodos da rota.
+func NoMethodHandler(c *gin.Context) {
+	problem.Abort(c, globalerr.ErrMethodNotAllowed)
 }
 
 // @Summary      Retorna pong
@@ -112,7 +309,7 @@ func GetStatusHandler(c *gin.Context) {
 // @Accept       json
 // @Produce      json
 // @Success      200 {object} map[string]string
-// @Failure      400 {object} dto.OutputDefault
+// @Failure      400 {object} map[string]string
 // @Router       /ping [get]
 func GetPingHandler(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{
@@ -20,7 +20,13 @@ import (
 	"go.mongodb.org/mongo-driver/mongo/options"
 
 	"github.com/valdinei-santos/cpf-backend/cmd/api/routes"
+	"github.com/valdinei-santos/cpf-backend/internal/infra/config"
+	"github.com/valdinei-santos/cpf-backend/internal/infra/keyring"
 	"github.com/valdinei-santos/cpf-backend/internal/infra/logger"
+	"github.com/valdinei-santos/cpf-backend/internal/infra/metrics"
+	"github.com/valdinei-santos/cpf-backend/internal/infra/ratelimit"
+	"github.com/valdinei-santos/cpf-backend/internal/infra/rbac"
+	"github.com/valdinei-santos/cpf-backend/internal/infra/worker"
 )
 
 type mockLogger struct{}
@@ -66,12 +72,24 @@ func setupIntegrationTest(t *testing.T) *testEnv {
 	require.NoError(t, err)
 	require.NoError(t, client.Ping(ctx, nil))
 
-	db := client.Database("cpf_management")
+	// Sem rate limit para que os testes não dependam da quantidade de requisições
+	cfg := config.Default()
+	cfg.RateLimit.Read, cfg.RateLimit.Write, cfg.RateLimit.IP = ratelimit.Rate{}, ratelimit.Rate{}, ratelimit.Rate{}
+
+	db := client.Database(cfg.Mongo.Database)
 	log := &mockLogger{}
 
 	gin.SetMode(gin.TestMode)
 	router := gin.Default()
-	routes.InitRoutes(&router.RouterGroup, log, db)
+	routes.InitRoutes(&router.RouterGroup, routes.Dependencies{
+		Log:     log,
+		DB:      db,
+		Config:  cfg,
+		Cipher:  keyring.NewPlaintext(),
+		Authz:   rbac.NewAuthorizer(rbac.DefaultPolicy(), true, log),
+		Workers: worker.NewGroup(log),
+		Metrics: metrics.New(),
+	})
 
 	return &testEnv{ctx, client, db, router}
 }
@@ -0,0 +1,126 @@
+// Comando que verifica a cadeia de hashes do histórico de consentimentos e do log de acesso de todos
+// os tenants e informa o primeiro elo quebrado de cada cadeia.
+//
+// Uso: AUDIT_SIGNING_KEY=/run/secrets/audit.pem go run cmd/auditverify/main.go -cadeia todas
+//
+// Com AUDIT_SIGNING_KEY as assinaturas dos checkpoints também são conferidas, e -checkpoint assina
+// o topo atual das cadeias antes da verificação. Termina com código 1 se alguma cadeia estiver quebrada.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/valdinei-santos/cpf-backend/internal/infra/config"
+	"github.com/valdinei-santos/cpf-backend/internal/infra/database"
+	"github.com/valdinei-santos/cpf-backend/internal/infra/hashchain"
+	"github.com/valdinei-santos/cpf-backend/internal/infra/logger"
+	"github.com/valdinei-santos/cpf-backend/internal/modules/cliente/infra/repository"
+)
+
+// chain é uma cadeia verificável
+type chain struct {
+	name      string
+	hashes    *hashchain.Chain
+	indexes   func(ctx context.Context) error
+	verifyAll func(ctx context.Context) ([]*hashchain.Report, error)
+}
+
+func main() {
+	cadeia := flag.String("cadeia", "todas", "cadeia a verificar: consentimento, acesso ou todas")
+	checkpoint := flag.Bool("checkpoint", false, "assina o topo das cadeias antes da verificação")
+	configFlags := config.RegisterFlags(flag.CommandLine)
+	flag.Parse()
+
+	cfg, err := config.LoadConfig(configFlags)
+	if err != nil {
+		fmt.Printf("Erro ao carregar a configuração: %v\n", err)
+		os.Exit(1)
+	}
+	if *cadeia != "todas" && *cadeia != "consentimento" && *cadeia != "acesso" {
+		fmt.Println("cadeia inválida, use consentimento, acesso ou todas")
+		os.Exit(1)
+	}
+
+	signer, err := hashchain.LoadSigner(cfg.Audit.SigningKey)
+	if err != nil {
+		fmt.Printf("Erro ao carregar a chave de assinatura da auditoria: %v\n", err)
+		os.Exit(1)
+	}
+	if signer == nil && *checkpoint {
+		fmt.Println("AUDIT_SIGNING_KEY não configurado")
+		os.Exit(1)
+	}
+
+	log := logger.NewSlogILogger(cfg.Log)
+	if err := verify(log, cfg, signer, *cadeia, *checkpoint); err != nil {
+		os.Exit(1)
+	}
+}
+
+// verify conecta no banco e verifica as cadeias. Separado do main para que a conexão
+// seja fechada antes do os.Exit.
+func verify(log logger.ILogger, cfg *config.Config, signer *hashchain.Signer, cadeia string, checkpoint bool) error {
+	client, err := database.ConnectDB(log, cfg.Mongo)
+	if err != nil {
+		log.Error("Erro ao conectar no database", "err", err.Error())
+		return err
+	}
+	defer func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		database.DisconnectDB(log, client, ctx)
+	}()
+
+	// No TENANT_MODE field ou database cada tenant tem a sua cadeia
+	tenants := database.NewTenants(client.Database(cfg.Mongo.Database), cfg.Tenant.Mode, cfg.Tenant.Allowed, log)
+	repoConsentimento := repository.NewRepoConsentimentoMongoDB(tenants, cfg.Mongo.Collections.Consentimento, signer, log)
+	repoAcesso := repository.NewRepoAcessoMongoDB(tenants, cfg.Mongo.Collections.Acesso, cfg.Audit.AccessLogRetention(), signer, log)
+	chains := []chain{
+		{"consentimento", repoConsentimento.Chain(), repoConsentimento.EnsureIndexes, repoConsentimento.VerifyAllChains},
+		{"acesso", repoAcesso.Chain(), repoAcesso.EnsureIndexes, repoAcesso.VerifyAllChains},
+	}
+
+	ctx := context.Background()
+	broken := false
+	for _, c := range chains {
+		if cadeia != "todas" && cadeia != c.name {
+			continue
+		}
+		if err := c.indexes(ctx); err != nil {
+			log.Error("Erro ao criar os índices da collection "+c.name, "err", err.Error())
+		}
+		if checkpoint {
+			n, err := c.hashes.Checkpoint(ctx)
+			if err != nil {
+				log.Error("Erro ao criar os checkpoints", "cadeia", c.name, "err", err.Error())
+				return err
+			}
+			log.Info("Checkpoints criados", "cadeia", c.name, "checkpoints", n)
+		}
+
+		reports, err := c.verifyAll(ctx)
+		if err != nil {
+			log.Error("Verificação interrompida", "cadeia", c.name, "err", err.Error())
+			return err
+		}
+		for _, r := range reports {
+			args := []any{"cadeia", c.name, "database", r.Database, "tenant", r.Tenant, "verificados", r.Checked,
+				"sem_encadeamento", r.Unchained, "primeiro_seq", r.FirstSeq, "ultimo_seq", r.LastSeq, "checkpoints", r.Checkpoints,
+				"checkpoints_nao_conferidos", r.Unverified}
+			if r.OK() {
+				log.Info("Cadeia íntegra", args...)
+				continue
+			}
+			broken = true
+			log.Error("Cadeia quebrada", append(args, "seq", r.Broken.Seq, "registro", r.Broken.ID, "motivo", r.Broken.Reason)...)
+		}
+	}
+	if broken {
+		return fmt.Errorf("cadeia de auditoria quebrada")
+	}
+	return nil
+}
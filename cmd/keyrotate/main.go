@@ -0,0 +1,85 @@
+// Comando que recifra os clientes com a chave ativa do keyring (rotação de chaves).
+//
+// Uso: KEYRING_FILE=/run/secrets/keyring.json go run cmd/keyrotate/main.go -batch 500
+//
+// Para rotacionar: adicione a nova chave em "keys", aponte "active_key" para ela, aguarde a API
+// recarregar o arquivo (novas gravações já usam a nova chave) e rode este comando. A chave antiga só pode ser removida
+// do arquivo depois que o comando terminar sem erros.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/valdinei-santos/cpf-backend/internal/infra/config"
+	"github.com/valdinei-santos/cpf-backend/internal/infra/database"
+	"github.com/valdinei-santos/cpf-backend/internal/infra/keyring"
+	"github.com/valdinei-santos/cpf-backend/internal/infra/logger"
+	"github.com/valdinei-santos/cpf-backend/internal/modules/cliente/infra/repository"
+)
+
+func main() {
+	batch := flag.Int64("batch", 500, "quantidade de registros recifrados por lote")
+	configFlags := config.RegisterFlags(flag.CommandLine)
+	flag.Parse()
+
+	cfg, err := config.LoadConfig(configFlags)
+	if err != nil {
+		fmt.Printf("Erro ao carregar a configuração: %v\n", err)
+		os.Exit(1)
+	}
+	if cfg.Crypto.KeyringFile == "" {
+		fmt.Println("KEYRING_FILE não configurado")
+		os.Exit(1)
+	}
+	if *batch < 1 {
+		fmt.Println("o tamanho do lote deve ser maior que zero")
+		os.Exit(1)
+	}
+
+	log := logger.NewSlogILogger(cfg.Log)
+
+	cipher, err := keyring.Load(cfg.Crypto.KeyringFile)
+	if err != nil {
+		fmt.Printf("Erro ao carregar o arquivo de chaves: %v\n", err)
+		os.Exit(1)
+	}
+
+	if err := rotate(log, cfg, cipher, *batch); err != nil {
+		os.Exit(1)
+	}
+}
+
+// rotate conecta no banco e recifra os clientes. Separado do main para que a conexão
+// seja fechada antes do os.Exit.
+func rotate(log logger.ILogger, cfg *config.Config, cipher *keyring.Keyring, batch int64) error {
+	client, err := database.ConnectDB(log, cfg.Mongo)
+	if err != nil {
+		log.Error("Erro ao conectar no database", "err", err.Error())
+		return err
+	}
+	defer func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		database.DisconnectDB(log, client, ctx)
+	}()
+
+	// No TENANT_MODE=database a rotação percorre o database de cada tenant
+	tenants := database.NewTenants(client.Database(cfg.Mongo.Database), cfg.Tenant.Mode, cfg.Tenant.Allowed, log)
+	repo := repository.NewRepoClienteMongoDB(tenants, cfg.Mongo.Collections.Cliente, log, cipher, cfg.Crypto.EncryptTelefone)
+	if err := repo.EnsureIndexes(context.Background()); err != nil {
+		log.Error("Erro ao criar os índices da collection "+cfg.Mongo.Collections.Cliente, "err", err.Error())
+	}
+
+	log.Info("Iniciando a rotação de chaves", "enc_key", cipher.ActiveKeyID(), "batch", batch)
+	total, err := repo.RotateEncryption(context.Background(), batch)
+	if err != nil {
+		log.Error("Rotação de chaves interrompida", "recifrados", total, "err", err.Error())
+		return err
+	}
+	log.Info("Rotação de chaves concluída", "recifrados", total)
+	return nil
+}
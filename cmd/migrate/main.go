@@ -0,0 +1,143 @@
+// Comando que aplica, reverte e lista as migrações versionadas do MongoDB (índices, regras de
+// validação e evolução dos documentos).
+//
+// Uso: go run cmd/migrate/main.go [flags] up|down|status
+//
+//	up     aplica as migrações pendentes; -to N aplica só até a versão N e -reapply executa de novo
+//	       as já aplicadas (tenants criados depois delas no TENANT_MODE=database)
+//	down   reverte a última migração aplicada; -steps N reverte as N últimas
+//	status lista as versões com a situação de cada uma
+//
+// Uma trava na collection das migrações impede duas execuções ao mesmo tempo. Termina com código 1
+// se alguma migração falhar.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/valdinei-santos/cpf-backend/internal/infra/config"
+	"github.com/valdinei-santos/cpf-backend/internal/infra/database"
+	"github.com/valdinei-santos/cpf-backend/internal/infra/logger"
+	"github.com/valdinei-santos/cpf-backend/internal/infra/migration"
+)
+
+func main() {
+	configFlags := config.RegisterFlags(flag.CommandLine)
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Uso: %s [flags] up [-to N] [-reapply] | down [-steps N] | status\n", os.Args[0])
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	// Flags próprias de cada subcomando, depois do nome dele
+	command := flag.Arg(0)
+	sub := flag.NewFlagSet(command, flag.ExitOnError)
+	to := sub.Int64("to", 0, "versão final do up; 0 aplica todas as pendentes")
+	reapply := sub.Bool("reapply", false, "no up, executa de novo as migrações já aplicadas antes das pendentes")
+	steps := sub.Int("steps", 1, "quantidade de migrações revertidas pelo down")
+	switch command {
+	case "up", "down", "status":
+		sub.Parse(flag.Args()[1:])
+	default:
+		flag.Usage()
+		os.Exit(2)
+	}
+	if *to < 0 {
+		fmt.Println("a versão do -to não pode ser negativa")
+		os.Exit(1)
+	}
+	if *steps < 1 {
+		fmt.Println("o -steps deve ser maior que zero")
+		os.Exit(1)
+	}
+
+	cfg, err := config.LoadConfig(configFlags)
+	if err != nil {
+		fmt.Printf("Erro ao carregar a configuração: %v\n", err)
+		os.Exit(1)
+	}
+
+	log := logger.NewSlogILogger(cfg.Log)
+	if err := run(log, cfg, command, *to, *reapply, *steps); err != nil {
+		os.Exit(1)
+	}
+}
+
+// run conecta no banco e executa o subcomando. Separado do main para que a conexão seja fechada
+// antes do os.Exit.
+func run(log logger.ILogger, cfg *config.Config, command string, to int64, reapply bool, steps int) error {
+	client, err := database.ConnectDB(log, cfg.Mongo)
+	if err != nil {
+		log.Error("Erro ao conectar no database", "err", err.Error())
+		return err
+	}
+	defer func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		database.DisconnectDB(log, client, ctx)
+	}()
+
+	db := client.Database(cfg.Mongo.Database)
+	migrator, err := migration.New(migration.Env{
+		DB:          db,
+		Tenants:     database.NewTenants(db, cfg.Tenant.Mode, cfg.Tenant.Allowed, log),
+		Collections: cfg.Mongo.Collections,
+	}, migration.All(), cfg.Migration, log)
+	if err != nil {
+		log.Error("Erro nas migrações", "err", err.Error())
+		return err
+	}
+
+	ctx := context.Background()
+	switch command {
+	case "up":
+		if reapply {
+			reapplied, err := migrator.Reapply(ctx)
+			if err != nil {
+				log.Error("Reaplicação interrompida", "reaplicadas", reapplied, "err", err.Error())
+				return err
+			}
+			log.Info("Migrações reaplicadas", "reaplicadas", reapplied)
+		}
+		applied, err := migrator.Up(ctx, to)
+		if err != nil {
+			log.Error("Migrações interrompidas", "aplicadas", applied, "err", err.Error())
+			return err
+		}
+		log.Info("Migrações concluídas", "aplicadas", applied)
+	case "down":
+		reverted, err := migrator.Down(ctx, steps)
+		if err != nil {
+			log.Error("Reversão interrompida", "revertidas", reverted, "err", err.Error())
+			return err
+		}
+		log.Info("Reversão concluída", "revertidas", reverted)
+	case "status":
+		list, err := migrator.Status(ctx)
+		if err != nil {
+			log.Error("Erro ao consultar as migrações", "err", err.Error())
+			return err
+		}
+		printStatus(list)
+	}
+	return nil
+}
+
+// printStatus exibe a situação das versões em uma tabela
+func printStatus(list []migration.Status) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "VERSÃO\tSITUAÇÃO\tAPLICADA EM\tDESCRIÇÃO")
+	for _, s := range list {
+		appliedAt := "-"
+		if s.AppliedAt != nil {
+			appliedAt = s.AppliedAt.Local().Format(time.DateTime)
+		}
+		fmt.Fprintf(w, "%d\t%s\t%s\t%s\n", s.Version, s.Status, appliedAt, s.Description)
+	}
+	w.Flush()
+}
@@ -15,16 +15,126 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/": {
+        "/admin/log/level": {
             "get": {
-                "description": "Retorna uma lista de clientes, paginada",
+                "description": "Retorna o nível atual do log desta instância",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "clientes"
+                    "admin"
                 ],
-                "summary": "Lista todos os clientes",
+                "summary": "Nível do log",
+                "parameters": [
+                    {
+                        "enum": [
+                            "pt-BR",
+                            "en-US"
+                        ],
+                        "type": "string",
+                        "description": "Idioma das mensagens de erro: pt-BR (padrão) ou en-US",
+                        "name": "Accept-Language",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/LogLevel"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/Problem"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Altera o nível do log desta instância sem reiniciar a API. Vale até o próximo reinício, quando volta o LOG_LEVEL. Exige a permissão log:manage",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Altera o nível do log",
+                "parameters": [
+                    {
+                        "description": "Novo nível: debug, info, warn ou error",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/LogLevel"
+                        }
+                    },
+                    {
+                        "enum": [
+                            "pt-BR",
+                            "en-US"
+                        ],
+                        "type": "string",
+                        "description": "Idioma das mensagens de erro: pt-BR (padrão) ou en-US",
+                        "name": "Accept-Language",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/LogLevel"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/Problem"
+                        }
+                    },
+                    "422": {
+                        "description": "Nível inválido",
+                        "schema": {
+                            "$ref": "#/definitions/Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/apikey": {
+            "get": {
+                "description": "Retorna as chaves de API, paginadas. Os segredos nunca são retornados.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "apikeys"
+                ],
+                "summary": "Lista as chaves de API",
                 "parameters": [
                     {
                         "type": "integer",
@@ -39,28 +149,41 @@ const docTemplate = `{
                         "description": "Quantidade de itens na página a ser retornada",
                         "name": "size",
                         "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "pt-BR",
+                            "en-US"
+                        ],
+                        "type": "string",
+                        "description": "Idioma das mensagens de erro: pt-BR (padrão) ou en-US",
+                        "name": "Accept-Language",
+                        "in": "header"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/dto.ResponseManyPaginated"
-                            }
+                            "$ref": "#/definitions/ApiKeysPaginated"
+                        }
+                    },
+                    "403": {
+                        "description": "Permissão insuficiente",
+                        "schema": {
+                            "$ref": "#/definitions/Problem"
                         }
                     },
                     "500": {
                         "description": "Erro interno do servidor",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/Problem"
                         }
                     }
                 }
             },
             "post": {
-                "description": "Cria um novo cliente com os dados fornecidos",
+                "description": "Cria uma chave de API para clientes serviço a serviço. O segredo é exibido somente nesta resposta.",
                 "consumes": [
                     "application/json"
                 ],
@@ -68,137 +191,240 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "clientes"
+                    "apikeys"
                 ],
-                "summary": "Cria um novo cliente",
+                "summary": "Cria uma chave de API",
                 "parameters": [
                     {
-                        "description": "Dados do cliente a ser criado",
-                        "name": "cliente",
+                        "description": "Dados da chave",
+                        "name": "apikey",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.Request"
+                            "$ref": "#/definitions/ApiKeyRequest"
                         }
+                    },
+                    {
+                        "enum": [
+                            "pt-BR",
+                            "en-US"
+                        ],
+                        "type": "string",
+                        "description": "Idioma das mensagens de erro: pt-BR (padrão) ou en-US",
+                        "name": "Accept-Language",
+                        "in": "header"
                     }
                 ],
                 "responses": {
                     "201": {
                         "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/dto.Response"
+                            "$ref": "#/definitions/ApiKeySecretResponse"
                         }
                     },
                     "400": {
                         "description": "Erro na requisição",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Permissão insuficiente",
+                        "schema": {
+                            "$ref": "#/definitions/Problem"
+                        }
+                    },
+                    "422": {
+                        "description": "Campos inválidos, todos listados em errors",
+                        "schema": {
+                            "$ref": "#/definitions/Problem"
                         }
                     }
                 }
             }
         },
-        "/ping": {
-            "get": {
-                "description": "Retorna pong se estiver tudo ok com a API",
-                "consumes": [
-                    "application/json"
-                ],
+        "/apikey/{id}/revogar": {
+            "post": {
+                "description": "Revoga a chave. O registro é mantido para auditoria e a chave não pode ser reativada.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "util"
+                    "apikeys"
+                ],
+                "summary": "Revoga uma chave de API",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID da chave",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "pt-BR",
+                            "en-US"
+                        ],
+                        "type": "string",
+                        "description": "Idioma das mensagens de erro: pt-BR (padrão) ou en-US",
+                        "name": "Accept-Language",
+                        "in": "header"
+                    }
                 ],
-                "summary": "Retorna pong",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/ApiKeyResponse"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "403": {
+                        "description": "Permissão insuficiente",
+                        "schema": {
+                            "$ref": "#/definitions/Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Chave não encontrada",
+                        "schema": {
+                            "$ref": "#/definitions/Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Chave já revogada",
                         "schema": {
-                            "$ref": "#/definitions/dto.OutputDefault"
+                            "$ref": "#/definitions/Problem"
                         }
                     }
                 }
             }
         },
-        "/status": {
-            "get": {
-                "description": "Retorna o status da API",
-                "consumes": [
-                    "application/json"
-                ],
+        "/apikey/{id}/rotacionar": {
+            "post": {
+                "description": "Gera um novo segredo para a chave. O segredo anterior deixa de ser aceito imediatamente.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "util"
+                    "apikeys"
+                ],
+                "summary": "Rotaciona o segredo de uma chave de API",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID da chave",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "pt-BR",
+                            "en-US"
+                        ],
+                        "type": "string",
+                        "description": "Idioma das mensagens de erro: pt-BR (padrão) ou en-US",
+                        "name": "Accept-Language",
+                        "in": "header"
+                    }
                 ],
-                "summary": "Retorna o status da API",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/ApiKeySecretResponse"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "403": {
+                        "description": "Permissão insuficiente",
                         "schema": {
-                            "$ref": "#/definitions/dto.OutputDefault"
+                            "$ref": "#/definitions/Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Chave não encontrada",
+                        "schema": {
+                            "$ref": "#/definitions/Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Chave revogada",
+                        "schema": {
+                            "$ref": "#/definitions/Problem"
                         }
                     }
                 }
             }
         },
-        "/{id}": {
+        "/cliente": {
             "get": {
-                "description": "Retorna um cliente específico com base no ID fornecido",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Retorna uma lista de clientes, paginada",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "clientes"
                 ],
-                "summary": "Retorna um cliente pelo ID",
+                "summary": "Lista todos os clientes",
                 "parameters": [
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "description": "Numero da página a ser retornada",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "description": "Quantidade de itens na página a ser retornada",
+                        "name": "size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Retorna documento e telefone completos (exige permissão e é auditado)",
+                        "name": "unmask",
+                        "in": "query"
+                    },
                     {
                         "type": "string",
-                        "description": "Cliente ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Finalidade do acesso, registrada no log de acesso",
+                        "name": "X-Access-Purpose",
+                        "in": "header"
+                    },
+                    {
+                        "enum": [
+                            "pt-BR",
+                            "en-US"
+                        ],
+                        "type": "string",
+                        "description": "Idioma das mensagens de erro: pt-BR (padrão) ou en-US",
+                        "name": "Accept-Language",
+                        "in": "header"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dto.Response"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/ClientesPaginated"
+                            }
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "500": {
+                        "description": "Erro interno do servidor",
                         "schema": {
-                            "$ref": "#/definitions/dto.OutputDefault"
+                            "$ref": "#/definitions/Problem"
                         }
                     }
                 }
             },
-            "put": {
-                "description": "Atualiza um cliente existente com base no ID",
+            "post": {
+                "description": "Cria um novo cliente com os dados fornecidos",
                 "consumes": [
                     "application/json"
                 ],
@@ -208,91 +434,1044 @@ const docTemplate = `{
                 "tags": [
                     "clientes"
                 ],
-                "summary": "Atualiza um cliente pelo ID",
+                "summary": "Cria um novo cliente",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Cliente ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Dados do cliente para atualização",
+                        "description": "Dados do cliente a ser criado",
                         "name": "cliente",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.Request"
+                            "$ref": "#/definitions/ClienteRequest"
                         }
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Retorna documento e telefone completos (exige permissão e é auditado)",
+                        "name": "unmask",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "pt-BR",
+                            "en-US"
+                        ],
+                        "type": "string",
+                        "description": "Idioma das mensagens de erro: pt-BR (padrão) ou en-US",
+                        "name": "Accept-Language",
+                        "in": "header"
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/dto.Response"
+                            "$ref": "#/definitions/ClienteResponse"
                         }
                     },
                     "400": {
-                        "description": "Bad Request",
+                        "description": "Erro na requisição",
                         "schema": {
-                            "$ref": "#/definitions/dto.OutputDefault"
+                            "$ref": "#/definitions/Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Permissão insuficiente",
+                        "schema": {
+                            "$ref": "#/definitions/Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Documento já cadastrado",
+                        "schema": {
+                            "$ref": "#/definitions/Problem"
+                        }
+                    },
+                    "422": {
+                        "description": "Campos inválidos, todos listados em errors",
+                        "schema": {
+                            "$ref": "#/definitions/Problem"
                         }
                     }
                 }
-            },
-            "delete": {
-                "description": "Deleta um cliente específico com base no ID fornecido",
+            }
+        },
+        "/cliente/acessos": {
+            "get": {
+                "description": "Retorna, paginadas, as leituras dos dados pessoais de um cliente ou as leituras feitas por um operador (LGPD)",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "clientes"
+                    "acessos"
                 ],
-                "summary": "Deleta um cliente pelo ID",
+                "summary": "Consulta o log de acesso aos dados dos clientes",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "ID do cliente a ser deletado",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "Cliente deletado com sucesso\"}",
-                        "schema": {
-                            "$ref": "#/definitions/dto.OutputDefault"
-                        }
+                        "description": "Usuário ou serviço que acessou os dados (rota por operador)",
+                        "name": "operador",
+                        "in": "query"
                     },
-                    "404": {
-                        "description": "cliente não encontrado",
-                        "schema": {
-                            "type": "string"
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "description": "Numero da página a ser retornada",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "description": "Quantidade de itens na página a ser retornada",
+                        "name": "size",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "pt-BR",
+                            "en-US"
+                        ],
+                        "type": "string",
+                        "description": "Idioma das mensagens de erro: pt-BR (padrão) ou en-US",
+                        "name": "Accept-Language",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.AcessosPaginated"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/cliente/auditoria/verificar": {
+            "get": {
+                "description": "Percorre a cadeia de hashes do histórico de consentimentos ou do log de acesso e retorna o primeiro elo quebrado (registro alterado ou removido diretamente no banco)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auditoria"
+                ],
+                "summary": "Verifica a integridade dos registros de auditoria",
+                "parameters": [
+                    {
+                        "enum": [
+                            "consentimento",
+                            "acesso"
+                        ],
+                        "type": "string",
+                        "description": "Cadeia a verificar",
+                        "name": "cadeia",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Verifica apenas os registros do cliente",
+                        "name": "cliente_id",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "pt-BR",
+                            "en-US"
+                        ],
+                        "type": "string",
+                        "description": "Idioma das mensagens de erro: pt-BR (padrão) ou en-US",
+                        "name": "Accept-Language",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.VerificacaoResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/cliente/consentimento/{finalidade}": {
+            "get": {
+                "description": "Retorna, paginado, os clientes com consentimento vigente para a finalidade. Usado nas exportações de campanhas",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "consentimentos"
+                ],
+                "summary": "Lista os clientes que consentiram com uma finalidade",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Finalidade do consentimento",
+                        "name": "finalidade",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "description": "Numero da página a ser retornada",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "description": "Quantidade de itens na página a ser retornada",
+                        "name": "size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Retorna documento e telefone completos (exige permissão e é auditado)",
+                        "name": "unmask",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Finalidade do acesso, registrada no log de acesso",
+                        "name": "X-Access-Purpose",
+                        "in": "header"
+                    },
+                    {
+                        "enum": [
+                            "pt-BR",
+                            "en-US"
+                        ],
+                        "type": "string",
+                        "description": "Idioma das mensagens de erro: pt-BR (padrão) ou en-US",
+                        "name": "Accept-Language",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ClientesConsentimentoPaginated"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/Problem"
+                        }
+                    },
+                    "422": {
+                        "description": "Finalidade inválida",
+                        "schema": {
+                            "$ref": "#/definitions/Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/cliente/{id}": {
+            "get": {
+                "description": "Retorna um cliente específico com base no ID fornecido",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "clientes"
+                ],
+                "summary": "Retorna um cliente pelo ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Cliente ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Retorna documento e telefone completos (exige permissão e é auditado)",
+                        "name": "unmask",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Finalidade do acesso, registrada no log de acesso",
+                        "name": "X-Access-Purpose",
+                        "in": "header"
+                    },
+                    {
+                        "enum": [
+                            "pt-BR",
+                            "en-US"
+                        ],
+                        "type": "string",
+                        "description": "Idioma das mensagens de erro: pt-BR (padrão) ou en-US",
+                        "name": "Accept-Language",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/ClienteResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/Problem"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Atualiza um cliente existente com base no ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "clientes"
+                ],
+                "summary": "Atualiza um cliente pelo ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Cliente ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Dados do cliente para atualização",
+                        "name": "cliente",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/ClienteRequest"
+                        }
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Retorna documento e telefone completos (exige permissão e é auditado)",
+                        "name": "unmask",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "pt-BR",
+                            "en-US"
+                        ],
+                        "type": "string",
+                        "description": "Idioma das mensagens de erro: pt-BR (padrão) ou en-US",
+                        "name": "Accept-Language",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/ClienteResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Permissão insuficiente para bloquear ou desbloquear",
+                        "schema": {
+                            "$ref": "#/definitions/Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Documento já cadastrado para outro cliente",
+                        "schema": {
+                            "$ref": "#/definitions/Problem"
+                        }
+                    },
+                    "422": {
+                        "description": "Campos inválidos, todos listados em errors",
+                        "schema": {
+                            "$ref": "#/definitions/Problem"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Deleta um cliente específico com base no ID fornecido",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "clientes"
+                ],
+                "summary": "Deleta um cliente pelo ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID do cliente a ser deletado",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "pt-BR",
+                            "en-US"
+                        ],
+                        "type": "string",
+                        "description": "Idioma das mensagens de erro: pt-BR (padrão) ou en-US",
+                        "name": "Accept-Language",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Cliente deletado com sucesso"
+                    },
+                    "404": {
+                        "description": "cliente não encontrado",
+                        "schema": {
+                            "$ref": "#/definitions/Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/cliente/{id}/acessos": {
+            "get": {
+                "description": "Retorna, paginadas, as leituras dos dados pessoais de um cliente ou as leituras feitas por um operador (LGPD)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "acessos"
+                ],
+                "summary": "Consulta o log de acesso aos dados dos clientes",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Cliente ID (rota por cliente)",
+                        "name": "id",
+                        "in": "path"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Usuário ou serviço que acessou os dados (rota por operador)",
+                        "name": "operador",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "description": "Numero da página a ser retornada",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "description": "Quantidade de itens na página a ser retornada",
+                        "name": "size",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "pt-BR",
+                            "en-US"
+                        ],
+                        "type": "string",
+                        "description": "Idioma das mensagens de erro: pt-BR (padrão) ou en-US",
+                        "name": "Accept-Language",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.AcessosPaginated"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/cliente/{id}/consentimento": {
+            "get": {
+                "description": "Retorna o registro vigente de cada finalidade consentida ou revogada pelo cliente",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "consentimentos"
+                ],
+                "summary": "Retorna o status atual dos consentimentos",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Cliente ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "pt-BR",
+                            "en-US"
+                        ],
+                        "type": "string",
+                        "description": "Idioma das mensagens de erro: pt-BR (padrão) ou en-US",
+                        "name": "Accept-Language",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ConsentimentosResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/Problem"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Registra a concessão de um consentimento (LGPD) do cliente para uma finalidade",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "consentimentos"
+                ],
+                "summary": "Concede um consentimento",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Cliente ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Dados do consentimento",
+                        "name": "consentimento",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.ConsentimentoRequest"
+                        }
+                    },
+                    {
+                        "enum": [
+                            "pt-BR",
+                            "en-US"
+                        ],
+                        "type": "string",
+                        "description": "Idioma das mensagens de erro: pt-BR (padrão) ou en-US",
+                        "name": "Accept-Language",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ConsentimentoResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/Problem"
+                        }
+                    },
+                    "422": {
+                        "description": "Campos inválidos, todos listados em errors",
+                        "schema": {
+                            "$ref": "#/definitions/Problem"
                         }
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "dto.OutputDefault": {
+        },
+        "/cliente/{id}/consentimento/historico": {
+            "get": {
+                "description": "Retorna todas as concessões e revogações do cliente em ordem cronológica",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "consentimentos"
+                ],
+                "summary": "Retorna o histórico de consentimentos",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Cliente ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "pt-BR",
+                            "en-US"
+                        ],
+                        "type": "string",
+                        "description": "Idioma das mensagens de erro: pt-BR (padrão) ou en-US",
+                        "name": "Accept-Language",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ConsentimentosResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/cliente/{id}/consentimento/{finalidade}/revogar": {
+            "post": {
+                "description": "Registra a revogação do consentimento vigente do cliente para a finalidade",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "consentimentos"
+                ],
+                "summary": "Revoga um consentimento",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Cliente ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Finalidade do consentimento",
+                        "name": "finalidade",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Canal da revogação",
+                        "name": "revogacao",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.RevogacaoRequest"
+                        }
+                    },
+                    {
+                        "enum": [
+                            "pt-BR",
+                            "en-US"
+                        ],
+                        "type": "string",
+                        "description": "Idioma das mensagens de erro: pt-BR (padrão) ou en-US",
+                        "name": "Accept-Language",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ConsentimentoResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/Problem"
+                        }
+                    },
+                    "422": {
+                        "description": "Canal inválido",
+                        "schema": {
+                            "$ref": "#/definitions/Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/health/live": {
+            "get": {
+                "description": "Retorna 200 enquanto o processo estiver atendendo, sem verificar as dependências",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "util"
+                ],
+                "summary": "Liveness da API",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/health/ready": {
+            "get": {
+                "description": "Verifica as dependências (MongoDB e workers) e retorna a situação e a latência de cada uma. O resultado fica em cache por HEALTH_CACHE_TTL",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "util"
+                ],
+                "summary": "Readiness da API",
+                "responses": {
+                    "200": {
+                        "description": "status up ou degraded",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "503": {
+                        "description": "verificação crítica com falha",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/metrics": {
+            "get": {
+                "description": "Métricas no formato texto do Prometheus: requisições HTTP por rota, método e status, latência das requisições e dos comandos do MongoDB, pool de conexões e runtime do Go",
+                "produces": [
+                    "text/plain"
+                ],
+                "tags": [
+                    "util"
+                ],
+                "summary": "Métricas da API",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/ping": {
+            "get": {
+                "description": "Retorna pong se estiver tudo ok com a API",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "util"
+                ],
+                "summary": "Retorna pong",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/status": {
+            "get": {
+                "description": "Resumo das métricas do /metrics: requisições por rota e status, latência, comandos e pool do MongoDB e runtime do Go",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "util"
+                ],
+                "summary": "Retorna o status da API",
+                "parameters": [
+                    {
+                        "enum": [
+                            "pt-BR",
+                            "en-US"
+                        ],
+                        "type": "string",
+                        "description": "Idioma das mensagens de erro: pt-BR (padrão) ou en-US",
+                        "name": "Accept-Language",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/Problem"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "ApiKeyRequest": {
+            "type": "object",
+            "properties": {
+                "expires_at": {
+                    "type": "string"
+                },
+                "nome": {
+                    "type": "string"
+                },
+                "owner": {
+                    "type": "string"
+                },
+                "scopes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "tenant": {
+                    "type": "string"
+                }
+            }
+        },
+        "ApiKeyResponse": {
+            "type": "object",
+            "properties": {
+                "ativa": {
+                    "type": "boolean"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "expires_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "last_used_at": {
+                    "type": "string"
+                },
+                "nome": {
+                    "type": "string"
+                },
+                "owner": {
+                    "type": "string"
+                },
+                "prefix": {
+                    "type": "string"
+                },
+                "revoked_at": {
+                    "type": "string"
+                },
+                "scopes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "tenant": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "ApiKeySecretResponse": {
             "type": "object",
             "properties": {
-                "detail": {
+                "ativa": {
+                    "type": "boolean"
+                },
+                "created_at": {
                     "type": "string"
                 },
-                "instance": {
+                "expires_at": {
                     "type": "string"
                 },
-                "title": {
+                "id": {
+                    "type": "string"
+                },
+                "last_used_at": {
+                    "type": "string"
+                },
+                "nome": {
+                    "type": "string"
+                },
+                "owner": {
+                    "type": "string"
+                },
+                "prefix": {
+                    "type": "string"
+                },
+                "revoked_at": {
+                    "type": "string"
+                },
+                "scopes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "secret": {
+                    "type": "string"
+                },
+                "tenant": {
+                    "type": "string"
+                },
+                "updated_at": {
                     "type": "string"
                 }
             }
         },
-        "dto.Request": {
+        "ApiKeysPaginated": {
+            "type": "object",
+            "properties": {
+                "api_keys": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/ApiKeyResponse"
+                    }
+                },
+                "currentPage": {
+                    "type": "integer"
+                },
+                "itemsPerPage": {
+                    "type": "integer"
+                },
+                "totalItems": {
+                    "type": "integer"
+                },
+                "totalPages": {
+                    "type": "integer"
+                }
+            }
+        },
+        "ClienteRequest": {
             "type": "object",
             "properties": {
                 "bloqueado": {
@@ -310,7 +1489,7 @@ const docTemplate = `{
                 }
             }
         },
-        "dto.Response": {
+        "ClienteResponse": {
             "type": "object",
             "properties": {
                 "bloqueado": {
@@ -336,18 +1515,195 @@ const docTemplate = `{
                 }
             }
         },
-        "dto.ResponseManyPaginated": {
+        "ClientesPaginated": {
+            "type": "object",
+            "properties": {
+                "clientes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/ClienteResponse"
+                    }
+                },
+                "currentPage": {
+                    "type": "integer"
+                },
+                "itemsPerPage": {
+                    "type": "integer"
+                },
+                "totalItems": {
+                    "type": "integer"
+                },
+                "totalPages": {
+                    "type": "integer"
+                }
+            }
+        },
+        "FieldProblem": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "example": "cliente.nome_invalid"
+                },
+                "detail": {
+                    "description": "No idioma do Accept-Language",
+                    "type": "string",
+                    "example": "o nome deve ter entre 3 e 50 caracteres"
+                },
+                "field": {
+                    "type": "string",
+                    "example": "nome"
+                }
+            }
+        },
+        "LogLevel": {
+            "type": "object",
+            "required": [
+                "level"
+            ],
+            "properties": {
+                "level": {
+                    "description": "debug, info, warn ou error",
+                    "type": "string",
+                    "example": "debug"
+                }
+            }
+        },
+        "Problem": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "Código estável do erro",
+                    "type": "string",
+                    "example": "cliente.not_found"
+                },
+                "detail": {
+                    "description": "Mensagem do código, no idioma do Accept-Language",
+                    "type": "string",
+                    "example": "cliente não encontrado"
+                },
+                "errors": {
+                    "description": "Campos inválidos, nas respostas 422",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/FieldProblem"
+                    }
+                },
+                "instance": {
+                    "type": "string",
+                    "example": "/api/v1/cliente/43310521-a934-11f0-ae2b-fabc94dc9b50"
+                },
+                "request_id": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "integer",
+                    "example": 404
+                },
+                "title": {
+                    "description": "Descrição do status, no idioma do Accept-Language",
+                    "type": "string",
+                    "example": "recurso não encontrado"
+                },
+                "trace_id": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string",
+                    "example": "urn:cpf-backend:problem:cliente.not_found"
+                }
+            }
+        },
+        "dto.AcessoResponse": {
+            "type": "object",
+            "properties": {
+                "acao": {
+                    "type": "string"
+                },
+                "cliente_id": {
+                    "type": "string"
+                },
+                "completo": {
+                    "type": "boolean"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "finalidade": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "ip": {
+                    "type": "string"
+                },
+                "metodo": {
+                    "type": "string"
+                },
+                "operador": {
+                    "type": "string"
+                },
+                "recurso": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.AcessosPaginated": {
+            "type": "object",
+            "properties": {
+                "acessos": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.AcessoResponse"
+                    }
+                },
+                "cliente_id": {
+                    "type": "string"
+                },
+                "currentPage": {
+                    "type": "integer"
+                },
+                "itemsPerPage": {
+                    "type": "integer"
+                },
+                "operador": {
+                    "type": "string"
+                },
+                "totalItems": {
+                    "type": "integer"
+                },
+                "totalPages": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.ClienteConsentimento": {
+            "type": "object",
+            "properties": {
+                "cliente": {
+                    "$ref": "#/definitions/ClienteResponse"
+                },
+                "consentimento": {
+                    "$ref": "#/definitions/dto.ConsentimentoResponse"
+                }
+            }
+        },
+        "dto.ClientesConsentimentoPaginated": {
             "type": "object",
             "properties": {
                 "clientes": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/dto.Response"
+                        "$ref": "#/definitions/dto.ClienteConsentimento"
                     }
                 },
                 "currentPage": {
                     "type": "integer"
                 },
+                "finalidade": {
+                    "type": "string"
+                },
                 "itemsPerPage": {
                     "type": "integer"
                 },
@@ -358,6 +1714,133 @@ const docTemplate = `{
                     "type": "integer"
                 }
             }
+        },
+        "dto.ConsentimentoRequest": {
+            "type": "object",
+            "properties": {
+                "base_legal": {
+                    "type": "string"
+                },
+                "canal": {
+                    "type": "string"
+                },
+                "finalidade": {
+                    "type": "string"
+                },
+                "versao_politica": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.ConsentimentoResponse": {
+            "type": "object",
+            "properties": {
+                "base_legal": {
+                    "type": "string"
+                },
+                "canal": {
+                    "type": "string"
+                },
+                "cliente_id": {
+                    "type": "string"
+                },
+                "concedido_em": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "finalidade": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "revogado_em": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "versao_politica": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.ConsentimentosResponse": {
+            "type": "object",
+            "properties": {
+                "cliente_id": {
+                    "type": "string"
+                },
+                "consentimentos": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.ConsentimentoResponse"
+                    }
+                }
+            }
+        },
+        "dto.EloQuebradoDTO": {
+            "type": "object",
+            "properties": {
+                "motivo": {
+                    "type": "string"
+                },
+                "registro": {
+                    "description": "ID do registro, vazio quando ele foi removido",
+                    "type": "string"
+                },
+                "seq": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.RevogacaoRequest": {
+            "type": "object",
+            "properties": {
+                "canal": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.VerificacaoResponse": {
+            "type": "object",
+            "properties": {
+                "cadeia": {
+                    "type": "string"
+                },
+                "checkpoints": {
+                    "description": "Checkpoints assinados conferidos",
+                    "type": "integer"
+                },
+                "checkpoints_nao_conferidos": {
+                    "description": "Checkpoints de outra chave, ignorados",
+                    "type": "integer"
+                },
+                "cliente_id": {
+                    "type": "string"
+                },
+                "elo_quebrado": {
+                    "$ref": "#/definitions/dto.EloQuebradoDTO"
+                },
+                "integra": {
+                    "type": "boolean"
+                },
+                "primeiro_seq": {
+                    "type": "integer"
+                },
+                "sem_encadeamento": {
+                    "description": "Registros anteriores ao encadeamento",
+                    "type": "integer"
+                },
+                "ultimo_seq": {
+                    "type": "integer"
+                },
+                "verificados": {
+                    "type": "integer"
+                }
+            }
         }
     }
 }`
@@ -366,7 +1849,7 @@ const docTemplate = `{
 var SwaggerInfo = &swag.Spec{
 	Version:          "1.0",
 	Host:             "localhost:8889",
-	BasePath:         "/api/v1/cliente",
+	BasePath:         "/api/v1",
 	Schemes:          []string{},
 	Title:            "CPF Management API",
 	Description:      "Está API gerencia CPFs.",
@@ -7,9 +7,17 @@ var (
 	ErrNotFound             = errors.New("não encontrado")
 	ErrNotNil               = errors.New("não pode ser nil")
 	ErrBadRequest           = errors.New("erro nos dados do request")
+	ErrValidation           = errors.New("um ou mais campos são inválidos")
 	ErrSaveInDatabase       = errors.New("erro ao salvar no banco de dados")
 	ErrConnectionInDatabase = errors.New("erro de conexão com o banco de dados")
 	ErrInternal             = errors.New("erro interno no servidor")
+	ErrForbidden            = errors.New("permissão insuficiente para a operação")
+	ErrUnauthorized         = errors.New("credencial de acesso ausente ou inválida")
+	ErrTooManyRequests      = errors.New("limite de requisições excedido, tente novamente mais tarde")
+	ErrTimeout              = errors.New("a operação não terminou dentro do prazo")
+	ErrCanceled             = errors.New("requisição cancelada pelo cliente")
+	ErrRouteNotFound        = errors.New("rota não encontrada")
+	ErrMethodNotAllowed     = errors.New("método não suportado pela rota, veja o header Allow")
 	ErrHttp400              = errors.New("dados de requisição inválidos")
 	ErrHttp401              = errors.New("não autenticado")
 	ErrHttp403              = errors.New("acesso não permitido")
@@ -18,6 +26,8 @@ var (
 	ErrHttp409              = errors.New("conflito de recurso")
 	ErrHttp422              = errors.New("entidade inutilizável")
 	ErrHttp429              = errors.New("muitas requisições")
+	ErrHttp499              = errors.New("requisição cancelada")
 	ErrHttp500              = errors.New("erro inesperado no servidor")
 	ErrHttp503              = errors.New("serviço indisponível")
+	ErrHttp504              = errors.New("tempo de resposta esgotado")
 )
@@ -0,0 +1,67 @@
+package globalerr
+
+import "strings"
+
+// FieldError é o erro de validação de um campo da entrada
+type FieldError struct {
+	Field string // Nome do campo no JSON da requisição. Ex: nome, documento
+	Err   error  // Erro do domínio. Ex: domainerr.ErrClienteNomeInvalid
+}
+
+func (e *FieldError) Error() string {
+	return e.Field + ": " + e.Err.Error()
+}
+
+func (e *FieldError) Unwrap() error {
+	return e.Err
+}
+
+// ValidationError reúne os erros de todos os campos inválidos da entrada, para que o chamador
+// corrija todos de uma vez. errors.Is encontra o erro de qualquer um dos campos.
+type ValidationError struct {
+	Fields []*FieldError
+}
+
+func (e *ValidationError) Error() string {
+	msgs := make([]string, len(e.Fields))
+	for i, f := range e.Fields {
+		msgs[i] = f.Error()
+	}
+	return strings.Join(msgs, "; ")
+}
+
+func (e *ValidationError) Unwrap() []error {
+	errs := make([]error, len(e.Fields))
+	for i, f := range e.Fields {
+		errs[i] = f
+	}
+	return errs
+}
+
+// Validation acumula os erros dos campos durante a criação de uma entidade:
+//
+//	var v globalerr.Validation
+//	nomeVO, err := vo.NewNomeCliente(nome)
+//	v.Add("nome", err)
+//	...
+//	if err := v.Err(); err != nil {
+//		return nil, err
+//	}
+type Validation struct {
+	fields []*FieldError
+}
+
+// Add registra o erro do campo. Erro nil é ignorado.
+func (v *Validation) Add(field string, err error) {
+	if err != nil {
+		v.fields = append(v.fields, &FieldError{Field: field, Err: err})
+	}
+}
+
+// Err retorna o *ValidationError com os campos inválidos, ou nil se todos são válidos
+func (v *Validation) Err() error {
+	if len(v.fields) == 0 {
+		return nil
+	}
+	return &ValidationError{Fields: v.fields}
+}
@@ -10,6 +10,7 @@ import (
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
+// ID é o identificador (UUID) das entidades, compartilhado pelos módulos
 type ID uuid.UUID
 
 func NewUUID(log logger.ILogger) (ID, error) {
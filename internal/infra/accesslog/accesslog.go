@@ -0,0 +1,134 @@
+// Pacote com o log de acesso HTTP: uma linha estruturada por requisição, com o corpo da requisição
+// e da resposta opcionalmente, sem os dados pessoais.
+package accesslog
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/valdinei-santos/cpf-backend/internal/infra/logger"
+)
+
+// Mensagem das linhas do log de acesso, para filtrá-las no log
+const message = "http_request"
+
+// Options são as configurações do log de acesso. As tags definem a chave no arquivo YAML e o
+// sufixo da variável de ambiente (HTTP_LOG_*), ver o pacote config.
+type Options struct {
+	Enabled      bool     `yaml:"enabled" env:"ENABLED"`
+	SkipRoutes   []string `yaml:"skip_routes" env:"SKIP_ROUTES"`       // Rotas sem log. Ex: /health/live, /metrics
+	Bodies       bool     `yaml:"bodies" env:"BODIES"`                 // Inclui o corpo JSON da requisição e da resposta
+	MaxBodyBytes int      `yaml:"max_body_bytes" env:"MAX_BODY_BYTES"` // Corpos maiores não são incluídos
+	RedactFields []string `yaml:"redact_fields" env:"REDACT_FIELDS"`   // Campos dos corpos substituídos por [REDACTED], sem diferenciar maiúsculas
+}
+
+// Middleware grava uma linha por requisição com o método, o template da rota, o status, a duração,
+// os bytes da resposta e o IP. O id da requisição, o usuário, o tenant e o trace são acrescentados
+// pelo logger a partir do contexto. Respostas 5xx são gravadas como erro e 4xx como aviso.
+func Middleware(opts Options, log logger.ILogger) gin.HandlerFunc {
+	redactor := newRedactor(opts.RedactFields)
+	return func(c *gin.Context) {
+		if slices.Contains(opts.SkipRoutes, c.FullPath()) {
+			c.Next()
+			return
+		}
+		start := time.Now()
+
+		var reqBody []byte
+		var resp *bodyWriter
+		if opts.Bodies {
+			reqBody = readBody(c.Request, opts.MaxBodyBytes)
+			resp = &bodyWriter{ResponseWriter: c.Writer, max: opts.MaxBodyBytes}
+			c.Writer = resp
+		}
+
+		c.Next()
+
+		status := c.Writer.Status()
+		args := []any{
+			"method", c.Request.Method,
+			"route", c.FullPath(),
+			"path", c.Request.URL.Path,
+			"status", status,
+			"latency_ms", float64(time.Since(start).Microseconds()) / 1000,
+			"bytes", max(c.Writer.Size(), 0),
+			"ip", c.ClientIP(),
+			"user_agent", c.Request.UserAgent(),
+		}
+		if opts.Bodies {
+			if body, ok := redactor.redact(reqBody); ok {
+				args = append(args, "request_body", body)
+			}
+			if body, ok := redactor.redact(resp.captured()); ok {
+				args = append(args, "response_body", body)
+			}
+		}
+
+		ctx := c.Request.Context()
+		switch {
+		case status >= http.StatusInternalServerError:
+			log.ErrorContext(ctx, message, args...)
+		case status >= http.StatusBadRequest:
+			log.WarnContext(ctx, message, args...)
+		default:
+			log.InfoContext(ctx, message, args...)
+		}
+	}
+}
+
+// readBody lê o corpo da requisição até o limite e o devolve à requisição para o handler.
+// Retorna nil se o corpo for maior que o limite.
+func readBody(r *http.Request, limit int) []byte {
+	if r.Body == nil || r.Body == http.NoBody {
+		return nil
+	}
+	data, err := io.ReadAll(io.LimitReader(r.Body, int64(limit)+1))
+	r.Body = readCloser{io.MultiReader(bytes.NewReader(data), r.Body), r.Body}
+	if err != nil || len(data) > limit {
+		return nil
+	}
+	return data
+}
+
+type readCloser struct {
+	io.Reader
+	io.Closer
+}
+
+// bodyWriter guarda uma cópia do corpo da resposta até o limite
+type bodyWriter struct {
+	gin.ResponseWriter
+	buf      bytes.Buffer
+	max      int
+	overflow bool
+}
+
+func (w *bodyWriter) Write(data []byte) (int, error) {
+	w.capture(data)
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *bodyWriter) WriteString(s string) (int, error) {
+	w.capture([]byte(s))
+	return w.ResponseWriter.WriteString(s)
+}
+
+func (w *bodyWriter) capture(data []byte) {
+	if w.overflow || w.buf.Len()+len(data) > w.max {
+		w.overflow = true
+		return
+	}
+	w.buf.Write(data)
+}
+
+// captured retorna o corpo da resposta, nil se passou do limite
+func (w *bodyWriter) captured() []byte {
+	if w.overflow {
+		return nil
+	}
+	return w.buf.Bytes()
+}
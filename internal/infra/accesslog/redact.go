@@ -0,0 +1,57 @@
+package accesslog
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// Valor gravado no lugar dos campos com dados pessoais
+const redacted = "[REDACTED]"
+
+// redactor substitui os campos com dados pessoais dos corpos JSON, em qualquer nível
+type redactor struct {
+	fields map[string]bool
+}
+
+func newRedactor(fields []string) redactor {
+	r := redactor{fields: make(map[string]bool, len(fields))}
+	for _, f := range fields {
+		r.fields[strings.ToLower(f)] = true
+	}
+	return r
+}
+
+// redact retorna o corpo JSON sem os dados pessoais. Corpos vazios ou que não são JSON não
+// são gravados, pois não há como retirar os dados pessoais deles.
+func (r redactor) redact(body []byte) (json.RawMessage, bool) {
+	if len(body) == 0 {
+		return nil, false
+	}
+	var value any
+	if err := json.Unmarshal(body, &value); err != nil {
+		return nil, false
+	}
+	data, err := json.Marshal(r.walk(value))
+	if err != nil {
+		return nil, false
+	}
+	return data, true
+}
+
+func (r redactor) walk(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		for key, item := range v {
+			if r.fields[strings.ToLower(key)] {
+				v[key] = redacted
+				continue
+			}
+			v[key] = r.walk(item)
+		}
+	case []any:
+		for i, item := range v {
+			v[i] = r.walk(item)
+		}
+	}
+	return value
+}
@@ -0,0 +1,51 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/valdinei-santos/cpf-backend/internal/domain/globalerr"
+	"github.com/valdinei-santos/cpf-backend/internal/infra/identity"
+	"github.com/valdinei-santos/cpf-backend/internal/infra/logger"
+	"github.com/valdinei-santos/cpf-backend/internal/infra/problem"
+	"github.com/valdinei-santos/cpf-backend/internal/infra/requestinfo"
+)
+
+// APIKeyHeader é o header com o segredo da chave de API
+const APIKeyHeader = "X-API-Key"
+
+// APIKeyAuthenticator valida o segredo da chave de API e retorna a identidade correspondente.
+// Credencial inválida deve retornar globalerr.ErrUnauthorized.
+type APIKeyAuthenticator interface {
+	Execute(ctx context.Context, secret string) (*identity.Identity, error)
+}
+
+// APIKeyMiddleware autentica a requisição pelo header X-API-Key. Sem o header a requisição segue
+// para os próximos middlewares (ex: JWTMiddleware). Com o header inválido responde 401.
+func APIKeyMiddleware(a APIKeyAuthenticator, log logger.ILogger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		secret := c.GetHeader(APIKeyHeader)
+		if secret == "" || c.Request.Method == http.MethodOptions {
+			c.Next()
+			return
+		}
+
+		id, err := a.Execute(c.Request.Context(), secret)
+		if err != nil {
+			if errors.Is(err, globalerr.ErrUnauthorized) {
+				log.WarnContext(c.Request.Context(), "Chave de API rejeitada", "ip", c.ClientIP(), "path", c.Request.URL.Path)
+				Unauthorized(c, "ApiKey", "invalid_key")
+				return
+			}
+			log.ErrorContext(c.Request.Context(), "Erro ao validar a chave de API", "err", err.Error())
+			problem.Abort(c, globalerr.ErrInternal)
+			return
+		}
+
+		requestinfo.SetUserID(c.Request.Context(), id.Subject)
+		c.Request = c.Request.WithContext(identity.NewContext(c.Request.Context(), id))
+		c.Next()
+	}
+}
@@ -0,0 +1,15 @@
+package auth
+
+import "time"
+
+// SetNow troca o relógio do verificador nos testes
+func (v *JWTVerifier) SetNow(now func() time.Time) {
+	v.now = now
+}
+
+// SetLastRefresh simula nos testes o momento da última carga do JWKS
+func (p *JWKSProvider) SetLastRefresh(t time.Time) {
+	p.mu.Lock()
+	p.lastRefresh = t
+	p.mu.Unlock()
+}
@@ -0,0 +1,211 @@
+// Pacote com a autenticação das requisições (JWT e chaves de API).
+package auth
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/valdinei-santos/cpf-backend/internal/infra/logger"
+)
+
+var ErrKeyNotFound = errors.New("chave de assinatura não encontrada no JWKS")
+
+// jwk é uma chave pública no formato JSON Web Key (RFC 7517). Só RSA e EC P-256 são suportadas.
+type jwk struct {
+	Kid string `json:"kid"`
+	Kty string `json:"kty"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+// JWKSProvider mantém em memória as chaves públicas do emissor dos tokens, carregadas de um
+// arquivo local ou de uma URL e recarregadas periodicamente para acompanhar a rotação de chaves.
+type JWKSProvider struct {
+	source     string
+	interval   time.Duration
+	httpClient *http.Client
+	log        logger.ILogger
+
+	mu          sync.RWMutex
+	keys        map[string]crypto.PublicKey
+	lastRefresh time.Time
+}
+
+// NewJWKSProvider - cria o provider e faz a primeira carga. Source pode ser um caminho de arquivo ou uma URL http(s).
+func NewJWKSProvider(source string, interval time.Duration, log logger.ILogger) (*JWKSProvider, error) {
+	p := &JWKSProvider{
+		source:     source,
+		interval:   interval,
+		httpClient: &http.Client{Timeout: 10 * time.Second},
+		log:        log,
+	}
+	if err := p.Refresh(); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// Run recarrega o JWKS a cada intervalo até o contexto ser cancelado. Em caso de erro as chaves
+// anteriores continuam valendo.
+func (p *JWKSProvider) Run(ctx context.Context) {
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := p.Refresh(); err != nil {
+				p.log.Error("Erro ao recarregar o JWKS", "source", p.source, "err", err.Error())
+			}
+		}
+	}
+}
+
+// Refresh carrega o JWKS da origem e substitui as chaves em memória.
+func (p *JWKSProvider) Refresh() error {
+	data, err := p.read()
+	if err != nil {
+		return err
+	}
+	keys, err := parseJWKS(data)
+	if err != nil {
+		return err
+	}
+	p.mu.Lock()
+	p.keys = keys
+	p.lastRefresh = time.Now()
+	p.mu.Unlock()
+	p.log.Debug("JWKS carregado", "source", p.source, "keys", len(keys))
+	return nil
+}
+
+// Key retorna a chave pública pelo kid. Se o kid não existir, recarrega o JWKS (no máximo uma vez
+// por minuto), pois o emissor pode ter rotacionado a chave antes do próximo refresh periódico.
+func (p *JWKSProvider) Key(kid string) (crypto.PublicKey, error) {
+	p.mu.RLock()
+	key, ok := p.keys[kid]
+	last := p.lastRefresh
+	p.mu.RUnlock()
+	if ok {
+		return key, nil
+	}
+	if time.Since(last) < time.Minute {
+		return nil, ErrKeyNotFound
+	}
+	if err := p.Refresh(); err != nil {
+		p.log.Error("Erro ao recarregar o JWKS", "source", p.source, "err", err.Error())
+		return nil, ErrKeyNotFound
+	}
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	if key, ok := p.keys[kid]; ok {
+		return key, nil
+	}
+	return nil, ErrKeyNotFound
+}
+
+func (p *JWKSProvider) read() ([]byte, error) {
+	if !strings.HasPrefix(p.source, "http://") && !strings.HasPrefix(p.source, "https://") {
+		return os.ReadFile(p.source)
+	}
+	resp, err := p.httpClient.Get(p.source)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("JWKS respondeu com status %d", resp.StatusCode)
+	}
+	return io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+}
+
+func parseJWKS(data []byte) (map[string]crypto.PublicKey, error) {
+	var set struct {
+		Keys []jwk `json:"keys"`
+	}
+	if err := json.Unmarshal(data, &set); err != nil {
+		return nil, fmt.Errorf("JWKS inválido: %w", err)
+	}
+	keys := map[string]crypto.PublicKey{}
+	for _, k := range set.Keys {
+		if k.Use != "" && k.Use != "sig" {
+			continue
+		}
+		key, err := k.publicKey()
+		if err != nil {
+			return nil, fmt.Errorf("chave %q do JWKS inválida: %w", k.Kid, err)
+		}
+		if key != nil {
+			keys[k.Kid] = key
+		}
+	}
+	if len(keys) == 0 {
+		return nil, errors.New("JWKS sem chaves RSA ou EC P-256 de assinatura")
+	}
+	return keys, nil
+}
+
+// publicKey converte o JWK para a chave pública. Tipos não suportados são ignorados (nil, nil).
+func (k jwk) publicKey() (crypto.PublicKey, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err := decodeBigInt(k.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := decodeBigInt(k.E)
+		if err != nil {
+			return nil, err
+		}
+		if n.BitLen() < 2048 {
+			return nil, errors.New("chave RSA menor que 2048 bits")
+		}
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+	case "EC":
+		if k.Crv != "P-256" {
+			return nil, nil
+		}
+		x, err := decodeBigInt(k.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := decodeBigInt(k.Y)
+		if err != nil {
+			return nil, err
+		}
+		key := &ecdsa.PublicKey{Curve: elliptic.P256(), X: x, Y: y}
+		if _, err := key.ECDH(); err != nil {
+			return nil, errors.New("ponto fora da curva P-256")
+		}
+		return key, nil
+	}
+	return nil, nil
+}
+
+func decodeBigInt(s string) (*big.Int, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, err
+	}
+	return new(big.Int).SetBytes(b), nil
+}
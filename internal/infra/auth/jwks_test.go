@@ -0,0 +1,179 @@
+package auth_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"math/big"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/valdinei-santos/cpf-backend/internal/infra/auth"
+	"github.com/valdinei-santos/cpf-backend/internal/infra/logger"
+)
+
+func TestJWKSProviderKey(t *testing.T) {
+	rsaKey, ecKey := testKeys(t)
+	newKey := mustECKey(t)
+
+	tests := []struct {
+		name         string
+		lastRefresh  time.Duration // Tempo desde a última carga
+		rotate       func(s *jwksServer)
+		kid          string
+		expectedKey  any
+		expectedErr  error
+		expectReload bool
+	}{
+		{
+			name:        "Deve retornar a chave RSA do kid",
+			kid:         "rsa-1",
+			expectedKey: &rsaKey.PublicKey,
+		},
+		{
+			name:        "Deve retornar a chave EC do kid",
+			kid:         "ec-1",
+			expectedKey: &ecKey.PublicKey,
+		},
+		{
+			name:         "Deve recarregar o JWKS quando o kid é desconhecido",
+			lastRefresh:  2 * time.Minute,
+			rotate:       func(s *jwksServer) { s.set(http.StatusOK, ecJWK("ec-2", &newKey.PublicKey)) },
+			kid:          "ec-2",
+			expectedKey:  &newKey.PublicKey,
+			expectReload: true,
+		},
+		{
+			name:        "Não deve recarregar o JWKS mais de uma vez por minuto",
+			lastRefresh: 10 * time.Second,
+			rotate:      func(s *jwksServer) { s.set(http.StatusOK, ecJWK("ec-2", &newKey.PublicKey)) },
+			kid:         "ec-2",
+			expectedErr: auth.ErrKeyNotFound,
+		},
+		{
+			name:         "Deve recusar o kid que continua desconhecido após recarregar",
+			lastRefresh:  2 * time.Minute,
+			kid:          "outro",
+			expectedErr:  auth.ErrKeyNotFound,
+			expectReload: true,
+		},
+		{
+			name:         "Deve manter as chaves anteriores quando a recarga falha",
+			lastRefresh:  2 * time.Minute,
+			rotate:       func(s *jwksServer) { s.set(http.StatusInternalServerError) },
+			kid:          "outro",
+			expectedErr:  auth.ErrKeyNotFound,
+			expectReload: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newJWKSServer(t, rsaJWK("rsa-1", &rsaKey.PublicKey), ecJWK("ec-1", &ecKey.PublicKey))
+			p, err := auth.NewJWKSProvider(server.URL, time.Hour, logger.NewMockILogger())
+			require.NoError(t, err)
+			p.SetLastRefresh(time.Now().Add(-tt.lastRefresh))
+			if tt.rotate != nil {
+				tt.rotate(server)
+			}
+
+			key, err := p.Key(tt.kid)
+
+			assert.ErrorIs(t, err, tt.expectedErr)
+			assert.Equal(t, tt.expectedKey, key)
+			reloads := 0
+			if tt.expectReload {
+				reloads = 1
+			}
+			assert.Equal(t, int32(1+reloads), server.requests.Load())
+			if tt.rotate != nil && tt.expectedErr != nil {
+				_, err := p.Key("rsa-1")
+				assert.NoError(t, err, "as chaves em uso não devem ser descartadas")
+			}
+		})
+	}
+}
+
+func TestJWTVerifierRefreshOnUnknownKid(t *testing.T) {
+	rsaKey, _ := testKeys(t)
+	newKey := mustECKey(t)
+	server := newJWKSServer(t, rsaJWK("rsa-1", &rsaKey.PublicKey))
+	p, err := auth.NewJWKSProvider(server.URL, time.Hour, logger.NewMockILogger())
+	require.NoError(t, err)
+	v := auth.NewJWTVerifier(p, issuer, audience, 0)
+	v.SetNow(func() time.Time { return now })
+
+	// O emissor passa a assinar com uma chave nova antes do próximo refresh periódico
+	server.set(http.StatusOK, rsaJWK("rsa-1", &rsaKey.PublicKey), ecJWK("ec-2", &newKey.PublicKey))
+	p.SetLastRefresh(time.Now().Add(-2 * time.Minute))
+
+	claims, err := v.Verify(sign(t, "ES256", "ec-2", newKey, validClaims(nil)))
+
+	require.NoError(t, err)
+	assert.Equal(t, "maria", claims["sub"])
+}
+
+func TestNewJWKSProvider(t *testing.T) {
+	rsaKey, ecKey := testKeys(t)
+	encKey := ecJWK("enc-1", &ecKey.PublicKey)
+	encKey["use"] = "enc"
+	offCurve := ecJWK("ec-1", &ecKey.PublicKey)
+	offCurve["y"] = b64(new(big.Int).Add(ecKey.Y, big.NewInt(1)).FillBytes(make([]byte, 32)))
+	smallRSA := rsaJWK("rsa-1", &rsa.PublicKey{N: new(big.Int).Rsh(rsaKey.N, 1200), E: rsaKey.E})
+
+	tests := []struct {
+		name     string
+		keys     []map[string]string
+		status   int
+		expected []string // kids carregados
+	}{
+		{name: "Deve carregar as chaves RSA e EC de assinatura", keys: []map[string]string{rsaJWK("rsa-1", &rsaKey.PublicKey), ecJWK("ec-1", &ecKey.PublicKey), encKey}, expected: []string{"rsa-1", "ec-1"}},
+		{name: "Deve recusar o JWKS só com chaves de cifragem", keys: []map[string]string{encKey}},
+		{name: "Deve recusar a chave RSA menor que 2048 bits", keys: []map[string]string{smallRSA}},
+		{name: "Deve recusar o ponto fora da curva P-256", keys: []map[string]string{offCurve}},
+		{name: "Deve recusar a resposta de erro do servidor", status: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newJWKSServer(t)
+			status := tt.status
+			if status == 0 {
+				status = http.StatusOK
+			}
+			server.set(status, tt.keys...)
+
+			p, err := auth.NewJWKSProvider(server.URL, time.Hour, logger.NewMockILogger())
+
+			if tt.expected == nil {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			for _, kid := range tt.expected {
+				_, err := p.Key(kid)
+				assert.NoError(t, err, kid)
+			}
+			_, err = p.Key("enc-1")
+			assert.ErrorIs(t, err, auth.ErrKeyNotFound)
+		})
+	}
+}
+
+func TestNewJWKSProviderArquivo(t *testing.T) {
+	_, ecKey := testKeys(t)
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"keys":[{"kid":"ec-1","kty":"EC","crv":"P-256","x":"`+
+		b64(ecKey.X.FillBytes(make([]byte, 32)))+`","y":"`+b64(ecKey.Y.FillBytes(make([]byte, 32)))+`"}]}`), 0o600))
+
+	p, err := auth.NewJWKSProvider(path, time.Hour, logger.NewMockILogger())
+
+	require.NoError(t, err)
+	key, err := p.Key("ec-1")
+	require.NoError(t, err)
+	assert.True(t, key.(*ecdsa.PublicKey).Equal(&ecKey.PublicKey))
+}
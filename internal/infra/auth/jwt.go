@@ -0,0 +1,174 @@
+package auth
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+)
+
+var (
+	ErrTokenMalformed = errors.New("token malformado")
+	ErrTokenAlg       = errors.New("algoritmo de assinatura não permitido")
+	ErrTokenSignature = errors.New("assinatura do token inválida")
+	ErrTokenExpired   = errors.New("token expirado")
+	ErrTokenNotYet    = errors.New("token ainda não é válido")
+	ErrTokenIssuer    = errors.New("emissor do token inválido")
+	ErrTokenAudience  = errors.New("audiência do token inválida")
+)
+
+// keySource fornece as chaves públicas pelo kid. Implementado pelo JWKSProvider.
+type keySource interface {
+	Key(kid string) (crypto.PublicKey, error)
+}
+
+// JWTVerifier valida tokens JWT assinados com RS256 ou ES256 contra as chaves do JWKS,
+// conferindo emissor, audiência e validade.
+type JWTVerifier struct {
+	keys     keySource
+	issuer   string
+	audience string
+	leeway   time.Duration
+	now      func() time.Time
+}
+
+// NewJWTVerifier - cria o verificador. O leeway é a tolerância de relógio aplicada ao exp e ao nbf.
+func NewJWTVerifier(keys keySource, issuer string, audience string, leeway time.Duration) *JWTVerifier {
+	return &JWTVerifier{
+		keys:     keys,
+		issuer:   issuer,
+		audience: audience,
+		leeway:   leeway,
+		now:      time.Now,
+	}
+}
+
+// Verify valida o token e retorna as suas claims.
+func (v *JWTVerifier) Verify(token string) (map[string]any, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, ErrTokenMalformed
+	}
+
+	var header struct {
+		Alg string `json:"alg"`
+		Kid string `json:"kid"`
+	}
+	if err := decodeSegment(parts[0], &header); err != nil {
+		return nil, ErrTokenMalformed
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, ErrTokenMalformed
+	}
+
+	key, err := v.keys.Key(header.Kid)
+	if err != nil {
+		return nil, err
+	}
+	digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+	if err := verifySignature(header.Alg, key, digest[:], signature); err != nil {
+		return nil, err
+	}
+
+	claims := map[string]any{}
+	if err := decodeSegment(parts[1], &claims); err != nil {
+		return nil, ErrTokenMalformed
+	}
+	if err := v.validateClaims(claims); err != nil {
+		return nil, err
+	}
+	return claims, nil
+}
+
+// verifySignature confere a assinatura. O algoritmo precisa combinar com o tipo da chave do kid,
+// o que impede a troca de algoritmo pelo cliente (ex: alg "none" ou HS256 com a chave pública).
+func verifySignature(alg string, key crypto.PublicKey, digest []byte, signature []byte) error {
+	switch alg {
+	case "RS256":
+		rsaKey, ok := key.(*rsa.PublicKey)
+		if !ok {
+			return ErrTokenAlg
+		}
+		if err := rsa.VerifyPKCS1v15(rsaKey, crypto.SHA256, digest, signature); err != nil {
+			return ErrTokenSignature
+		}
+		return nil
+	case "ES256":
+		ecKey, ok := key.(*ecdsa.PublicKey)
+		if !ok {
+			return ErrTokenAlg
+		}
+		// Assinatura JWS do ES256: r e s concatenados com 32 bytes cada
+		if len(signature) != 64 {
+			return ErrTokenSignature
+		}
+		r := new(big.Int).SetBytes(signature[:32])
+		s := new(big.Int).SetBytes(signature[32:])
+		if !ecdsa.Verify(ecKey, digest, r, s) {
+			return ErrTokenSignature
+		}
+		return nil
+	}
+	return ErrTokenAlg
+}
+
+func (v *JWTVerifier) validateClaims(claims map[string]any) error {
+	now := v.now()
+
+	exp, ok := numericClaim(claims, "exp")
+	if !ok {
+		return fmt.Errorf("%w: exp ausente", ErrTokenMalformed)
+	}
+	if now.After(time.Unix(exp, 0).Add(v.leeway)) {
+		return ErrTokenExpired
+	}
+	if nbf, ok := numericClaim(claims, "nbf"); ok && now.Add(v.leeway).Before(time.Unix(nbf, 0)) {
+		return ErrTokenNotYet
+	}
+	if iss, _ := claims["iss"].(string); iss != v.issuer {
+		return ErrTokenIssuer
+	}
+	if !audienceContains(claims["aud"], v.audience) {
+		return ErrTokenAudience
+	}
+	return nil
+}
+
+func decodeSegment(segment string, out any) error {
+	data, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, out)
+}
+
+func numericClaim(claims map[string]any, name string) (int64, bool) {
+	v, ok := claims[name].(float64)
+	if !ok {
+		return 0, false
+	}
+	return int64(v), true
+}
+
+// audienceContains trata o aud como string ou lista de strings (RFC 7519, seção 4.1.3).
+func audienceContains(aud any, audience string) bool {
+	switch v := aud.(type) {
+	case string:
+		return v == audience
+	case []any:
+		for _, a := range v {
+			if s, ok := a.(string); ok && s == audience {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,126 @@
+package auth_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/valdinei-santos/cpf-backend/internal/infra/auth"
+	"github.com/valdinei-santos/cpf-backend/internal/infra/logger"
+)
+
+const (
+	issuer   = "https://sso.exemplo.com.br/realms/cpf"
+	audience = "cpf-backend"
+)
+
+var now = time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+
+// newVerifier cria o verificador com o JWKS servido localmente (kid rsa-1 e ec-1), leeway de 30s
+// e o relógio parado em now
+func newVerifier(t *testing.T) *auth.JWTVerifier {
+	t.Helper()
+	rsaKey, ecKey := testKeys(t)
+	server := newJWKSServer(t, rsaJWK("rsa-1", &rsaKey.PublicKey), ecJWK("ec-1", &ecKey.PublicKey))
+	provider, err := auth.NewJWKSProvider(server.URL, time.Hour, logger.NewMockILogger())
+	require.NoError(t, err)
+	v := auth.NewJWTVerifier(provider, issuer, audience, 30*time.Second)
+	v.SetNow(func() time.Time { return now })
+	return v
+}
+
+// validClaims retorna as claims válidas em now, com as alterações informadas (nil remove a claim)
+func validClaims(changes map[string]any) map[string]any {
+	claims := map[string]any{
+		"sub":   "maria",
+		"iss":   issuer,
+		"aud":   audience,
+		"exp":   now.Add(5 * time.Minute).Unix(),
+		"roles": []string{"operator"},
+	}
+	for k, v := range changes {
+		if v == nil {
+			delete(claims, k)
+			continue
+		}
+		claims[k] = v
+	}
+	return claims
+}
+
+func TestJWTVerifierAlgorithm(t *testing.T) {
+	rsaKey, ecKey := testKeys(t)
+	v := newVerifier(t)
+	claims := validClaims(nil)
+	// Ataque clássico: HS256 usando a chave pública RSA como segredo
+	publicAsSecret := rsaKey.PublicKey.N.Bytes()
+
+	tests := []struct {
+		name        string
+		token       string
+		expectedErr error
+	}{
+		{name: "Deve aceitar o RS256 assinado com a chave do kid", token: sign(t, "RS256", "rsa-1", rsaKey, claims)},
+		{name: "Deve aceitar o ES256 assinado com a chave do kid", token: sign(t, "ES256", "ec-1", ecKey, claims)},
+		{name: "Deve recusar o alg none", token: sign(t, "none", "rsa-1", nil, claims), expectedErr: auth.ErrTokenAlg},
+		{name: "Deve recusar o HS256 com a chave pública como segredo", token: sign(t, "HS256", "rsa-1", publicAsSecret, claims), expectedErr: auth.ErrTokenAlg},
+		{name: "Deve recusar o ES256 com o kid de uma chave RSA", token: sign(t, "ES256", "rsa-1", ecKey, claims), expectedErr: auth.ErrTokenAlg},
+		{name: "Deve recusar o RS256 com o kid de uma chave EC", token: sign(t, "RS256", "ec-1", rsaKey, claims), expectedErr: auth.ErrTokenAlg},
+		{name: "Deve recusar o algoritmo fora da lista", token: sign(t, "PS256", "rsa-1", nil, claims), expectedErr: auth.ErrTokenAlg},
+		{name: "Deve recusar a assinatura de outra chave", token: sign(t, "ES256", "ec-1", mustECKey(t), claims), expectedErr: auth.ErrTokenSignature},
+		{name: "Deve recusar o payload alterado", token: tamperPayload(sign(t, "RS256", "rsa-1", rsaKey, claims)), expectedErr: auth.ErrTokenSignature},
+		{name: "Deve recusar o kid desconhecido", token: sign(t, "RS256", "outro", rsaKey, claims), expectedErr: auth.ErrKeyNotFound},
+		{name: "Deve recusar o token sem as três partes", token: "abc.def", expectedErr: auth.ErrTokenMalformed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := v.Verify(tt.token)
+
+			assert.ErrorIs(t, err, tt.expectedErr)
+			if tt.expectedErr == nil {
+				assert.Equal(t, "maria", got["sub"])
+			}
+		})
+	}
+}
+
+func TestJWTVerifierClaims(t *testing.T) {
+	rsaKey, _ := testKeys(t)
+	v := newVerifier(t)
+
+	tests := []struct {
+		name        string
+		changes     map[string]any
+		expectedErr error
+	}{
+		{name: "Deve aceitar as claims válidas"},
+		{name: "Deve aceitar o token expirado dentro do leeway", changes: map[string]any{"exp": now.Add(-20 * time.Second).Unix()}},
+		{name: "Deve recusar o token expirado além do leeway", changes: map[string]any{"exp": now.Add(-31 * time.Second).Unix()}, expectedErr: auth.ErrTokenExpired},
+		{name: "Deve recusar o token sem exp", changes: map[string]any{"exp": nil}, expectedErr: auth.ErrTokenMalformed},
+		{name: "Deve aceitar o nbf futuro dentro do leeway", changes: map[string]any{"nbf": now.Add(20 * time.Second).Unix()}},
+		{name: "Deve recusar o nbf futuro além do leeway", changes: map[string]any{"nbf": now.Add(31 * time.Second).Unix()}, expectedErr: auth.ErrTokenNotYet},
+		{name: "Deve recusar outro emissor", changes: map[string]any{"iss": "https://outro.exemplo.com.br"}, expectedErr: auth.ErrTokenIssuer},
+		{name: "Deve recusar o token sem emissor", changes: map[string]any{"iss": nil}, expectedErr: auth.ErrTokenIssuer},
+		{name: "Deve aceitar a audiência em uma lista", changes: map[string]any{"aud": []string{"outra-api", audience}}},
+		{name: "Deve recusar outra audiência", changes: map[string]any{"aud": "outra-api"}, expectedErr: auth.ErrTokenAudience},
+		{name: "Deve recusar a lista sem a audiência", changes: map[string]any{"aud": []string{"outra-api"}}, expectedErr: auth.ErrTokenAudience},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := v.Verify(sign(t, "RS256", "rsa-1", rsaKey, validClaims(tt.changes)))
+
+			assert.ErrorIs(t, err, tt.expectedErr)
+		})
+	}
+}
+
+// tamperPayload troca o payload do token, mantendo a assinatura original
+func tamperPayload(token string) string {
+	parts := strings.Split(token, ".")
+	parts[1] = b64([]byte(`{"sub":"admin","iss":"` + issuer + `","aud":"` + audience + `","exp":9999999999}`))
+	return strings.Join(parts, ".")
+}
@@ -0,0 +1,116 @@
+package auth
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/valdinei-santos/cpf-backend/internal/domain/globalerr"
+	"github.com/valdinei-santos/cpf-backend/internal/infra/identity"
+	"github.com/valdinei-santos/cpf-backend/internal/infra/logger"
+	"github.com/valdinei-santos/cpf-backend/internal/infra/problem"
+	"github.com/valdinei-santos/cpf-backend/internal/infra/requestinfo"
+)
+
+// JWTMiddleware autentica a requisição pelo header "Authorization: Bearer <token>". Com o token
+// válido, o subject, os papéis, os escopos e as claims ficam no contexto da requisição
+// (identity.FromContext). Sem token ou com token inválido responde 401.
+func JWTMiddleware(v *JWTVerifier, rolesClaim string, log logger.ILogger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		// O preflight do CORS não envia credenciais
+		if c.Request.Method == http.MethodOptions {
+			c.Next()
+			return
+		}
+		// Já autenticada por outro método (ex: X-API-Key)
+		if !identity.FromContext(c.Request.Context()).IsAnonymous() {
+			c.Next()
+			return
+		}
+
+		token, ok := bearerToken(c.GetHeader("Authorization"))
+		if !ok {
+			Unauthorized(c, "Bearer", "")
+			return
+		}
+		claims, err := v.Verify(token)
+		if err != nil {
+			log.WarnContext(c.Request.Context(), "Token JWT rejeitado", "err", err.Error(), "ip", c.ClientIP(), "path", c.Request.URL.Path)
+			Unauthorized(c, "Bearer", "invalid_token")
+			return
+		}
+
+		id := IdentityFromClaims(claims, rolesClaim)
+		requestinfo.SetUserID(c.Request.Context(), id.Subject)
+		c.Request = c.Request.WithContext(identity.NewContext(c.Request.Context(), id))
+		c.Next()
+	}
+}
+
+// Unauthorized responde 401 no formato padrão de erro da API, com o header WWW-Authenticate.
+func Unauthorized(c *gin.Context, scheme string, errorCode string) {
+	challenge := scheme + ` realm="cpf-backend"`
+	if errorCode != "" {
+		challenge += `, error="` + errorCode + `"`
+	}
+	c.Header("WWW-Authenticate", challenge)
+	problem.Abort(c, globalerr.ErrUnauthorized)
+}
+
+// IdentityFromClaims monta a identidade a partir das claims do token. O rolesClaim aceita
+// caminho com ponto para claims aninhadas. Ex: realm_access.roles
+func IdentityFromClaims(claims map[string]any, rolesClaim string) *identity.Identity {
+	sub, _ := claims["sub"].(string)
+	return &identity.Identity{
+		Subject: sub,
+		Roles:   stringList(nestedClaim(claims, rolesClaim)),
+		Scopes:  scopes(claims),
+		Claims:  claims,
+		Method:  "jwt",
+	}
+}
+
+func bearerToken(header string) (string, bool) {
+	scheme, token, ok := strings.Cut(header, " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") {
+		return "", false
+	}
+	token = strings.TrimSpace(token)
+	return token, token != ""
+}
+
+func nestedClaim(claims map[string]any, path string) any {
+	var current any = claims
+	for _, part := range strings.Split(path, ".") {
+		m, ok := current.(map[string]any)
+		if !ok {
+			return nil
+		}
+		current = m[part]
+	}
+	return current
+}
+
+// scopes lê o "scope" (string separada por espaço, RFC 8693) ou o "scp" (lista).
+func scopes(claims map[string]any) []string {
+	if s, ok := claims["scope"].(string); ok {
+		return strings.Fields(s)
+	}
+	return stringList(claims["scp"])
+}
+
+func stringList(v any) []string {
+	switch list := v.(type) {
+	case string:
+		return strings.Fields(list)
+	case []any:
+		result := make([]string, 0, len(list))
+		for _, item := range list {
+			if s, ok := item.(string); ok {
+				result = append(result, s)
+			}
+		}
+		return result
+	}
+	return []string{}
+}
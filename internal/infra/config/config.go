@@ -1,37 +1,368 @@
+// Pacote com a configuração da API e dos comandos, carregada do arquivo YAML, das variáveis de
+// ambiente e das flags de linha de comando, nessa ordem de precedência crescente.
 package config
 
 import (
+	"errors"
 	"fmt"
-	"log"
-	"os"
+	"strconv"
+	"strings"
+	"time"
 
-	"github.com/joho/godotenv"
+	"github.com/valdinei-santos/cpf-backend/internal/infra/accesslog"
+	"github.com/valdinei-santos/cpf-backend/internal/infra/corspolicy"
+	"github.com/valdinei-santos/cpf-backend/internal/infra/database"
+	"github.com/valdinei-santos/cpf-backend/internal/infra/logger"
+	"github.com/valdinei-santos/cpf-backend/internal/infra/migration"
+	"github.com/valdinei-santos/cpf-backend/internal/infra/ratelimit"
+	"github.com/valdinei-santos/cpf-backend/internal/infra/tenant"
+	"github.com/valdinei-santos/cpf-backend/internal/infra/tracing"
 )
 
-// Config - ...
+// Config é a configuração completa. Cada campo tem a chave no arquivo YAML (tag yaml) e a variável
+// de ambiente (tag env). Nas seções, a tag env é o prefixo das variáveis dos seus campos.
 type Config struct {
-	Port string
-	//ArqLog string
+	Profile   string            `yaml:"profile" env:"APP_PROFILE"` // dev ou prod. Fora do dev as credenciais padrão são recusadas
+	Server    Server            `yaml:"server"`
+	Timeouts  Timeouts          `yaml:"timeouts" env:"REQUEST_TIMEOUT_"`
+	Mongo     database.Options  `yaml:"mongo" env:"MONGO_"`
+	Migration migration.Options `yaml:"migration" env:"MIGRATION_"`
+	Log       logger.Options    `yaml:"log" env:"LOG_"`
+	HTTPLog   accesslog.Options `yaml:"http_log" env:"HTTP_LOG_"`
+	Auth      Auth              `yaml:"auth" env:"AUTH_"`
+	RBAC      RBAC              `yaml:"rbac" env:"RBAC_"`
+	Crypto    Crypto            `yaml:"crypto"`
+	Tenant    Tenant            `yaml:"tenant" env:"TENANT_"`
+	RateLimit RateLimit         `yaml:"rate_limit" env:"RATE_LIMIT_"`
+	Audit     Audit             `yaml:"audit"`
+	CORS      CORS              `yaml:"cors"`
+	Secrets   Secrets           `yaml:"secrets" env:"SECRETS_"`
+	Health    Health            `yaml:"health" env:"HEALTH_"`
+	Tracing   tracing.Options   `yaml:"tracing" env:"TRACING_"`
 }
 
-func LoadConfig() (*Config, error) {
-	err := godotenv.Load()
-	if err != nil {
-		log.Println("Erro ao carregar .env local.")
+// Perfis de execução
+const (
+	ProfileDev  = "dev"  // Desenvolvimento local, aceita as credenciais padrão
+	ProfileProd = "prod" // Padrão
+)
+
+// Senha padrão do MongoDB, a mesma do docker-compose de desenvolvimento
+const defaultMongoPassword = "useradmin"
+
+// Server - servidor HTTP
+type Server struct {
+	Port              string        `yaml:"port" env:"PORT"`
+	ReadTimeout       time.Duration `yaml:"read_timeout" env:"HTTP_READ_TIMEOUT"`               // Leitura da requisição inteira, incluindo o corpo
+	ReadHeaderTimeout time.Duration `yaml:"read_header_timeout" env:"HTTP_READ_HEADER_TIMEOUT"` // Leitura dos headers
+	WriteTimeout      time.Duration `yaml:"write_timeout" env:"HTTP_WRITE_TIMEOUT"`             // Escrita da resposta
+	IdleTimeout       time.Duration `yaml:"idle_timeout" env:"HTTP_IDLE_TIMEOUT"`               // Conexões keep-alive ociosas
+	ShutdownTimeout   time.Duration `yaml:"shutdown_timeout" env:"HTTP_SHUTDOWN_TIMEOUT"`       // Espera pelas requisições em andamento e pelos workers no encerramento
+}
+
+// Timeouts - prazo de cada operação, repassado aos casos de uso e ao MongoDB. Esgotado o prazo a
+// API responde 504. Zero desabilita o prazo.
+type Timeouts struct {
+	Read        time.Duration `yaml:"read" env:"READ"`                 // Consultas (GET)
+	Write       time.Duration `yaml:"write" env:"WRITE"`               // Gravações (POST, PUT e DELETE)
+	AuditVerify time.Duration `yaml:"audit_verify" env:"AUDIT_VERIFY"` // Verificação da cadeia de auditoria, que percorre a collection inteira
+}
+
+// Auth - autenticação JWT. Sem JWKS apenas as chaves de API autenticam.
+type Auth struct {
+	JWKS           string        `yaml:"jwks" env:"JWKS"`                       // Caminho do arquivo ou URL do JWKS com as chaves públicas do emissor
+	JWKSRefresh    time.Duration `yaml:"jwks_refresh" env:"JWKS_REFRESH"`       // Intervalo de recarga do JWKS
+	Issuer         string        `yaml:"issuer" env:"ISSUER"`                   // Valor esperado na claim iss
+	Audience       string        `yaml:"audience" env:"AUDIENCE"`               // Valor esperado na claim aud
+	RolesClaim     string        `yaml:"roles_claim" env:"ROLES_CLAIM"`         // Claim com os papéis do usuário. Aceita caminho com ponto. Ex: realm_access.roles
+	Leeway         time.Duration `yaml:"leeway" env:"LEEWAY"`                   // Tolerância de relógio na validação do exp/nbf
+	AllowAnonymous bool          `yaml:"allow_anonymous" env:"ALLOW_ANONYMOUS"` // Aceita requisições sem credencial (exceto a administração). Só no perfil dev
+}
+
+// RBAC - permissões de cada papel
+type RBAC struct {
+	File string `yaml:"file" env:"FILE"` // Arquivo JSON com as permissões de cada papel. Sem ele é usada a política padrão
+}
+
+// Crypto - criptografia dos campos sensíveis
+type Crypto struct {
+	KeyringFile     string `yaml:"keyring_file" env:"KEYRING_FILE"`         // Arquivo JSON com as chaves de criptografia dos campos sensíveis. Opcional só no perfil dev
+	EncryptTelefone bool   `yaml:"encrypt_telefone" env:"ENCRYPT_TELEFONE"` // Cifra também o telefone, além do documento
+}
+
+// Tenant - multi-tenancy
+type Tenant struct {
+	Mode    string   `yaml:"mode" env:"MODE"`       // Isolamento entre os tenants: none, field ou database
+	Claim   string   `yaml:"claim" env:"CLAIM"`     // Claim do token (ou da chave de API) com o tenant do chamador
+	Allowed []string `yaml:"allowed" env:"ALLOWED"` // Tenants habilitados. Obrigatório no modo database, vazio aceita qualquer tenant no field
+}
+
+// RateLimit - limite de requisições por chave de API, usuário ou IP
+type RateLimit struct {
+	Read       ratelimit.Rate `yaml:"read" env:"READ"`               // Limite das leituras (GET/HEAD)
+	Write      ratelimit.Rate `yaml:"write" env:"WRITE"`             // Limite das escritas (POST/PUT/PATCH/DELETE)
+	DailyQuota int            `yaml:"daily_quota" env:"DAILY_QUOTA"` // Cota diária de requisições. Zero desabilita
+	IP         ratelimit.Rate `yaml:"ip" env:"IP"`                   // Limite por IP antes da autenticação, inclusive das credenciais inválidas
+	Store      string         `yaml:"store" env:"STORE"`             // Onde ficam os contadores: memory ou mongo
+}
+
+// Audit - log de acesso e cadeia de hashes da auditoria (histórico de consentimentos e log de acesso)
+type Audit struct {
+	AccessLogRetentionDays int           `yaml:"access_log_retention_days" env:"ACCESS_LOG_RETENTION_DAYS"` // Retenção do log de acesso aos dados dos clientes. Zero mantém para sempre
+	SigningKey             string        `yaml:"signing_key" env:"AUDIT_SIGNING_KEY"`                       // Arquivo PEM com a chave privada Ed25519 que assina os checkpoints. Sem ele não há checkpoints
+	CheckpointInterval     time.Duration `yaml:"checkpoint_interval" env:"AUDIT_CHECKPOINT_INTERVAL"`       // Intervalo entre os checkpoints. Zero desabilita
+}
+
+// AccessLogRetention retorna a retenção do log de acesso
+func (a Audit) AccessLogRetention() time.Duration {
+	return time.Duration(a.AccessLogRetentionDays) * 24 * time.Hour
+}
+
+// Secrets - recarga dos segredos lidos de arquivos (keyring, chave da auditoria e *_FILE)
+type Secrets struct {
+	ReloadInterval time.Duration `yaml:"reload_interval" env:"RELOAD_INTERVAL"` // Intervalo de verificação dos arquivos. Zero desabilita a recarga
+}
+
+// Health - verificações de saúde do /health/ready
+type Health struct {
+	Timeout  time.Duration `yaml:"timeout" env:"TIMEOUT"`     // Prazo de cada verificação. Ex: ping no MongoDB
+	CacheTTL time.Duration `yaml:"cache_ttl" env:"CACHE_TTL"` // Tempo em que o resultado é reaproveitado entre as sondas
+}
+
+// CORS - política de cada grupo de rotas
+type CORS struct {
+	API    corspolicy.Policy `yaml:"api" env:"CORS_"`           // Rotas de /api/v1
+	Public corspolicy.Policy `yaml:"public" env:"CORS_PUBLIC_"` // Utilitários públicos: /ping, /status e /swagger
+}
+
+// Default retorna a configuração padrão, sobre a qual são aplicados o arquivo, o ambiente e as flags
+func Default() *Config {
+	return &Config{
+		Profile: ProfileProd,
+		Server: Server{
+			Port:              "8889",
+			ReadTimeout:       15 * time.Second,
+			ReadHeaderTimeout: 5 * time.Second,
+			WriteTimeout:      30 * time.Second,
+			IdleTimeout:       2 * time.Minute,
+			ShutdownTimeout:   20 * time.Second,
+		},
+		Timeouts: Timeouts{
+			Read:        5 * time.Second,
+			Write:       10 * time.Second,
+			AuditVerify: 25 * time.Second,
+		},
+		Mongo: database.Options{
+			Host:                   "localhost",
+			Port:                   "27017",
+			User:                   "useradmin",
+			Password:               defaultMongoPassword,
+			AuthSource:             "admin",
+			Database:               "cpf_management",
+			MaxPoolSize:            100,
+			MinPoolSize:            0,
+			ConnectTimeout:         10 * time.Second,
+			ServerSelectionTimeout: 10 * time.Second,
+			Collections: database.Collections{
+				Cliente:       "cliente",
+				Consentimento: "consentimento",
+				Acesso:        "acesso",
+				ApiKey:        "apikey",
+				RateLimit:     "ratelimit",
+				Migrations:    "migrations",
+			},
+		},
+		Migration: migration.Options{
+			LockTTL: 5 * time.Minute,
+		},
+		Log: logger.Options{
+			Level:  "debug",
+			Format: logger.FormatJSON,
+			Output: logger.OutputStdout,
+			File: logger.FileOptions{
+				MaxSizeMB:  100,
+				MaxAgeDays: 30,
+				MaxBackups: 10,
+				Compress:   true,
+			},
+			Sampling: logger.SamplingOptions{
+				Thereafter: 100,
+				Tick:       time.Second,
+			},
+		},
+		HTTPLog: accesslog.Options{
+			Enabled:      true,
+			SkipRoutes:   []string{"/health/live", "/health/ready", "/metrics"},
+			MaxBodyBytes: 8192,
+			RedactFields: []string{"documento", "telefone", "nome", "email", "cpf", "cnpj", "secret", "senha", "password", "token"},
+		},
+		Auth: Auth{
+			JWKSRefresh: 15 * time.Minute,
+			RolesClaim:  "roles",
+			Leeway:      30 * time.Second,
+		},
+		Tenant: Tenant{
+			Mode:  tenant.ModeNone,
+			Claim: "tenant",
+		},
+		RateLimit: RateLimit{
+			Read:  ratelimit.Rate{Limit: 300, Period: time.Minute},
+			Write: ratelimit.Rate{Limit: 60, Period: time.Minute},
+			IP:    ratelimit.Rate{Limit: 600, Period: time.Minute},
+			Store: ratelimit.StoreMemory,
+		},
+		Audit: Audit{
+			AccessLogRetentionDays: 1825,
+			CheckpointInterval:     time.Hour,
+		},
+		CORS: CORS{
+			API: corspolicy.Policy{
+				AllowOrigins:     []string{"http://localhost:8888", "http://127.0.0.1:8888"},
+				AllowMethods:     []string{"GET", "POST", "PUT", "PATCH", "DELETE", "HEAD", "OPTIONS"},
+				AllowHeaders:     []string{"Origin", "Content-Length", "Content-Type", "Authorization", "X-API-Key", "X-Tenant-ID", "X-Access-Purpose", "X-Request-ID", "traceparent", "tracestate"},
+				ExposeHeaders:    []string{"RateLimit-Limit", "RateLimit-Remaining", "RateLimit-Reset", "RateLimit-Policy", "Retry-After", "X-Request-ID", "X-Trace-ID"},
+				AllowCredentials: true,
+				MaxAge:           12 * time.Hour,
+			},
+			Public: corspolicy.Policy{
+				AllowOrigins: []string{corspolicy.AllOrigins},
+				AllowMethods: []string{"GET", "HEAD", "OPTIONS"},
+				AllowHeaders: []string{"Origin", "Content-Type"},
+				MaxAge:       12 * time.Hour,
+			},
+		},
+		Secrets: Secrets{
+			ReloadInterval: time.Minute,
+		},
+		Health: Health{
+			Timeout:  2 * time.Second,
+			CacheTTL: 2 * time.Second,
+		},
+		Tracing: tracing.Options{
+			Exporter:    tracing.ExporterNone,
+			SampleRatio: 1,
+			ServiceName: "cpf-backend",
+		},
+	}
+}
+
+// normalize ajusta os valores equivalentes antes da validação
+func (c *Config) normalize() {
+	for _, p := range []*corspolicy.Policy{&c.CORS.API, &c.CORS.Public} {
+		for i, m := range p.AllowMethods {
+			p.AllowMethods[i] = strings.ToUpper(m)
+		}
+	}
+	c.Profile = strings.ToLower(c.Profile)
+	c.Log.Level = strings.ToLower(c.Log.Level)
+	c.Log.Format = strings.ToLower(c.Log.Format)
+	c.Log.Output = strings.ToLower(c.Log.Output)
+	c.Tracing.Exporter = strings.ToLower(c.Tracing.Exporter)
+}
+
+// Validate verifica a configuração na subida, para que um valor inválido impeça o início em vez de
+// aparecer como falha no primeiro uso. Retorna todos os problemas encontrados.
+func (c *Config) Validate() error {
+	var errs []error
+	check := func(ok bool, format string, args ...any) {
+		if !ok {
+			errs = append(errs, fmt.Errorf(format, args...))
+		}
 	}
 
-	config := &Config{
-		Port: os.Getenv("PORT"),
-		//ArqLog: os.Getenv("ARQ_LOG"),
+	check(c.Profile == ProfileDev || c.Profile == ProfileProd, "APP_PROFILE inválido: %q (use dev ou prod)", c.Profile)
+	check(c.Profile == ProfileDev || c.Mongo.ConnectionPassword() != defaultMongoPassword,
+		"a senha padrão do MongoDB só é aceita com APP_PROFILE=dev, informe MONGO_PASS_FILE ou MONGO_PASS")
+	check(c.Profile == ProfileDev || c.Crypto.KeyringFile != "",
+		"KEYRING_FILE é obrigatório fora do APP_PROFILE=dev, sem ele o documento é gravado sem criptografia")
+
+	port, err := strconv.Atoi(c.Server.Port)
+	check(err == nil && port > 0 && port <= 65535, "PORT inválida: %q", c.Server.Port)
+	check(c.Server.ReadTimeout >= 0 && c.Server.ReadHeaderTimeout >= 0 && c.Server.WriteTimeout >= 0 && c.Server.IdleTimeout >= 0,
+		"os timeouts do servidor HTTP não podem ser negativos")
+	check(c.Server.ShutdownTimeout > 0, "HTTP_SHUTDOWN_TIMEOUT deve ser maior que zero")
+	timeouts := []struct {
+		name string
+		d    time.Duration
+	}{{"READ", c.Timeouts.Read}, {"WRITE", c.Timeouts.Write}, {"AUDIT_VERIFY", c.Timeouts.AuditVerify}}
+	for _, t := range timeouts {
+		check(t.d >= 0, "REQUEST_TIMEOUT_%s não pode ser negativo", t.name)
+		// Depois do HTTP_WRITE_TIMEOUT o servidor fecha a conexão e a resposta 504 não chega ao cliente
+		check(c.Server.WriteTimeout == 0 || t.d < c.Server.WriteTimeout,
+			"REQUEST_TIMEOUT_%s (%s) deve ser menor que HTTP_WRITE_TIMEOUT (%s)", t.name, t.d, c.Server.WriteTimeout)
 	}
 
-	// Validação
-	if config.Port == "" {
-		return nil, fmt.Errorf("PORT não encontrada no .env")
+	check(c.Mongo.URI != "" || (c.Mongo.Host != "" && c.Mongo.Port != ""), "informe MONGO_URI ou MONGO_HOST e MONGO_PORT")
+	check(c.Mongo.Database != "", "MONGO_DATABASE não pode ser vazio")
+	check(c.Mongo.MaxPoolSize == 0 || c.Mongo.MinPoolSize <= c.Mongo.MaxPoolSize,
+		"MONGO_MIN_POOL_SIZE (%d) maior que MONGO_MAX_POOL_SIZE (%d)", c.Mongo.MinPoolSize, c.Mongo.MaxPoolSize)
+	check(c.Mongo.ConnectTimeout > 0, "MONGO_CONNECT_TIMEOUT deve ser maior que zero")
+	check(c.Mongo.ServerSelectionTimeout > 0, "MONGO_SERVER_SELECTION_TIMEOUT deve ser maior que zero")
+	check(c.Mongo.MaxConnIdleTime >= 0 && c.Mongo.Timeout >= 0, "os timeouts do MongoDB não podem ser negativos")
+	cols := c.Mongo.Collections
+	check(cols.Cliente != "" && cols.Consentimento != "" && cols.Acesso != "" && cols.ApiKey != "" && cols.RateLimit != "" && cols.Migrations != "",
+		"os nomes das collections (MONGO_COLLECTION_*) não podem ser vazios")
+	check(c.Migration.LockTTL >= 3*time.Second, "MIGRATION_LOCK_TTL deve ser de pelo menos 3s")
+
+	_, err = logger.ParseLevel(c.Log.Level)
+	check(err == nil, "LOG_LEVEL inválido: %q (use debug, info, warn ou error)", c.Log.Level)
+	check(c.Log.Format == logger.FormatJSON || c.Log.Format == logger.FormatText, "LOG_FORMAT inválido: %q (use json ou text)", c.Log.Format)
+	switch c.Log.Output {
+	case logger.OutputStdout:
+	case logger.OutputFile, logger.OutputBoth:
+		check(c.Log.File.Path != "", "LOG_FILE_PATH é obrigatório com LOG_OUTPUT=%s", c.Log.Output)
+	default:
+		check(false, "LOG_OUTPUT inválido: %q (use stdout, file ou both)", c.Log.Output)
 	}
-	//if config.ArqLog == "" {
-	//	return nil, fmt.Errorf("ARQ_LOG não encontrada no .env")
-	//}
+	check(c.Log.File.MaxSizeMB > 0, "LOG_FILE_MAX_SIZE_MB deve ser maior que zero")
+	check(c.Log.File.MaxAgeDays >= 0 && c.Log.File.MaxBackups >= 0, "LOG_FILE_MAX_AGE_DAYS e LOG_FILE_MAX_BACKUPS não podem ser negativos")
+	check(c.Log.Sampling.Initial >= 0 && c.Log.Sampling.Thereafter >= 0, "LOG_SAMPLING_INITIAL e LOG_SAMPLING_THEREAFTER não podem ser negativos")
+	check(c.Log.Sampling.Initial == 0 || c.Log.Sampling.Tick > 0, "LOG_SAMPLING_TICK deve ser maior que zero com a amostragem habilitada")
+
+	check(!c.HTTPLog.Bodies || c.HTTPLog.MaxBodyBytes > 0, "HTTP_LOG_MAX_BODY_BYTES deve ser maior que zero com HTTP_LOG_BODIES=true")
 
-	return config, nil
+	check(c.Auth.JWKS == "" || (c.Auth.Issuer != "" && c.Auth.Audience != ""),
+		"AUTH_ISSUER e AUTH_AUDIENCE são obrigatórios quando AUTH_JWKS está configurado")
+	check(c.Auth.JWKSRefresh > 0, "AUTH_JWKS_REFRESH deve ser maior que zero")
+	check(c.Auth.Leeway >= 0, "AUTH_LEEWAY não pode ser negativo")
+	check(!c.Auth.AllowAnonymous || c.Profile == ProfileDev, "AUTH_ALLOW_ANONYMOUS só é aceito com APP_PROFILE=dev")
+
+	switch c.Tenant.Mode {
+	case tenant.ModeNone, tenant.ModeField, tenant.ModeDatabase:
+	default:
+		check(false, "TENANT_MODE inválido: %s (use none, field ou database)", c.Tenant.Mode)
+	}
+	check(c.Tenant.Mode != tenant.ModeDatabase || len(c.Tenant.Allowed) > 0, "TENANT_ALLOWED é obrigatório com TENANT_MODE=database")
+	for _, id := range c.Tenant.Allowed {
+		check(tenant.Validate(id) == nil, "TENANT_ALLOWED com tenant inválido: %q", id)
+	}
+
+	check(c.RateLimit.Store == ratelimit.StoreMemory || c.RateLimit.Store == ratelimit.StoreMongo,
+		"RATE_LIMIT_STORE inválido: %s (use memory ou mongo)", c.RateLimit.Store)
+	check(c.RateLimit.DailyQuota >= 0, "RATE_LIMIT_DAILY_QUOTA não pode ser negativo")
+
+	check(c.Audit.AccessLogRetentionDays >= 0, "ACCESS_LOG_RETENTION_DAYS não pode ser negativo")
+	check(c.Audit.CheckpointInterval >= 0, "AUDIT_CHECKPOINT_INTERVAL não pode ser negativo")
+
+	check(c.Secrets.ReloadInterval >= 0, "SECRETS_RELOAD_INTERVAL não pode ser negativo")
+	check(c.Health.Timeout > 0, "HEALTH_TIMEOUT deve ser maior que zero")
+	check(c.Health.CacheTTL >= 0, "HEALTH_CACHE_TTL não pode ser negativo")
+
+	switch c.Tracing.Exporter {
+	case tracing.ExporterNone, tracing.ExporterOTLP, tracing.ExporterStdout:
+	case tracing.ExporterFile:
+		check(c.Tracing.File != "", "TRACING_FILE é obrigatório com TRACING_EXPORTER=file")
+	default:
+		check(false, "TRACING_EXPORTER inválido: %s (use none, otlp, stdout ou file)", c.Tracing.Exporter)
+	}
+	check(c.Tracing.SampleRatio >= 0 && c.Tracing.SampleRatio <= 1, "TRACING_SAMPLE_RATIO deve estar entre 0 e 1")
+	check(c.Tracing.ServiceName != "", "TRACING_SERVICE_NAME não pode ser vazio")
+
+	if err := c.CORS.API.Validate(); err != nil {
+		errs = append(errs, fmt.Errorf("política de CORS CORS_* inválida: %w", err))
+	}
+	if err := c.CORS.Public.Validate(); err != nil {
+		errs = append(errs, fmt.Errorf("política de CORS CORS_PUBLIC_* inválida: %w", err))
+	}
+	return errors.Join(errs...)
 }
@@ -0,0 +1,245 @@
+package config
+
+import (
+	"bytes"
+	"encoding"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/joho/godotenv"
+	"github.com/valdinei-santos/cpf-backend/internal/infra/secrets"
+	"gopkg.in/yaml.v3"
+)
+
+// Variável de ambiente com o arquivo YAML de configuração, quando a flag -config não é informada
+const FileEnv = "CONFIG_FILE"
+
+var (
+	durationType    = reflect.TypeFor[time.Duration]()
+	textUnmarshaler = reflect.TypeFor[encoding.TextUnmarshaler]()
+)
+
+// Flags são as flags de linha de comando da configuração: -config com o arquivo YAML e uma flag
+// por campo, com o caminho do campo no YAML. Ex: -server.port 8080 -mongo.database cpf_teste
+type Flags struct {
+	file   *string
+	values map[string]*flagValue
+}
+
+// RegisterFlags - registra as flags da configuração no FlagSet. Deve ser chamado antes do Parse.
+func RegisterFlags(fs *flag.FlagSet) *Flags {
+	flags := &Flags{
+		file:   fs.String("config", "", "arquivo YAML de configuração (ou "+FileEnv+")"),
+		values: map[string]*flagValue{},
+	}
+	for _, f := range fields(reflect.ValueOf(Default()).Elem(), "", "") {
+		value := &flagValue{boolean: f.value.Kind() == reflect.Bool}
+		usage := "campo " + f.path + " do arquivo de configuração"
+		if f.env != "" {
+			usage += ", equivale a " + f.env
+		}
+		fs.Var(value, f.path, usage)
+		flags.values[f.path] = value
+	}
+	return flags
+}
+
+// flagValue guarda o valor da flag para ser aplicado depois do arquivo e do ambiente
+type flagValue struct {
+	value   string
+	set     bool
+	boolean bool
+}
+
+func (v *flagValue) String() string   { return v.value }
+func (v *flagValue) IsBoolFlag() bool { return v.boolean }
+
+func (v *flagValue) Set(value string) error {
+	v.value, v.set = value, true
+	return nil
+}
+
+// LoadConfig - carrega a configuração. Cada fonte sobrescreve a anterior: valores padrão, arquivo
+// YAML (flag -config ou CONFIG_FILE), variáveis de ambiente (incluindo as do .env) e flags. Depois,
+// os segredos com arquivo (ex: MONGO_PASS_FILE) são lidos do disco. flags pode ser nil quando o
+// comando não registra as flags da configuração. A configuração é validada antes de retornar.
+func LoadConfig(flags *Flags) (*Config, error) {
+	if err := godotenv.Load(); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("erro ao carregar o .env: %w", err)
+	}
+
+	config := Default()
+	file := os.Getenv(FileEnv)
+	if flags != nil && *flags.file != "" {
+		file = *flags.file
+	}
+	if file != "" {
+		if err := loadFile(config, file); err != nil {
+			return nil, err
+		}
+	}
+
+	list := fields(reflect.ValueOf(config).Elem(), "", "")
+	for _, f := range list {
+		if f.env == "" {
+			continue
+		}
+		if value, exists := os.LookupEnv(f.env); exists {
+			if err := f.set(value); err != nil {
+				return nil, fmt.Errorf("%s inválido: %w", f.env, err)
+			}
+		}
+	}
+	if flags != nil {
+		for _, f := range list {
+			if value := flags.values[f.path]; value != nil && value.set {
+				if err := f.set(value.value); err != nil {
+					return nil, fmt.Errorf("flag -%s inválida: %w", f.path, err)
+				}
+			}
+		}
+	}
+
+	for _, f := range list {
+		if f.file.IsValid() && f.file.String() != "" {
+			value, err := secrets.ReadFile(f.file.String())
+			if err != nil {
+				return nil, err
+			}
+			f.value.SetString(value)
+		}
+	}
+
+	config.normalize()
+	if err := config.Validate(); err != nil {
+		return nil, fmt.Errorf("configuração inválida:\n%w", err)
+	}
+	return config, nil
+}
+
+// loadFile aplica o arquivo YAML sobre a configuração. Chaves desconhecidas são rejeitadas para que
+// um erro de digitação não seja ignorado.
+func loadFile(config *Config, path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("erro ao ler o arquivo de configuração %s: %w", path, err)
+	}
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(config); err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("arquivo de configuração %s inválido: %w", path, err)
+	}
+	return nil
+}
+
+// field é um campo da configuração que recebe valor
+type field struct {
+	path   string        // Caminho no YAML, também o nome da flag. Ex: mongo.uri
+	env    string        // Variável de ambiente, vazio quando o campo não é lido do ambiente
+	secret string        // Tag secret: "true" oculta o valor e "url" oculta a senha da URL
+	file   reflect.Value // Campo com o arquivo do segredo (tag file), que tem precedência sobre o valor
+	value  reflect.Value
+}
+
+// fields lista os campos de v, entrando nas seções. path e env são o caminho e o prefixo da seção.
+func fields(v reflect.Value, path, env string) []field {
+	var list []field
+	t := v.Type()
+	for i := range t.NumField() {
+		sf := t.Field(i)
+		key, _, _ := strings.Cut(sf.Tag.Get("yaml"), ",")
+		if key == "" || key == "-" {
+			continue
+		}
+		if path != "" {
+			key = path + "." + key
+		}
+		if sf.Type.Kind() == reflect.Struct && !reflect.PointerTo(sf.Type).Implements(textUnmarshaler) {
+			list = append(list, fields(v.Field(i), key, env+sf.Tag.Get("env"))...)
+			continue
+		}
+		f := field{path: key, secret: sf.Tag.Get("secret"), value: v.Field(i)}
+		if name := sf.Tag.Get("env"); name != "" {
+			f.env = env + name
+		}
+		if name := sf.Tag.Get("file"); name != "" {
+			f.file = v.FieldByName(name)
+		}
+		list = append(list, f)
+	}
+	return list
+}
+
+// set converte o texto da variável de ambiente ou da flag para o tipo do campo. Listas são
+// separadas por vírgula.
+func (f field) set(text string) error {
+	v := f.value
+	if u, ok := v.Addr().Interface().(encoding.TextUnmarshaler); ok {
+		return u.UnmarshalText([]byte(text))
+	}
+	text = strings.TrimSpace(text)
+	if v.Type() == durationType {
+		d, err := time.ParseDuration(text)
+		if err != nil {
+			return err
+		}
+		v.SetInt(int64(d))
+		return nil
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(text)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(text)
+		if err != nil {
+			return err
+		}
+		v.SetBool(b)
+	case reflect.Int, reflect.Int64:
+		n, err := strconv.ParseInt(text, 10, 64)
+		if err != nil {
+			return err
+		}
+		v.SetInt(n)
+	case reflect.Uint, reflect.Uint64:
+		n, err := strconv.ParseUint(text, 10, 64)
+		if err != nil {
+			return err
+		}
+		v.SetUint(n)
+	case reflect.Float64:
+		n, err := strconv.ParseFloat(text, 64)
+		if err != nil {
+			return err
+		}
+		v.SetFloat(n)
+	case reflect.Slice:
+		if v.Type().Elem().Kind() != reflect.String {
+			return fmt.Errorf("tipo %s não suportado", v.Type())
+		}
+		v.Set(reflect.ValueOf(splitList(text)))
+	default:
+		return fmt.Errorf("tipo %s não suportado", v.Type())
+	}
+	return nil
+}
+
+// splitList separa uma lista de valores separados por vírgula, ignorando os vazios.
+func splitList(value string) []string {
+	list := []string{}
+	for _, v := range strings.Split(value, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			list = append(list, v)
+		}
+	}
+	return list
+}
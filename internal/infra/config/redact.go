@@ -0,0 +1,73 @@
+package config
+
+import (
+	"fmt"
+	"io"
+	"net/url"
+	"reflect"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Valor exibido no lugar dos segredos
+const redacted = "******"
+
+// Redacted retorna a configuração efetiva com os segredos ocultos, agrupada pelas seções do YAML.
+// Usado no log da subida e na flag -print-config.
+func (c *Config) Redacted() map[string]any {
+	out := map[string]any{}
+	for _, f := range fields(reflect.ValueOf(c).Elem(), "", "") {
+		section := out
+		keys := strings.Split(f.path, ".")
+		for _, k := range keys[:len(keys)-1] {
+			next, ok := section[k].(map[string]any)
+			if !ok {
+				next = map[string]any{}
+				section[k] = next
+			}
+			section = next
+		}
+		section[keys[len(keys)-1]] = f.display()
+	}
+	return out
+}
+
+// Print escreve a configuração efetiva, com os segredos ocultos, em YAML
+func (c *Config) Print(w io.Writer) error {
+	encoder := yaml.NewEncoder(w)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(c.Redacted()); err != nil {
+		return err
+	}
+	return encoder.Close()
+}
+
+// display retorna o valor do campo para exibição
+func (f field) display() any {
+	v := f.value
+	if v.Kind() == reflect.String && v.String() != "" {
+		switch f.secret {
+		case "true":
+			return redacted
+		case "url":
+			return redactURL(v.String())
+		}
+	}
+	if s, ok := v.Interface().(fmt.Stringer); ok {
+		return s.String() // Ex: time.Duration e ratelimit.Rate
+	}
+	if v.Kind() == reflect.Slice {
+		return append([]string{}, v.Interface().([]string)...)
+	}
+	return v.Interface()
+}
+
+// redactURL oculta a senha da URL. URL inválida é ocultada por inteiro.
+func redactURL(value string) string {
+	u, err := url.Parse(value)
+	if err != nil {
+		return redacted
+	}
+	return u.Redacted()
+}
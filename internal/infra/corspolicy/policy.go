@@ -0,0 +1,145 @@
+// Pacote com a política de CORS de cada grupo de rotas, carregada da configuração.
+package corspolicy
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/gin-contrib/cors"
+	"github.com/gin-gonic/gin"
+)
+
+// AllOrigins libera qualquer origem. Não pode ser usado com AllowCredentials.
+const AllOrigins = "*"
+
+var methods = []string{
+	http.MethodGet, http.MethodHead, http.MethodPost, http.MethodPut,
+	http.MethodPatch, http.MethodDelete, http.MethodOptions,
+}
+
+// Nome de header válido (token da RFC 9110)
+var headerRegex = regexp.MustCompile("^[A-Za-z0-9!#$%&'*+.^_`|~-]+$")
+
+// Policy é a política de CORS de um grupo de rotas
+// As tags definem a chave no arquivo YAML e o sufixo da variável de ambiente, ver o pacote config.
+type Policy struct {
+	AllowOrigins     []string      `yaml:"allow_origins" env:"ALLOW_ORIGINS"`         // Origens exatas (https://app.exemplo.com.br), com subdomínio curinga (https://*.exemplo.com.br) ou "*"
+	AllowMethods     []string      `yaml:"allow_methods" env:"ALLOW_METHODS"`         // Métodos aceitos no preflight
+	AllowHeaders     []string      `yaml:"allow_headers" env:"ALLOW_HEADERS"`         // Headers que o navegador pode enviar
+	ExposeHeaders    []string      `yaml:"expose_headers" env:"EXPOSE_HEADERS"`       // Headers da resposta visíveis para o JavaScript
+	AllowCredentials bool          `yaml:"allow_credentials" env:"ALLOW_CREDENTIALS"` // Permite cookies e o header Authorization
+	MaxAge           time.Duration `yaml:"max_age" env:"MAX_AGE"`                     // Tempo de cache do preflight no navegador
+}
+
+// Validate verifica a política. Chamado na subida da API para que um erro de configuração
+// não apareça só como falha de CORS no navegador.
+func (p Policy) Validate() error {
+	if len(p.AllowOrigins) == 0 {
+		return errors.New("informe ao menos uma origem")
+	}
+	for _, o := range p.AllowOrigins {
+		if o == AllOrigins {
+			if len(p.AllowOrigins) > 1 {
+				return errors.New(`a origem "*" não pode ser combinada com outras origens`)
+			}
+			if p.AllowCredentials {
+				return errors.New(`a origem "*" não pode ser usada com credenciais`)
+			}
+			continue
+		}
+		if _, err := parseOrigin(o); err != nil {
+			return err
+		}
+	}
+	if len(p.AllowMethods) == 0 {
+		return errors.New("informe ao menos um método")
+	}
+	for _, m := range p.AllowMethods {
+		if !slices.Contains(methods, m) {
+			return fmt.Errorf("método %q inválido", m)
+		}
+	}
+	for _, h := range slices.Concat(p.AllowHeaders, p.ExposeHeaders) {
+		if !headerRegex.MatchString(h) {
+			return fmt.Errorf("header %q inválido", h)
+		}
+	}
+	if p.MaxAge < 0 {
+		return errors.New("o max-age não pode ser negativo")
+	}
+	return nil
+}
+
+// Middleware retorna o middleware de CORS da política
+func (p Policy) Middleware() gin.HandlerFunc {
+	cfg := cors.Config{
+		AllowMethods:     p.AllowMethods,
+		AllowHeaders:     p.AllowHeaders,
+		ExposeHeaders:    p.ExposeHeaders,
+		AllowCredentials: p.AllowCredentials,
+		MaxAge:           p.MaxAge,
+	}
+	if slices.Contains(p.AllowOrigins, AllOrigins) {
+		cfg.AllowAllOrigins = true
+		return cors.New(cfg)
+	}
+
+	rules := make([]origin, 0, len(p.AllowOrigins))
+	for _, o := range p.AllowOrigins {
+		if r, err := parseOrigin(o); err == nil {
+			rules = append(rules, r)
+		}
+	}
+	cfg.AllowOriginFunc = func(value string) bool {
+		return slices.ContainsFunc(rules, func(r origin) bool { return r.matches(value) })
+	}
+	return cors.New(cfg)
+}
+
+// origin é uma origem permitida. Com wildcard, host é o domínio que segue o "*."
+type origin struct {
+	scheme   string
+	host     string
+	port     string
+	wildcard bool
+}
+
+func parseOrigin(value string) (origin, error) {
+	u, err := url.Parse(value)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return origin{}, fmt.Errorf("origem %q inválida, use <http|https>://<host>[:porta]", value)
+	}
+	if u.User != nil || (u.Path != "" && u.Path != "/") || u.RawQuery != "" || u.Fragment != "" {
+		return origin{}, fmt.Errorf("origem %q não pode ter caminho, query ou credenciais", value)
+	}
+	o := origin{scheme: u.Scheme, host: strings.ToLower(u.Hostname()), port: u.Port()}
+	if strings.HasPrefix(o.host, "*.") {
+		o.wildcard = true
+		o.host = strings.TrimPrefix(o.host, "*.")
+	}
+	if strings.Contains(o.host, "*") || (o.wildcard && !strings.Contains(o.host, ".")) {
+		return origin{}, fmt.Errorf(`origem %q inválida, o curinga só é aceito no início do domínio, como https://*.exemplo.com.br`, value)
+	}
+	return o, nil
+}
+
+// matches indica se a origem enviada pelo navegador é permitida. O curinga exige ao menos
+// um subdomínio: https://*.exemplo.com.br não aceita https://exemplo.com.br.
+func (o origin) matches(value string) bool {
+	u, err := url.Parse(value)
+	if err != nil || u.Scheme != o.scheme || u.Port() != o.port {
+		return false
+	}
+	host := strings.ToLower(u.Hostname())
+	if !o.wildcard {
+		return host == o.host
+	}
+	sub, ok := strings.CutSuffix(host, "."+o.host)
+	return ok && sub != "" && !strings.HasPrefix(sub, ".")
+}
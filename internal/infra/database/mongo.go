@@ -3,73 +3,147 @@ package database
 import (
 	"context"
 	"fmt"
-	"os"
+	"net/url"
 	"time"
 
 	"github.com/valdinei-santos/cpf-backend/internal/infra/logger"
+	"go.mongodb.org/mongo-driver/event"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
-// Constantes para as variáveis de ambiente
-const (
-	MONGO_HOST = "MONGO_HOST"
-	MONGO_PORT = "MONGO_PORT"
-	MONGO_USER = "MONGO_USER"
-	MONGO_PASS = "MONGO_PASS"
-)
+// Options são as configurações da conexão com o MongoDB. As tags definem a chave no arquivo YAML
+// e o sufixo da variável de ambiente (MONGO_*), ver o pacote config.
+// Os segredos também podem ser lidos de arquivos (Docker secrets), indicados nos campos *File.
+type Options struct {
+	URI          string `yaml:"uri" env:"URI" secret:"url" file:"URIFile"` // Connection string completa. Quando informada, Host, Port, User, Password e AuthSource são ignorados
+	URIFile      string `yaml:"uri_file" env:"URI_FILE"`                   // Arquivo com a URI, tem precedência sobre URI
+	Host         string `yaml:"host" env:"HOST"`
+	Port         string `yaml:"port" env:"PORT"`
+	User         string `yaml:"user" env:"USER"`
+	Password     string `yaml:"password" env:"PASS" secret:"true" file:"PasswordFile"`
+	PasswordFile string `yaml:"password_file" env:"PASS_FILE"` // Arquivo com a senha, tem precedência sobre Password
+	AuthSource   string `yaml:"auth_source" env:"AUTH_SOURCE"`
+	Database     string `yaml:"database" env:"DATABASE"`
+
+	// Pool de conexões
+	MaxPoolSize     uint64        `yaml:"max_pool_size" env:"MAX_POOL_SIZE"`
+	MinPoolSize     uint64        `yaml:"min_pool_size" env:"MIN_POOL_SIZE"`
+	MaxConnIdleTime time.Duration `yaml:"max_conn_idle_time" env:"MAX_CONN_IDLE_TIME"` // Zero mantém as conexões ociosas abertas
+
+	// Timeouts
+	ConnectTimeout         time.Duration `yaml:"connect_timeout" env:"CONNECT_TIMEOUT"`                   // Abertura de cada conexão e ping da subida
+	ServerSelectionTimeout time.Duration `yaml:"server_selection_timeout" env:"SERVER_SELECTION_TIMEOUT"` // Espera por um servidor disponível
+	Timeout                time.Duration `yaml:"timeout" env:"TIMEOUT"`                                   // Limite de cada operação. Zero desabilita
 
-// Conexao é a variável exportada que qualquer pacote pode usar para interagir com o DB.
-//var Conexao *mongo.Client
+	Collections Collections `yaml:"collections" env:"COLLECTION_"`
+}
+
+// Collections são os nomes das collections usadas pela API
+type Collections struct {
+	Cliente       string `yaml:"cliente" env:"CLIENTE"`
+	Consentimento string `yaml:"consentimento" env:"CONSENTIMENTO"`
+	Acesso        string `yaml:"acesso" env:"ACESSO"`
+	ApiKey        string `yaml:"apikey" env:"APIKEY"`
+	RateLimit     string `yaml:"ratelimit" env:"RATELIMIT"`
+	Migrations    string `yaml:"migrations" env:"MIGRATIONS"` // Versões aplicadas e trava das migrações, no database base
+}
+
+// ConnectionPassword retorna a senha da URI ou, sem URI, a do campo Password
+func (o Options) ConnectionPassword() string {
+	if o.URI == "" {
+		return o.Password
+	}
+	u, err := url.Parse(o.URI)
+	if err != nil {
+		return ""
+	}
+	password, _ := u.User.Password()
+	return password
+}
 
-// ConnectDB estabelece a conexão com o MongoDB.
-func ConnectDB(log logger.ILogger) (*mongo.Client, error) {
-	// Cria o Connection URI (string de conexão)
-	mongoURI := fmt.Sprintf("mongodb://%s:%s@%s:%s/admin?authSource=admin",
-		getEnv(MONGO_USER, "useradmin"),
-		getEnv(MONGO_PASS, "useradmin"),
-		getEnv(MONGO_HOST, "localhost"),
-		getEnv(MONGO_PORT, "27017"),
-	)
+// ConnectionURI retorna a URI informada ou a montada a partir do host, porta e credenciais
+func (o Options) ConnectionURI() string {
+	if o.URI != "" {
+		return o.URI
+	}
+	u := url.URL{Scheme: "mongodb", Host: o.Host + ":" + o.Port, Path: "/"}
+	if o.User != "" {
+		u.User = url.UserPassword(o.User, o.Password)
+	}
+	if o.AuthSource != "" {
+		u.RawQuery = url.Values{"authSource": {o.AuthSource}}.Encode()
+	}
+	return u.String()
+}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+// ConnectDB estabelece a conexão com o MongoDB. extra são opções do driver aplicadas sobre as de
+// opts, como os monitores das métricas.
+func ConnectDB(log logger.ILogger, opts Options, extra ...*options.ClientOptions) (*mongo.Client, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), opts.ConnectTimeout)
 	defer cancel()
 
 	clientOptions := options.Client().
-		ApplyURI(mongoURI).
-		SetConnectTimeout(10 * time.Second)
+		ApplyURI(opts.ConnectionURI()).
+		SetConnectTimeout(opts.ConnectTimeout).
+		SetServerSelectionTimeout(opts.ServerSelectionTimeout).
+		SetMaxPoolSize(opts.MaxPoolSize).
+		SetMinPoolSize(opts.MinPoolSize).
+		SetMaxConnIdleTime(opts.MaxConnIdleTime)
+	if opts.Timeout > 0 {
+		clientOptions.SetTimeout(opts.Timeout)
+	}
 
 	// Conecta ao MongoDB
-	var err error
-	Client, err := mongo.Connect(ctx, clientOptions)
+	client, err := mongo.Connect(ctx, append([]*options.ClientOptions{clientOptions}, extra...)...)
 	if err != nil {
-		log.ErrorContext(ctx, "Erro ao conectar ao MongoDB: %v", err)
+		return nil, fmt.Errorf("falha ao conectar ao MongoDB: %w", err)
 	}
 
 	// Tentativa de ping para confirmar a conexão imediatamente
-	if err = Client.Ping(ctx, nil); err != nil {
-		Client.Disconnect(context.Background())
+	if err = client.Ping(ctx, nil); err != nil {
+		client.Disconnect(context.Background())
 		return nil, fmt.Errorf("falha ao pingar o MongoDB: %w", err)
 	}
 
-	log.Info("Conexão com MongoDB estabelecida com sucesso!")
-	return Client, nil
+	log.Info("Conexão com MongoDB estabelecida com sucesso!", "database", opts.Database)
+	return client, nil
+}
+
+// CommandMonitors combina os monitores de comandos, já que o driver aceita apenas um. Ex: métricas
+// e rastreamento
+func CommandMonitors(monitors ...*event.CommandMonitor) *event.CommandMonitor {
+	return &event.CommandMonitor{
+		Started: func(ctx context.Context, e *event.CommandStartedEvent) {
+			for _, m := range monitors {
+				if m.Started != nil {
+					m.Started(ctx, e)
+				}
+			}
+		},
+		Succeeded: func(ctx context.Context, e *event.CommandSucceededEvent) {
+			for _, m := range monitors {
+				if m.Succeeded != nil {
+					m.Succeeded(ctx, e)
+				}
+			}
+		},
+		Failed: func(ctx context.Context, e *event.CommandFailedEvent) {
+			for _, m := range monitors {
+				if m.Failed != nil {
+					m.Failed(ctx, e)
+				}
+			}
+		},
+	}
 }
 
 // DisconnectDB fecha a conexão com o MongoDB.
 func DisconnectDB(log logger.ILogger, client *mongo.Client, ctx context.Context) {
 	if client != nil {
 		if err := client.Disconnect(ctx); err != nil {
-			log.ErrorContext(ctx, "Erro ao desconectar do MongoDB: %v", err)
+			log.ErrorContext(ctx, "Erro ao desconectar do MongoDB", "err", err.Error())
 		}
 		log.Info("Conexão com MongoDB fechada.")
 	}
 }
-
-// getEnv retorna a variável de ambiente ou um valor padrão.
-func getEnv(key string, defaultValue string) string {
-	if value, exists := os.LookupEnv(key); exists {
-		return value
-	}
-	return defaultValue
-}
@@ -0,0 +1,159 @@
+package database
+
+import (
+	"context"
+	"regexp"
+
+	"github.com/valdinei-santos/cpf-backend/internal/infra/logger"
+	"github.com/valdinei-santos/cpf-backend/internal/infra/tenant"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// Tenants entrega aos repositórios a collection do tenant da requisição conforme o modo de isolamento:
+//   - tenant.ModeNone: database base, sem filtro
+//   - tenant.ModeField: database base, com o campo tenant em todos os filtros e registros
+//   - tenant.ModeDatabase: um database por tenant, com o nome <database base>_<tenant>
+//
+// Os tenants fora da lista allowed (TENANT_ALLOWED) são recusados. No ModeDatabase a lista é
+// obrigatória, para que uma requisição não crie databases.
+type Tenants struct {
+	mode    string
+	db      *mongo.Database
+	allowed []string
+	log     logger.ILogger
+}
+
+// NewTenants - cria o Tenants a partir do database base e dos tenants habilitados
+func NewTenants(db *mongo.Database, mode string, allowed []string, log logger.ILogger) *Tenants {
+	if mode == "" {
+		mode = tenant.ModeNone
+	}
+	return &Tenants{
+		mode:    mode,
+		db:      db,
+		allowed: allowed,
+		log:     log,
+	}
+}
+
+// Mode retorna o modo de isolamento configurado
+func (t *Tenants) Mode() string {
+	return t.mode
+}
+
+// Scope é a collection do tenant. No ModeField, Filter e Tenant acrescentam o tenant nos filtros e registros.
+type Scope struct {
+	*mongo.Collection
+	tenant string
+}
+
+// Filter acrescenta o tenant ao filtro (apenas no ModeField)
+func (s Scope) Filter(filter bson.M) bson.M {
+	if s.tenant != "" {
+		filter["tenant"] = s.tenant
+	}
+	return filter
+}
+
+// Tenant retorna o valor a gravar no campo tenant do registro (vazio fora do ModeField)
+func (s Scope) Tenant() string {
+	return s.tenant
+}
+
+// IndexKeys acrescenta o tenant como primeiro campo do índice no ModeField, para que a unicidade seja por tenant
+func (t *Tenants) IndexKeys(keys bson.D) bson.D {
+	if t.mode != tenant.ModeField {
+		return keys
+	}
+	return append(bson.D{{Key: "tenant", Value: 1}}, keys...)
+}
+
+// EnsureIndexes - cria os índices da collection. No ModeDatabase os índices são criados no database
+// de cada tenant habilitado, na subida e nunca durante uma requisição.
+func (t *Tenants) EnsureIndexes(ctx context.Context, name string, models []mongo.IndexModel) error {
+	if t.mode != tenant.ModeDatabase {
+		_, err := t.db.Collection(name).Indexes().CreateMany(ctx, models)
+		return err
+	}
+	for _, id := range t.allowed {
+		coll := t.database(id).Collection(name)
+		if _, err := coll.Indexes().CreateMany(ctx, models); err != nil {
+			return err
+		}
+		t.log.Debug("Índices criados no database do tenant", "database", coll.Database().Name(), "collection", name)
+	}
+	return nil
+}
+
+// Collection retorna a collection do tenant do contexto
+func (t *Tenants) Collection(ctx context.Context, name string) (Scope, error) {
+	if t.mode == tenant.ModeNone {
+		return Scope{Collection: t.db.Collection(name)}, nil
+	}
+	id, ok := tenant.FromContext(ctx)
+	if !ok {
+		return Scope{}, tenant.ErrTenantRequired
+	}
+	if !tenant.Allowed(t.allowed, id) {
+		return Scope{}, tenant.ErrTenantUnknown
+	}
+	if t.mode == tenant.ModeField {
+		return Scope{Collection: t.db.Collection(name), tenant: id}, nil
+	}
+	return Scope{Collection: t.database(id).Collection(name)}, nil
+}
+
+// database retorna o database do tenant no ModeDatabase
+func (t *Tenants) database(id string) *mongo.Database {
+	return t.db.Client().Database(t.db.Name() + "_" + id)
+}
+
+// All retorna a collection de todos os tenants. Usado nas rotinas de manutenção, como a rotação de chaves.
+func (t *Tenants) All(ctx context.Context, name string) ([]*mongo.Collection, error) {
+	if t.mode != tenant.ModeDatabase {
+		return []*mongo.Collection{t.db.Collection(name)}, nil
+	}
+	filter := bson.M{"name": bson.M{"$regex": "^" + regexp.QuoteMeta(t.db.Name()+"_")}}
+	names, err := t.db.Client().ListDatabaseNames(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	colls := make([]*mongo.Collection, 0, len(names))
+	for _, n := range names {
+		colls = append(colls, t.db.Client().Database(n).Collection(name))
+	}
+	return colls, nil
+}
+
+// Scopes retorna a collection de cada tenant, com o filtro do tenant no ModeField. Usado nas rotinas
+// que tratam cada tenant separadamente, como os checkpoints da auditoria.
+func (t *Tenants) Scopes(ctx context.Context, name string) ([]Scope, error) {
+	switch t.mode {
+	case tenant.ModeField:
+		coll := t.db.Collection(name)
+		values, err := coll.Distinct(ctx, "tenant", bson.M{})
+		if err != nil {
+			return nil, err
+		}
+		scopes := make([]Scope, 0, len(values))
+		for _, v := range values {
+			if id, ok := v.(string); ok && id != "" {
+				scopes = append(scopes, Scope{Collection: coll, tenant: id})
+			}
+		}
+		return scopes, nil
+	case tenant.ModeDatabase:
+		colls, err := t.All(ctx, name)
+		if err != nil {
+			return nil, err
+		}
+		scopes := make([]Scope, 0, len(colls))
+		for _, c := range colls {
+			scopes = append(scopes, Scope{Collection: c})
+		}
+		return scopes, nil
+	default:
+		return []Scope{{Collection: t.db.Collection(name)}}, nil
+	}
+}
@@ -0,0 +1,181 @@
+// Pacote com o encadeamento por hash (hash chain) dos registros de auditoria, que permite detectar
+// registros alterados ou removidos diretamente no banco.
+package hashchain
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/valdinei-santos/cpf-backend/internal/infra/database"
+	"github.com/valdinei-santos/cpf-backend/internal/infra/logger"
+)
+
+// Versão do cálculo do hash, incluída no conteúdo hasheado
+const version = "v1"
+
+// Tentativas de encadear quando outra instância grava na mesma cadeia ao mesmo tempo
+const maxAttempts = 5
+
+var ErrChainConflict = errors.New("não foi possível encadear o registro: conflito com outra gravação")
+
+// Link são os campos de encadeamento gravados em cada registro. Cada registro aponta para o anterior
+// da cadeia (global no tenant) e para o anterior da mesma chave (ex: do mesmo cliente).
+type Link struct {
+	Seq         int64  `bson:"seq"`           // Posição na cadeia, começando em 1
+	PrevHash    string `bson:"prev_hash"`     // Hash do registro anterior da cadeia
+	KeyPrevHash string `bson:"key_prev_hash"` // Hash do registro anterior da mesma chave
+	Hash        string `bson:"hash"`          // SHA-256 da posição, dos hashes anteriores e do conteúdo
+}
+
+// Record é um registro a encadear
+type Record struct {
+	Key     any    // Valor do campo da chave. Ex: ID do cliente
+	Content []byte // Conteúdo canônico do registro, ver Content
+}
+
+// Compute calcula o hash do registro a partir da posição, dos hashes anteriores e do conteúdo
+func Compute(seq int64, prevHash, keyPrevHash string, content []byte) string {
+	h := sha256.New()
+	fmt.Fprintf(h, "%s\n%d\n%s\n%s\n", version, seq, prevHash, keyPrevHash)
+	h.Write(content)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// Content retorna o conteúdo canônico do registro: o BSON da entidade. O BSON grava as datas em
+// milissegundos, então a entidade lida do banco gera o mesmo conteúdo da entidade gravada.
+func Content(v any) ([]byte, error) {
+	return bson.Marshal(v)
+}
+
+// Chain é a cadeia de hashes de uma collection, separada por tenant
+type Chain struct {
+	tenants   *database.Tenants
+	name      string
+	keyField  string
+	retention time.Duration
+	signer    *Signer
+	log       logger.ILogger
+	mu        sync.Mutex
+}
+
+// NewChain - cria a cadeia da collection name. keyField é o campo da cadeia de cada entidade (ex:
+// cliente_id). retention é a retenção dos registros, que o MongoDB remove dos mais antigos para os
+// mais recentes. Sem signer os checkpoints ficam desabilitados.
+func NewChain(tenants *database.Tenants, name string, keyField string, retention time.Duration, signer *Signer, log logger.ILogger) *Chain {
+	return &Chain{
+		tenants:   tenants,
+		name:      name,
+		keyField:  keyField,
+		retention: retention,
+		signer:    signer,
+		log:       log,
+	}
+}
+
+// Name retorna o nome da collection da cadeia
+func (c *Chain) Name() string {
+	return c.name
+}
+
+// Indexes - índices da cadeia, a criar junto com os índices da collection. O índice único do seq
+// impede que duas gravações concorrentes ocupem a mesma posição. Os registros anteriores ao
+// encadeamento não têm seq e ficam fora dos índices.
+func (c *Chain) Indexes() []mongo.IndexModel {
+	chained := bson.M{"seq": bson.M{"$exists": true}}
+	return []mongo.IndexModel{
+		{
+			Keys:    c.tenants.IndexKeys(bson.D{{Key: "seq", Value: 1}}),
+			Options: options.Index().SetName("uniq_seq").SetUnique(true).SetPartialFilterExpression(chained),
+		},
+		{
+			Keys:    c.tenants.IndexKeys(bson.D{{Key: c.keyField, Value: 1}, {Key: "seq", Value: -1}}),
+			Options: options.Index().SetName("idx_" + c.keyField + "_seq").SetPartialFilterExpression(chained),
+		},
+	}
+}
+
+// Append - encadeia os registros, na ordem recebida, e os insere na collection do tenant. doc monta o
+// documento gravado de cada registro a partir do seu Link. Se outra instância gravar na cadeia ao
+// mesmo tempo, o índice único do seq rejeita a inserção e o encadeamento é refeito.
+func (c *Chain) Append(ctx context.Context, coll database.Scope, records []Record, doc func(i int, link Link) any) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for attempt := 1; attempt <= maxAttempts; attempt++ {
+		docs, err := c.link(ctx, coll, records, doc)
+		if err != nil {
+			return err
+		}
+		_, err = coll.InsertMany(ctx, docs)
+		if err == nil {
+			return nil
+		}
+		if !mongo.IsDuplicateKeyError(err) {
+			return err
+		}
+		c.log.WarnContext(ctx, "Conflito ao encadear os registros, tentando novamente", "collection", c.name, "tentativa", attempt)
+	}
+	return ErrChainConflict
+}
+
+// link calcula o Link de cada registro a partir do topo atual da cadeia
+func (c *Chain) link(ctx context.Context, coll database.Scope, records []Record, doc func(i int, link Link) any) ([]any, error) {
+	head, err := c.head(ctx, coll, bson.M{})
+	if err != nil {
+		return nil, err
+	}
+	if head.Seq == 0 {
+		// Sem registros encadeados: continua do último checkpoint, caso a retenção tenha removido todos
+		cp, err := c.lastCheckpoint(ctx, coll)
+		if err != nil {
+			return nil, err
+		}
+		if cp != nil {
+			head = Link{Seq: cp.Seq, Hash: cp.Hash}
+		}
+	}
+
+	keyHeads := map[string]string{}
+	docs := make([]any, len(records))
+	for i, r := range records {
+		key := fmt.Sprint(r.Key)
+		keyPrev, ok := keyHeads[key]
+		if !ok {
+			last, err := c.head(ctx, coll, bson.M{c.keyField: r.Key})
+			if err != nil {
+				return nil, err
+			}
+			keyPrev = last.Hash
+		}
+
+		link := Link{Seq: head.Seq + 1, PrevHash: head.Hash, KeyPrevHash: keyPrev}
+		link.Hash = Compute(link.Seq, link.PrevHash, link.KeyPrevHash, r.Content)
+		docs[i] = doc(i, link)
+		head, keyHeads[key] = link, link.Hash
+	}
+	return docs, nil
+}
+
+// head retorna o Link do último registro encadeado que atende ao filtro, ou o Link vazio
+func (c *Chain) head(ctx context.Context, coll database.Scope, filter bson.M) (Link, error) {
+	filter["seq"] = bson.M{"$exists": true}
+	findOptions := options.FindOne().
+		SetSort(bson.D{{Key: "seq", Value: -1}}).
+		SetProjection(bson.M{"seq": 1, "prev_hash": 1, "key_prev_hash": 1, "hash": 1})
+
+	var link Link
+	err := coll.FindOne(ctx, coll.Filter(filter), findOptions).Decode(&link)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return Link{}, nil
+	}
+	return link, err
+}
@@ -0,0 +1,115 @@
+//go:build integration
+// +build integration
+
+package hashchain_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/wait"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/valdinei-santos/cpf-backend/internal/infra/database"
+	"github.com/valdinei-santos/cpf-backend/internal/infra/hashchain"
+	"github.com/valdinei-santos/cpf-backend/internal/infra/logger"
+	"github.com/valdinei-santos/cpf-backend/internal/infra/tenant"
+)
+
+func setupMongo(t *testing.T) *mongo.Database {
+	ctx := context.Background()
+	mongoC, err := testcontainers.GenericContainer(ctx, testcontainers.GenericContainerRequest{
+		ContainerRequest: testcontainers.ContainerRequest{
+			Image:        "mongo:6.0",
+			ExposedPorts: []string{"27017/tcp"},
+			WaitingFor:   wait.ForListeningPort("27017/tcp"),
+		},
+		Started: true,
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() { mongoC.Terminate(ctx) })
+
+	host, _ := mongoC.Host(ctx)
+	port, _ := mongoC.MappedPort(ctx, "27017")
+	client, err := mongo.Connect(ctx, options.Client().ApplyURI("mongodb://"+host+":"+port.Port()))
+	require.NoError(t, err)
+	t.Cleanup(func() { client.Disconnect(ctx) })
+	return client.Database(dbName)
+}
+
+// appendAcessos encadeia e grava um registro por cliente
+func appendAcessos(t *testing.T, c *hashchain.Chain, coll database.Scope, clientes ...string) {
+	t.Helper()
+	records := make([]hashchain.Record, len(clientes))
+	for i, cliente := range clientes {
+		records[i] = hashchain.Record{Key: cliente, Content: []byte(fmt.Sprintf("acesso %s %d", cliente, i))}
+	}
+	err := c.Append(context.Background(), coll, records, func(i int, link hashchain.Link) any {
+		return registro{ID: fmt.Sprintf("%s-%d", clientes[i], link.Seq), Cliente: clientes[i], Dados: string(records[i].Content), Link: link}
+	})
+	require.NoError(t, err)
+}
+
+func TestChain_Integration(t *testing.T) {
+	ctx := context.Background()
+	db := setupMongo(t)
+	log := logger.NewMockILogger()
+	tenants := database.NewTenants(db, tenant.ModeNone, nil, log)
+	c := hashchain.NewChain(tenants, "acesso", "cliente_id", 0, newSigner(t), log)
+	require.NoError(t, tenants.EnsureIndexes(ctx, c.Name(), c.Indexes()))
+	coll, err := tenants.Collection(ctx, c.Name())
+	require.NoError(t, err)
+
+	// Dois lotes: o segundo continua do topo gravado pelo primeiro
+	appendAcessos(t, c, coll, "a", "b", "a")
+	appendAcessos(t, c, coll, "c", "a")
+
+	report, err := c.Verify(ctx, nil, decode)
+	require.NoError(t, err)
+	assert.True(t, report.OK(), "elo quebrado: %+v", report.Broken)
+	assert.Equal(t, int64(5), report.Checked)
+	assert.Equal(t, int64(1), report.FirstSeq)
+	assert.Equal(t, int64(5), report.LastSeq)
+
+	report, err = c.Verify(ctx, "a", decode)
+	require.NoError(t, err)
+	assert.True(t, report.OK(), "elo quebrado: %+v", report.Broken)
+	assert.Equal(t, int64(3), report.Checked)
+
+	// Um checkpoint por avanço da cadeia
+	n, err := c.Checkpoint(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, n)
+	n, err = c.Checkpoint(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 0, n)
+
+	report, err = c.Verify(ctx, nil, decode)
+	require.NoError(t, err)
+	assert.True(t, report.OK(), "elo quebrado: %+v", report.Broken)
+	assert.Equal(t, 1, report.Checkpoints)
+
+	// Registro alterado e último registro removido direto no banco
+	_, err = coll.UpdateOne(ctx, bson.M{"seq": 2}, bson.M{"$set": bson.M{"dados": "alterado"}})
+	require.NoError(t, err)
+	report, err = c.Verify(ctx, nil, decode)
+	require.NoError(t, err)
+	require.NotNil(t, report.Broken)
+	assert.Equal(t, int64(2), report.Broken.Seq)
+
+	_, err = coll.UpdateOne(ctx, bson.M{"seq": 2}, bson.M{"$set": bson.M{"dados": "acesso b 1"}})
+	require.NoError(t, err)
+	_, err = coll.DeleteOne(ctx, bson.M{"seq": 5})
+	require.NoError(t, err)
+	report, err = c.Verify(ctx, nil, decode)
+	require.NoError(t, err)
+	require.NotNil(t, report.Broken)
+	assert.Equal(t, int64(5), report.Broken.Seq)
+	assert.Contains(t, report.Broken.Reason, "registros removidos")
+}
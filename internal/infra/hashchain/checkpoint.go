@@ -0,0 +1,250 @@
+package hashchain
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/valdinei-santos/cpf-backend/internal/infra/database"
+	"github.com/valdinei-santos/cpf-backend/internal/infra/logger"
+)
+
+// CheckpointCollection é a collection dos checkpoints, no mesmo database da cadeia
+const CheckpointCollection = "audit_checkpoint"
+
+var ErrSigningKeyInvalid = errors.New("chave de assinatura da auditoria inválida: use uma chave Ed25519 PKCS#8 em PEM")
+
+// Checkpoint é o topo de uma cadeia assinado com a chave Ed25519 local. Um checkpoint prova que a
+// cadeia tinha aquele hash naquela posição, então reescrever a cadeia inteira também é detectado.
+type Checkpoint struct {
+	Collection string    `bson:"collection"`
+	Tenant     string    `bson:"tenant"` // Tenant no tenant.ModeField, vazio nos outros modos
+	Seq        int64     `bson:"seq"`
+	Hash       string    `bson:"hash"`
+	KeyID      string    `bson:"key_id"`    // Identificador da chave que assinou
+	Signature  string    `bson:"signature"` // Assinatura Ed25519 em base64
+	CreatedAt  time.Time `bson:"created_at"`
+}
+
+// message é o conteúdo assinado. Inclui o database para que um checkpoint não valha em outro tenant.
+func (cp *Checkpoint) message(database string) []byte {
+	return fmt.Appendf(nil, "cpf-backend/checkpoint/%s\n%s\n%s\n%s\n%d\n%s\n%d",
+		version, database, cp.Collection, cp.Tenant, cp.Seq, cp.Hash, cp.CreatedAt.UnixMilli())
+}
+
+// Signer assina e confere os checkpoints
+type Signer struct {
+	path  string // Arquivo da chave, vazio quando criado com NewSigner
+	mu    sync.RWMutex
+	key   ed25519.PrivateKey
+	keyID string
+}
+
+// LoadSigner carrega a chave privada Ed25519 (PKCS#8 em PEM) do arquivo. Sem arquivo retorna nil e os
+// checkpoints ficam desabilitados. Para gerar a chave: openssl genpkey -algorithm ed25519 -out audit.pem
+func LoadSigner(path string) (*Signer, error) {
+	if path == "" {
+		return nil, nil
+	}
+	key, err := readSigningKey(path)
+	if err != nil {
+		return nil, err
+	}
+	s := NewSigner(key)
+	s.path = path
+	return s, nil
+}
+
+func readSigningKey(path string) (ed25519.PrivateKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao ler a chave de assinatura %s: %w", path, err)
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, ErrSigningKeyInvalid
+	}
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrSigningKeyInvalid, err)
+	}
+	key, ok := parsed.(ed25519.PrivateKey)
+	if !ok {
+		return nil, ErrSigningKeyInvalid
+	}
+	return key, nil
+}
+
+// NewSigner - cria o Signer a partir da chave privada
+func NewSigner(key ed25519.PrivateKey) *Signer {
+	s := &Signer{}
+	s.setKey(key)
+	return s
+}
+
+func (s *Signer) setKey(key ed25519.PrivateKey) {
+	sum := sha256.Sum256(key.Public().(ed25519.PublicKey))
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.key, s.keyID = key, hex.EncodeToString(sum[:8])
+}
+
+// Reload - recarrega a chave do arquivo, depois de uma troca da chave. Os checkpoints seguintes são
+// assinados com a nova chave, e os da chave anterior deixam de ser conferidos.
+func (s *Signer) Reload() error {
+	if s.path == "" {
+		return nil
+	}
+	key, err := readSigningKey(s.path)
+	if err != nil {
+		return err
+	}
+	s.setKey(key)
+	return nil
+}
+
+// KeyID retorna o identificador da chave, derivado da chave pública
+func (s *Signer) KeyID() string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.keyID
+}
+
+// PublicKey retorna a chave pública em base64, para conferência dos checkpoints fora da API
+func (s *Signer) PublicKey() string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return base64.StdEncoding.EncodeToString(s.key.Public().(ed25519.PublicKey))
+}
+
+func (s *Signer) sign(cp *Checkpoint, database string) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	cp.KeyID = s.keyID
+	cp.Signature = base64.StdEncoding.EncodeToString(ed25519.Sign(s.key, cp.message(database)))
+}
+
+func (s *Signer) verify(cp *Checkpoint, database string) bool {
+	sig, err := base64.StdEncoding.DecodeString(cp.Signature)
+	if err != nil {
+		return false
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return ed25519.Verify(s.key.Public().(ed25519.PublicKey), cp.message(database), sig)
+}
+
+// Checkpoint - assina o topo da cadeia de cada tenant que avançou desde o último checkpoint.
+// Retorna a quantidade de checkpoints criados.
+func (c *Chain) Checkpoint(ctx context.Context) (int, error) {
+	if c.signer == nil {
+		return 0, nil
+	}
+	scopes, err := c.tenants.Scopes(ctx, c.name)
+	if err != nil {
+		return 0, err
+	}
+
+	created := 0
+	for _, coll := range scopes {
+		head, err := c.head(ctx, coll, bson.M{})
+		if err != nil {
+			return created, err
+		}
+		last, err := c.lastCheckpoint(ctx, coll)
+		if err != nil {
+			return created, err
+		}
+		if head.Seq == 0 || (last != nil && last.Seq >= head.Seq) {
+			continue
+		}
+
+		cp := &Checkpoint{
+			Collection: c.name,
+			Tenant:     coll.Tenant(),
+			Seq:        head.Seq,
+			Hash:       head.Hash,
+			CreatedAt:  time.Now().UTC().Truncate(time.Millisecond),
+		}
+		c.signer.sign(cp, coll.Database().Name())
+
+		// Upsert para que duas instâncias assinando a mesma posição gravem um único checkpoint
+		filter := bson.M{"collection": cp.Collection, "tenant": cp.Tenant, "seq": cp.Seq}
+		_, err = checkpoints(coll).UpdateOne(ctx, filter, bson.M{"$setOnInsert": cp}, options.Update().SetUpsert(true))
+		if err != nil {
+			return created, err
+		}
+		created++
+	}
+	return created, nil
+}
+
+// lastCheckpoint retorna o checkpoint mais recente da cadeia no tenant, ou nil
+func (c *Chain) lastCheckpoint(ctx context.Context, coll database.Scope) (*Checkpoint, error) {
+	var cp Checkpoint
+	filter := bson.M{"collection": c.name, "tenant": coll.Tenant()}
+	err := checkpoints(coll).FindOne(ctx, filter, options.FindOne().SetSort(bson.D{{Key: "seq", Value: -1}})).Decode(&cp)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &cp, nil
+}
+
+// checkpointsOf retorna os checkpoints da cadeia no tenant, do mais antigo para o mais recente
+func (c *Chain) checkpointsOf(ctx context.Context, coll database.Scope) ([]*Checkpoint, error) {
+	filter := bson.M{"collection": c.name, "tenant": coll.Tenant()}
+	cursor, err := checkpoints(coll).Find(ctx, filter, options.Find().SetSort(bson.D{{Key: "seq", Value: 1}}))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	list := []*Checkpoint{}
+	if err = cursor.All(ctx, &list); err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+func checkpoints(coll database.Scope) *mongo.Collection {
+	return coll.Database().Collection(CheckpointCollection)
+}
+
+// RunCheckpoints assina o topo das cadeias a cada interval, até o ctx ser cancelado
+func RunCheckpoints(ctx context.Context, interval time.Duration, log logger.ILogger, chains ...*Chain) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			for _, c := range chains {
+				n, err := c.Checkpoint(ctx)
+				if err != nil {
+					log.Error("Erro ao criar os checkpoints da auditoria", "collection", c.name, "err", err.Error())
+					continue
+				}
+				if n > 0 {
+					log.Info("Checkpoints da auditoria criados", "collection", c.name, "checkpoints", n)
+				}
+			}
+		}
+	}
+}
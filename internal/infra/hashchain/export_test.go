@@ -0,0 +1,22 @@
+package hashchain
+
+import "go.mongodb.org/mongo-driver/bson"
+
+// Walk confere nos testes a cadeia dos documentos em memória, na ordem recebida, sem o MongoDB
+func (c *Chain) Walk(database string, keyed bool, docs []bson.Raw, cps []*Checkpoint, decode Decoder) *Report {
+	report := &Report{Collection: c.name, Database: database}
+	c.walk(report, keyed, cps, decode, func() (bson.Raw, bool) {
+		if len(docs) == 0 {
+			return nil, false
+		}
+		raw := docs[0]
+		docs = docs[1:]
+		return raw, true
+	})
+	return report
+}
+
+// Sign assina o checkpoint nos testes
+func (s *Signer) Sign(cp *Checkpoint, database string) {
+	s.sign(cp, database)
+}
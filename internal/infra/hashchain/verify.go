@@ -0,0 +1,251 @@
+package hashchain
+
+import (
+	"context"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/valdinei-santos/cpf-backend/internal/infra/database"
+)
+
+// Entry é um registro lido da cadeia
+type Entry struct {
+	ID      string // Identificador do registro, exibido no relatório
+	Key     string // Chave do registro. Ex: ID do cliente
+	Content []byte // Conteúdo canônico, ver Content
+	Link
+}
+
+// Decoder converte o documento gravado no Entry
+type Decoder func(raw bson.Raw) (Entry, error)
+
+// Broken é o primeiro elo quebrado da cadeia
+type Broken struct {
+	Seq    int64  // Posição do elo quebrado
+	ID     string // Registro do elo, vazio quando o registro foi removido
+	Reason string
+}
+
+// Report é o resultado da verificação da cadeia de um tenant
+type Report struct {
+	Collection  string
+	Database    string
+	Tenant      string
+	Checked     int64 // Registros encadeados verificados
+	Unchained   int64 // Registros anteriores ao encadeamento, que ficam fora da verificação
+	FirstSeq    int64
+	LastSeq     int64
+	Checkpoints int // Checkpoints assinados conferidos
+	Unverified  int // Checkpoints sem a chave para conferir, fora da verificação
+	Broken      *Broken
+}
+
+// OK indica se a cadeia está íntegra
+func (r *Report) OK() bool {
+	return r.Broken == nil
+}
+
+// Verify - percorre a cadeia do tenant da requisição, do registro mais antigo ao mais recente, e
+// para no primeiro elo quebrado. Com key, percorre apenas os registros da chave (ex: de um cliente).
+// A verificação completa, que também confere os checkpoints, é a sem key.
+func (c *Chain) Verify(ctx context.Context, key any, decode Decoder) (*Report, error) {
+	coll, err := c.tenants.Collection(ctx, c.name)
+	if err != nil {
+		return nil, err
+	}
+	return c.verify(ctx, coll, key, decode)
+}
+
+// VerifyAll - verifica a cadeia completa de todos os tenants
+func (c *Chain) VerifyAll(ctx context.Context, decode Decoder) ([]*Report, error) {
+	scopes, err := c.tenants.Scopes(ctx, c.name)
+	if err != nil {
+		return nil, err
+	}
+	reports := make([]*Report, 0, len(scopes))
+	for _, coll := range scopes {
+		report, err := c.verify(ctx, coll, nil, decode)
+		if err != nil {
+			return reports, err
+		}
+		reports = append(reports, report)
+	}
+	return reports, nil
+}
+
+func (c *Chain) verify(ctx context.Context, coll database.Scope, key any, decode Decoder) (*Report, error) {
+	report := &Report{Collection: c.name, Database: coll.Database().Name(), Tenant: coll.Tenant()}
+	filter := bson.M{"seq": bson.M{"$exists": true}}
+	var cps []*Checkpoint
+	var err error
+	if key != nil {
+		filter[c.keyField] = key
+	} else {
+		report.Unchained, err = coll.CountDocuments(ctx, coll.Filter(bson.M{"seq": bson.M{"$exists": false}}))
+		if err != nil {
+			return nil, err
+		}
+		cps, err = c.checkpointsOf(ctx, coll)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	cursor, err := coll.Find(ctx, coll.Filter(filter), options.Find().SetSort(bson.D{{Key: "seq", Value: 1}}))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	c.walk(report, key != nil, cps, decode, func() (bson.Raw, bool) {
+		if !cursor.Next(ctx) {
+			return nil, false
+		}
+		return cursor.Current, true
+	})
+	if err := cursor.Err(); err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+// walk confere os registros devolvidos por next, em ordem de seq, e os checkpoints cps da cadeia.
+// Para no primeiro elo quebrado.
+func (c *Chain) walk(report *Report, keyed bool, cps []*Checkpoint, decode Decoder, next func() (bson.Raw, bool)) {
+	w := &walker{keyed: keyed, expires: c.retention > 0, keys: map[string]string{}}
+	for report.Broken == nil {
+		raw, ok := next()
+		if !ok {
+			break
+		}
+		e, err := decode(raw)
+		if err != nil {
+			seq, _ := raw.Lookup("seq").AsInt64OK()
+			report.Broken = &Broken{Seq: seq, Reason: "registro ilegível: " + err.Error()}
+			break
+		}
+		report.Broken = w.next(e)
+
+		// Checkpoints até a posição atual
+		for report.Broken == nil && len(cps) > 0 && cps[0].Seq <= e.Seq {
+			report.Broken = c.checkCheckpoint(cps[0], report, w, e)
+			cps = cps[1:]
+		}
+	}
+
+	// Checkpoints depois do último registro: os registros do fim da cadeia foram removidos
+	for _, cp := range cps {
+		if report.Broken != nil {
+			break
+		}
+		verified, b := c.checkSignature(cp, report.Database)
+		switch {
+		case b != nil:
+			report.Broken = b
+		case !verified:
+			report.Unverified++
+		case w.checked > 0 || !w.expires:
+			// Sem nenhum registro e com retenção, todos podem ter expirado
+			report.Broken = &Broken{Seq: w.last + 1, Reason: fmt.Sprintf("registros removidos: o checkpoint assinado vai até o seq %d", cp.Seq)}
+		}
+	}
+
+	report.Checked, report.FirstSeq, report.LastSeq = w.checked, w.first, w.last
+}
+
+// checkCheckpoint confere a assinatura e o hash de um checkpoint com posição até a do registro atual
+func (c *Chain) checkCheckpoint(cp *Checkpoint, report *Report, w *walker, e Entry) *Broken {
+	verified, b := c.checkSignature(cp, report.Database)
+	if b != nil {
+		return b
+	}
+	if !verified {
+		report.Unverified++
+		return nil
+	}
+	switch {
+	case cp.Seq == w.first-1:
+		// Checkpoint do registro anterior ao primeiro, removido pela retenção
+		if cp.Hash != w.firstPrev {
+			return &Broken{Seq: w.first, ID: e.ID, Reason: "prev_hash do primeiro registro não confere com o checkpoint assinado"}
+		}
+	case cp.Seq < w.first:
+		return nil // Registro removido pela retenção, não há o que conferir
+	case cp.Seq == e.Seq:
+		if cp.Hash != e.Hash {
+			return &Broken{Seq: e.Seq, ID: e.ID, Reason: "hash diferente do checkpoint assinado"}
+		}
+	default:
+		return &Broken{Seq: cp.Seq, Reason: "registro do checkpoint assinado não encontrado"}
+	}
+	report.Checkpoints++
+	return nil
+}
+
+// checkSignature confere a assinatura do checkpoint. Retorna false nos checkpoints que não podem ser
+// conferidos: sem a chave ou assinados com outra (ex: antes de uma troca da chave). Esses não
+// servem de referência para a cadeia, já que quem grava no banco também pode forjá-los.
+func (c *Chain) checkSignature(cp *Checkpoint, database string) (bool, *Broken) {
+	if c.signer == nil || cp.KeyID != c.signer.KeyID() {
+		return false, nil
+	}
+	if !c.signer.verify(cp, database) {
+		return true, &Broken{Seq: cp.Seq, Reason: "assinatura do checkpoint inválida"}
+	}
+	return true, nil
+}
+
+// walker confere os elos dos registros, recebidos em ordem de seq
+type walker struct {
+	keyed     bool              // Percorre apenas os registros de uma chave
+	expires   bool              // Os registros mais antigos podem ter sido removidos pela retenção
+	keys      map[string]string // Hash do último registro de cada chave
+	checked   int64
+	first     int64
+	firstPrev string
+	last      int64
+	lastHash  string
+}
+
+func (w *walker) next(e Entry) *Broken {
+	if e.Hash != Compute(e.Seq, e.PrevHash, e.KeyPrevHash, e.Content) {
+		return &Broken{Seq: e.Seq, ID: e.ID, Reason: "hash não confere: registro alterado"}
+	}
+
+	switch {
+	case w.checked == 0:
+		w.first, w.firstPrev = e.Seq, e.PrevHash
+		if w.keyed {
+			break
+		}
+		if e.Seq == 1 && e.PrevHash != "" {
+			return &Broken{Seq: e.Seq, ID: e.ID, Reason: "primeiro registro com prev_hash"}
+		}
+		if e.Seq > 1 && !w.expires {
+			return &Broken{Seq: 1, Reason: fmt.Sprintf("registros removidos: a cadeia começa no seq %d", e.Seq)}
+		}
+	case w.keyed:
+		if e.KeyPrevHash != w.lastHash {
+			return &Broken{Seq: e.Seq, ID: e.ID, Reason: "key_prev_hash não confere com o registro anterior da mesma chave"}
+		}
+	case e.Seq != w.last+1:
+		return &Broken{Seq: w.last + 1, Reason: fmt.Sprintf("registros removidos entre os seq %d e %d", w.last, e.Seq)}
+	case e.PrevHash != w.lastHash:
+		return &Broken{Seq: e.Seq, ID: e.ID, Reason: "prev_hash não confere com o registro anterior"}
+	}
+
+	if !w.keyed {
+		if prev, ok := w.keys[e.Key]; ok && e.KeyPrevHash != prev {
+			return &Broken{Seq: e.Seq, ID: e.ID, Reason: "key_prev_hash não confere com o registro anterior da mesma chave"}
+		} else if !ok && w.first == 1 && e.KeyPrevHash != "" {
+			return &Broken{Seq: e.Seq, ID: e.ID, Reason: "key_prev_hash aponta para um registro inexistente"}
+		}
+		w.keys[e.Key] = e.Hash
+	}
+
+	w.checked++
+	w.last, w.lastHash = e.Seq, e.Hash
+	return nil
+}
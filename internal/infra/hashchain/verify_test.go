@@ -0,0 +1,279 @@
+package hashchain_test
+
+import (
+	"crypto/ed25519"
+	"fmt"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+
+	"github.com/valdinei-santos/cpf-backend/internal/infra/hashchain"
+	"github.com/valdinei-santos/cpf-backend/internal/infra/logger"
+)
+
+const dbName = "cpf_test"
+
+// registro é o documento gravado nos testes, com o conteúdo e os campos do encadeamento
+type registro struct {
+	ID             string `bson:"id"`
+	Cliente        string `bson:"cliente_id"`
+	Dados          string `bson:"dados"`
+	hashchain.Link `bson:",inline"`
+}
+
+func decode(raw bson.Raw) (hashchain.Entry, error) {
+	var r registro
+	if err := bson.Unmarshal(raw, &r); err != nil {
+		return hashchain.Entry{}, err
+	}
+	return hashchain.Entry{ID: r.ID, Key: r.Cliente, Content: []byte(r.Dados), Link: r.Link}, nil
+}
+
+// encadear monta a cadeia como o Append: um registro por cliente, cada um apontando para o anterior
+// da cadeia e para o anterior do mesmo cliente
+func encadear(clientes ...string) []registro {
+	chain := make([]registro, len(clientes))
+	for i, cliente := range clientes {
+		chain[i] = registro{ID: fmt.Sprintf("r%d", i+1), Cliente: cliente, Dados: fmt.Sprintf("acesso %d", i+1)}
+		chain[i].Seq = int64(i + 1)
+	}
+	return reencadear(chain, "")
+}
+
+// reencadear recalcula os hashes a partir do primeiro registro, como faria quem reescreve a cadeia
+// direto no banco
+func reencadear(chain []registro, prevHash string) []registro {
+	chain = slices.Clone(chain)
+	keyPrev := map[string]string{}
+	for i := range chain {
+		r := &chain[i]
+		r.PrevHash, r.KeyPrevHash = prevHash, keyPrev[r.Cliente]
+		r.Hash = hashchain.Compute(r.Seq, r.PrevHash, r.KeyPrevHash, []byte(r.Dados))
+		prevHash, keyPrev[r.Cliente] = r.Hash, r.Hash
+	}
+	return chain
+}
+
+// alterar retorna uma cópia da cadeia com o conteúdo do registro i alterado, sem refazer o hash
+func alterar(chain []registro, i int) []registro {
+	chain = slices.Clone(chain)
+	chain[i].Dados = "alterado"
+	return chain
+}
+
+func newSigner(t *testing.T) *hashchain.Signer {
+	_, key, err := ed25519.GenerateKey(nil)
+	require.NoError(t, err)
+	return hashchain.NewSigner(key)
+}
+
+// checkpoint assina a posição do registro com o signer
+func checkpoint(signer *hashchain.Signer, r registro) *hashchain.Checkpoint {
+	cp := &hashchain.Checkpoint{Collection: "acesso", Seq: r.Seq, Hash: r.Hash, CreatedAt: time.Now().UTC().Truncate(time.Millisecond)}
+	signer.Sign(cp, dbName)
+	return cp
+}
+
+func TestVerify(t *testing.T) {
+	signer, outraChave := newSigner(t), newSigner(t)
+	chain := encadear("a", "b", "a", "c", "a")
+
+	adulterado := checkpoint(signer, chain[2])
+	adulterado.Hash = chain[3].Hash
+
+	// Registros do cliente a, na ordem da cadeia
+	doClienteA := []registro{chain[0], chain[2], chain[4]}
+
+	tests := []struct {
+		name           string
+		docs           []registro
+		cps            []*hashchain.Checkpoint
+		keyed          bool
+		retention      bool
+		noSigner       bool
+		expectedSeq    int64  // Seq do elo quebrado, 0 para a cadeia íntegra
+		expectedReason string // Trecho do motivo do elo quebrado
+		expectedFirst  int64
+		expectedLast   int64
+		expectedCps    int
+		expectedUnver  int
+	}{
+		{
+			name:          "Deve validar a cadeia íntegra",
+			docs:          chain,
+			expectedFirst: 1,
+			expectedLast:  5,
+		},
+		{
+			name:           "Deve detectar o registro alterado",
+			docs:           alterar(chain, 2),
+			expectedSeq:    3,
+			expectedReason: "hash não confere",
+		},
+		{
+			name:           "Deve detectar o registro removido do meio da cadeia",
+			docs:           slices.Delete(slices.Clone(chain), 2, 3),
+			expectedSeq:    3,
+			expectedReason: "registros removidos entre os seq 2 e 4",
+		},
+		{
+			name:           "Deve detectar os registros removidos do início sem retenção",
+			docs:           chain[2:],
+			expectedSeq:    1,
+			expectedReason: "a cadeia começa no seq 3",
+		},
+		{
+			name:          "Deve aceitar o início removido pela retenção",
+			docs:          chain[2:],
+			retention:     true,
+			expectedFirst: 3,
+			expectedLast:  5,
+		},
+		{
+			name:          "Deve validar os registros de um cliente",
+			docs:          doClienteA,
+			keyed:         true,
+			expectedFirst: 1,
+			expectedLast:  5,
+		},
+		{
+			name:           "Deve detectar o registro do cliente removido",
+			docs:           []registro{chain[0], chain[4]},
+			keyed:          true,
+			expectedSeq:    5,
+			expectedReason: "key_prev_hash não confere",
+		},
+		{
+			name:          "Deve conferir o checkpoint assinado",
+			docs:          chain,
+			cps:           []*hashchain.Checkpoint{checkpoint(signer, chain[2]), checkpoint(signer, chain[4])},
+			expectedFirst: 1,
+			expectedLast:  5,
+			expectedCps:   2,
+		},
+		{
+			name:           "Deve detectar pelo checkpoint a reescrita da cadeia inteira",
+			docs:           reencadear(alterar(chain, 1), ""),
+			cps:            []*hashchain.Checkpoint{checkpoint(signer, chain[2])},
+			expectedSeq:    3,
+			expectedReason: "hash diferente do checkpoint assinado",
+		},
+		{
+			name:           "Deve detectar pelo checkpoint os registros removidos do fim",
+			docs:           chain[:3],
+			cps:            []*hashchain.Checkpoint{checkpoint(signer, chain[4])},
+			expectedSeq:    4,
+			expectedReason: "o checkpoint assinado vai até o seq 5",
+		},
+		{
+			name:           "Deve detectar o checkpoint com a assinatura inválida",
+			docs:           chain,
+			cps:            []*hashchain.Checkpoint{adulterado},
+			expectedSeq:    3,
+			expectedReason: "assinatura do checkpoint inválida",
+		},
+		{
+			name:          "Deve aceitar o checkpoint depois do fim com todos os registros expirados",
+			docs:          nil,
+			cps:           []*hashchain.Checkpoint{checkpoint(signer, chain[4])},
+			retention:     true,
+			expectedFirst: 0,
+			expectedLast:  0,
+		},
+		{
+			name:          "Deve conferir o primeiro registro mantido pela retenção com o checkpoint anterior",
+			docs:          chain[2:],
+			cps:           []*hashchain.Checkpoint{checkpoint(signer, chain[1])},
+			retention:     true,
+			expectedFirst: 3,
+			expectedLast:  5,
+			expectedCps:   1,
+		},
+		{
+			name:           "Deve detectar a cadeia reescrita depois do checkpoint da retenção",
+			docs:           reencadear(chain[2:], "forjado"),
+			cps:            []*hashchain.Checkpoint{checkpoint(signer, chain[1])},
+			retention:      true,
+			expectedSeq:    3,
+			expectedReason: "prev_hash do primeiro registro não confere",
+		},
+		{
+			name:          "Deve ignorar o checkpoint de outra chave sem usá-lo como referência",
+			docs:          chain[:3],
+			cps:           []*hashchain.Checkpoint{checkpoint(outraChave, chain[1]), checkpoint(outraChave, chain[4])},
+			expectedFirst: 1,
+			expectedLast:  3,
+			expectedUnver: 2,
+		},
+		{
+			name:          "Deve ignorar os checkpoints sem a chave configurada",
+			docs:          reencadear(alterar(chain, 1), ""),
+			cps:           []*hashchain.Checkpoint{checkpoint(signer, chain[2])},
+			noSigner:      true,
+			expectedFirst: 1,
+			expectedLast:  5,
+			expectedUnver: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var retention time.Duration
+			if tt.retention {
+				retention = 24 * time.Hour
+			}
+			chainSigner := signer
+			if tt.noSigner {
+				chainSigner = nil
+			}
+			c := hashchain.NewChain(nil, "acesso", "cliente_id", retention, chainSigner, logger.NewMockILogger())
+			docs := make([]bson.Raw, len(tt.docs))
+			for i, r := range tt.docs {
+				raw, err := bson.Marshal(r)
+				require.NoError(t, err)
+				docs[i] = raw
+			}
+
+			report := c.Walk(dbName, tt.keyed, docs, tt.cps, decode)
+
+			if tt.expectedSeq != 0 {
+				require.NotNil(t, report.Broken)
+				assert.False(t, report.OK())
+				assert.Equal(t, tt.expectedSeq, report.Broken.Seq)
+				assert.Contains(t, report.Broken.Reason, tt.expectedReason)
+				return
+			}
+			assert.True(t, report.OK(), "elo quebrado: %+v", report.Broken)
+			assert.Equal(t, int64(len(tt.docs)), report.Checked)
+			assert.Equal(t, tt.expectedFirst, report.FirstSeq)
+			assert.Equal(t, tt.expectedLast, report.LastSeq)
+			assert.Equal(t, tt.expectedCps, report.Checkpoints)
+			assert.Equal(t, tt.expectedUnver, report.Unverified)
+		})
+	}
+}
+
+func TestCompute(t *testing.T) {
+	base := hashchain.Compute(2, "prev", "key_prev", []byte("conteúdo"))
+
+	tests := []struct {
+		name string
+		hash string
+	}{
+		{name: "Deve mudar com a posição", hash: hashchain.Compute(3, "prev", "key_prev", []byte("conteúdo"))},
+		{name: "Deve mudar com o hash anterior", hash: hashchain.Compute(2, "outro", "key_prev", []byte("conteúdo"))},
+		{name: "Deve mudar com o hash anterior da chave", hash: hashchain.Compute(2, "prev", "outro", []byte("conteúdo"))},
+		{name: "Deve mudar com o conteúdo", hash: hashchain.Compute(2, "prev", "key_prev", []byte("alterado"))},
+	}
+
+	assert.Equal(t, base, hashchain.Compute(2, "prev", "key_prev", []byte("conteúdo")))
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.NotEqual(t, base, tt.hash)
+		})
+	}
+}
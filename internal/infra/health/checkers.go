@@ -0,0 +1,37 @@
+package health
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/readpref"
+
+	"github.com/valdinei-santos/cpf-backend/internal/infra/worker"
+)
+
+// Mongo verifica o MongoDB com um ping no primário, onde são feitas as gravações
+func Mongo(client *mongo.Client) Checker {
+	return CheckerFunc(func(ctx context.Context) error {
+		return client.Ping(ctx, readpref.Primary())
+	})
+}
+
+// Workers verifica se as rotinas em segundo plano do grupo continuam em execução
+func Workers(group *worker.Group) Checker {
+	return CheckerFunc(func(ctx context.Context) error {
+		var stopped []string
+		for name, running := range group.Status() {
+			if !running {
+				stopped = append(stopped, name)
+			}
+		}
+		if len(stopped) > 0 {
+			sort.Strings(stopped)
+			return fmt.Errorf("workers parados: %s", strings.Join(stopped, ", "))
+		}
+		return nil
+	})
+}
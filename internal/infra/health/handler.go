@@ -0,0 +1,40 @@
+package health
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/valdinei-santos/cpf-backend/internal/domain/globalerr"
+)
+
+// Live responde 200 enquanto o processo estiver atendendo, sem verificar as dependências, para
+// que uma falha do banco não faça o orquestrador reiniciar a API.
+func Live(startTime time.Time) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Header("Cache-Control", "no-store")
+		c.JSON(http.StatusOK, gin.H{
+			"status": StatusUp,
+			"uptime": time.Since(startTime).Round(time.Second).String(),
+		})
+	}
+}
+
+// Ready responde com o resultado das verificações: 200 com a API no ar ou degradada e 503 quando
+// uma verificação crítica falhou, no formato padrão de erro da API acrescido das verificações.
+func (r *Registry) Ready(c *gin.Context) {
+	report := r.Check()
+	c.Header("Cache-Control", "no-store")
+	if report.Status != StatusDown {
+		c.JSON(http.StatusOK, report)
+		return
+	}
+	c.JSON(http.StatusServiceUnavailable, gin.H{
+		"title":      globalerr.ErrHttp503.Error(),
+		"detail":     "verificações críticas com falha: " + strings.Join(report.Failed(true), ", "),
+		"status":     report.Status,
+		"checks":     report.Checks,
+		"checked_at": report.CheckedAt,
+	})
+}
@@ -0,0 +1,146 @@
+// Pacote com as verificações de saúde (health checks) da API: liveness e readiness com
+// verificadores plugáveis das dependências.
+package health
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/valdinei-santos/cpf-backend/internal/infra/logger"
+)
+
+// Situação de cada verificação e da API
+const (
+	StatusUp       = "up"
+	StatusDegraded = "degraded" // Uma verificação não crítica falhou, a API continua atendendo
+	StatusDown     = "down"     // Uma verificação crítica falhou
+)
+
+// Checker verifica uma dependência. Deve respeitar o prazo do ctx.
+type Checker interface {
+	Check(ctx context.Context) error
+}
+
+// CheckerFunc adapta uma função para Checker
+type CheckerFunc func(ctx context.Context) error
+
+func (f CheckerFunc) Check(ctx context.Context) error {
+	return f(ctx)
+}
+
+// Result é o resultado de uma verificação
+type Result struct {
+	Status    string  `json:"status"`
+	Critical  bool    `json:"critical"`
+	LatencyMs float64 `json:"latency_ms"`
+	Error     string  `json:"error,omitempty"`
+}
+
+// Report é o resultado de todas as verificações
+type Report struct {
+	Status    string            `json:"status"`
+	Checks    map[string]Result `json:"checks"`
+	CheckedAt time.Time         `json:"checked_at"`
+}
+
+// Failed retorna, em ordem alfabética, as verificações com falha críticas (critical=true) ou não críticas
+func (r *Report) Failed(critical bool) []string {
+	var names []string
+	for name, res := range r.Checks {
+		if res.Status != StatusUp && res.Critical == critical {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+type check struct {
+	name     string
+	critical bool
+	checker  Checker
+}
+
+// Registry guarda os verificadores da readiness. O resultado fica em cache por ttl, para que as
+// sondas do orquestrador não sobrecarreguem o banco.
+type Registry struct {
+	timeout time.Duration
+	ttl     time.Duration
+	log     logger.ILogger
+	mu      sync.Mutex
+	checks  []check
+	cached  *Report
+}
+
+// NewRegistry - cria o Registry. timeout é o prazo de cada verificação.
+func NewRegistry(timeout time.Duration, ttl time.Duration, log logger.ILogger) *Registry {
+	return &Registry{timeout: timeout, ttl: ttl, log: log}
+}
+
+// Register - adiciona um verificador. A falha de um verificador crítico deixa a API fora do ar
+// (503) para o orquestrador, a de um não crítico apenas degradada.
+func (r *Registry) Register(name string, critical bool, checker Checker) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.checks = append(r.checks, check{name: name, critical: critical, checker: checker})
+	r.cached = nil
+}
+
+// Check - executa as verificações em paralelo, ou retorna o resultado em cache. As requisições
+// que chegam durante a execução esperam o mesmo resultado.
+func (r *Registry) Check() *Report {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.cached != nil && time.Since(r.cached.CheckedAt) < r.ttl {
+		return r.cached
+	}
+
+	report := &Report{Status: StatusUp, Checks: make(map[string]Result, len(r.checks)), CheckedAt: time.Now().UTC()}
+	results := make([]Result, len(r.checks))
+	var wg sync.WaitGroup
+	for i, c := range r.checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i] = r.run(c)
+		}()
+	}
+	wg.Wait()
+
+	for i, c := range r.checks {
+		res := results[i]
+		report.Checks[c.name] = res
+		if res.Status == StatusUp {
+			continue
+		}
+		if c.critical {
+			report.Status = StatusDown
+		} else if report.Status == StatusUp {
+			report.Status = StatusDegraded
+		}
+		r.log.Warn("Verificação de saúde com falha", "check", c.name, "critical", c.critical, "err", res.Error)
+	}
+	r.cached = report
+	return report
+}
+
+// run executa uma verificação com o prazo do Registry. O prazo não depende da requisição, porque
+// o resultado é compartilhado pelo cache.
+func (r *Registry) run(c check) Result {
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	start := time.Now()
+	err := c.checker.Check(ctx)
+	res := Result{
+		Status:    StatusUp,
+		Critical:  c.critical,
+		LatencyMs: float64(time.Since(start).Microseconds()) / 1000,
+	}
+	if err != nil {
+		res.Status, res.Error = StatusDown, err.Error()
+	}
+	return res
+}
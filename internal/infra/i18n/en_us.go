@@ -0,0 +1,78 @@
+package i18n
+
+// Catálogo en-US, com as mesmas chaves do pt-BR
+var enUS = map[string]string{
+	// Títulos
+	"status.400": "invalid request data",
+	"status.401": "unauthenticated",
+	"status.403": "access denied",
+	"status.404": "resource not found",
+	"status.405": "method not allowed",
+	"status.409": "resource conflict",
+	"status.422": "unprocessable entity",
+	"status.429": "too many requests",
+	"status.499": "request canceled",
+	"status.500": "unexpected server error",
+	"status.503": "service unavailable",
+	"status.504": "response time exceeded",
+
+	// Erros comuns
+	"bad_request":          "malformed request data",
+	"validation_failed":    "one or more fields are invalid",
+	"invalid":              "invalid value",
+	"unauthorized":         "access credential missing or invalid",
+	"forbidden":            "insufficient permission for the operation",
+	"not_found":            "not found",
+	"route_not_found":      "route not found",
+	"method_not_allowed":   "method not supported by the route, see the Allow header",
+	"duplicate_key":        "duplicate key",
+	"too_many_requests":    "request limit exceeded, try again later",
+	"canceled":             "request canceled by the client",
+	"internal":             "internal server error",
+	"database_write":       "error saving to the database",
+	"database_unavailable": "database connection error",
+	"timeout":              "the operation did not finish in time",
+
+	// Cliente
+	"cliente.nome_invalid":      "name must be between 3 and 50 characters",
+	"cliente.documento_invalid": "document must have between 11 and 14 digits",
+	"cliente.telefone_invalid":  "phone must have between 3 and 11 digits",
+	"cliente.bloqueado_invalid": "blocked must be true or false",
+	"cliente.id_invalid":        "invalid ID",
+	"cliente.uuid_invalid":      "invalid UUID",
+	"cliente.not_found":         "customer not found",
+	"cliente.none_found":        "no customers found",
+	"cliente.duplicate":         "record already exists",
+
+	// Consentimento, log de acesso e auditoria do cliente
+	"consentimento.finalidade_invalid":      "purpose must have between 3 and 50 lowercase letters, digits or _",
+	"consentimento.base_legal_invalid":      "invalid legal basis",
+	"consentimento.canal_invalid":           "channel must be between 2 and 30 characters",
+	"consentimento.versao_politica_invalid": "policy version must be between 1 and 20 characters",
+	"consentimento.not_found":               "consent not found",
+	"consentimento.already_revoked":         "consent is already revoked",
+	"acesso.operador_required":              "provide the operator to query",
+	"auditoria.cadeia_invalid":              "invalid chain, use consentimento or acesso",
+	"auditoria.chain_conflict":              "could not chain the record: conflict with another write",
+
+	// Chave de API
+	"apikey.nome_invalid":       "name must be between 3 and 50 characters",
+	"apikey.owner_invalid":      "owner must be between 3 and 100 characters",
+	"apikey.scopes_invalid":     "provide at least one scope with 2 to 50 lowercase letters, digits or _ : . -",
+	"apikey.expires_at_invalid": "expiration must be a future date",
+	"apikey.tenant_invalid":     "invalid tenant, use 2 to 32 lowercase letters, digits, _ or -",
+	"apikey.id_invalid":         "invalid ID",
+	"apikey.not_found":          "API key not found",
+	"apikey.revoked":            "API key revoked",
+	"apikey.expired":            "API key expired",
+	"apikey.invalid":            "invalid API key",
+
+	// Infraestrutura
+	"tenant.required":   "tenant not provided",
+	"tenant.invalid":    "invalid tenant, use 2 to 32 lowercase letters, digits, _ or -",
+	"tenant.mismatch":   "header tenant differs from the credential tenant",
+	"tenant.no_claim":   "credential without tenant",
+	"tenant.unknown":    "tenant not enabled in this installation",
+	"quota_exceeded":    "daily request quota exceeded",
+	"log.level_invalid": "invalid log level, use debug, info, warn or error",
+}
@@ -0,0 +1,51 @@
+// Pacote com os catálogos de mensagens da API. As mensagens são indexadas pelo código estável do
+// erro (ex: cliente.not_found) e o idioma é escolhido pelo header Accept-Language.
+package i18n
+
+import (
+	"golang.org/x/text/language"
+)
+
+// Idiomas suportados
+const (
+	PtBR = "pt-BR" // Padrão
+	EnUS = "en-US"
+)
+
+// HeaderAcceptLanguage é o header com os idiomas aceitos pelo chamador
+const HeaderAcceptLanguage = "Accept-Language"
+
+// Catálogos por idioma. O primeiro idioma é o padrão, usado quando o chamador não informa o
+// Accept-Language ou não aceita nenhum dos suportados.
+var (
+	languages = []string{PtBR, EnUS}
+	catalogs  = map[string]map[string]string{PtBR: ptBR, EnUS: enUS}
+	matcher   = language.NewMatcher([]language.Tag{language.BrazilianPortuguese, language.AmericanEnglish})
+)
+
+// Negotiate retorna o idioma suportado que melhor atende ao Accept-Language. Aceita pesos e
+// variantes, ex: "en-GB,en;q=0.9" resulta em en-US e "pt" em pt-BR.
+func Negotiate(acceptLanguage string) string {
+	if acceptLanguage == "" {
+		return languages[0]
+	}
+	tags, _, err := language.ParseAcceptLanguage(acceptLanguage)
+	if err != nil || len(tags) == 0 {
+		return languages[0]
+	}
+	_, index, confidence := matcher.Match(tags...)
+	if confidence == language.No {
+		return languages[0]
+	}
+	return languages[index]
+}
+
+// Message retorna a mensagem da chave no idioma. Sem tradução no idioma, retorna a do idioma
+// padrão; sem mensagem em nenhum catálogo, retorna false.
+func Message(lang, key string) (string, bool) {
+	if msg, ok := catalogs[lang][key]; ok {
+		return msg, true
+	}
+	msg, ok := catalogs[languages[0]][key]
+	return msg, ok
+}
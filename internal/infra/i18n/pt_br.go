@@ -0,0 +1,79 @@
+package i18n
+
+// Catálogo pt-BR. As chaves são os códigos dos erros registrados no pacote problem e, para os
+// títulos, status.<código HTTP>.
+var ptBR = map[string]string{
+	// Títulos
+	"status.400": "dados de requisição inválidos",
+	"status.401": "não autenticado",
+	"status.403": "acesso não permitido",
+	"status.404": "recurso não encontrado",
+	"status.405": "método não permitido",
+	"status.409": "conflito de recurso",
+	"status.422": "entidade inutilizável",
+	"status.429": "muitas requisições",
+	"status.499": "requisição cancelada",
+	"status.500": "erro inesperado no servidor",
+	"status.503": "serviço indisponível",
+	"status.504": "tempo de resposta esgotado",
+
+	// Erros comuns
+	"bad_request":          "erro nos dados do request",
+	"validation_failed":    "um ou mais campos são inválidos",
+	"invalid":              "valor inválido",
+	"unauthorized":         "credencial de acesso ausente ou inválida",
+	"forbidden":            "permissão insuficiente para a operação",
+	"not_found":            "não encontrado",
+	"route_not_found":      "rota não encontrada",
+	"method_not_allowed":   "método não suportado pela rota, veja o header Allow",
+	"duplicate_key":        "chave duplicada",
+	"too_many_requests":    "limite de requisições excedido, tente novamente mais tarde",
+	"canceled":             "requisição cancelada pelo cliente",
+	"internal":             "erro interno no servidor",
+	"database_write":       "erro ao salvar no banco de dados",
+	"database_unavailable": "erro de conexão com o banco de dados",
+	"timeout":              "a operação não terminou dentro do prazo",
+
+	// Cliente
+	"cliente.nome_invalid":      "o nome deve ter entre 3 e 50 caracteres",
+	"cliente.documento_invalid": "o documento deve ter entre 11 e 14 digitos",
+	"cliente.telefone_invalid":  "o telefone deve ter entre 3 e 11 digitos",
+	"cliente.bloqueado_invalid": "o bloqueio deve ser true ou false",
+	"cliente.id_invalid":        "ID inválido",
+	"cliente.uuid_invalid":      "UUID inválido",
+	"cliente.not_found":         "cliente não encontrado",
+	"cliente.none_found":        "nenhum cliente encontrado",
+	"cliente.duplicate":         "registro já existe",
+
+	// Consentimento, log de acesso e auditoria do cliente
+	"consentimento.finalidade_invalid":      "a finalidade deve ter entre 3 e 50 caracteres minúsculos, números ou _",
+	"consentimento.base_legal_invalid":      "base legal inválida",
+	"consentimento.canal_invalid":           "o canal deve ter entre 2 e 30 caracteres",
+	"consentimento.versao_politica_invalid": "a versão da política deve ter entre 1 e 20 caracteres",
+	"consentimento.not_found":               "consentimento não encontrado",
+	"consentimento.already_revoked":         "consentimento já está revogado",
+	"acesso.operador_required":              "informe o operador a consultar",
+	"auditoria.cadeia_invalid":              "cadeia inválida, use consentimento ou acesso",
+	"auditoria.chain_conflict":              "não foi possível encadear o registro: conflito com outra gravação",
+
+	// Chave de API
+	"apikey.nome_invalid":       "o nome deve ter entre 3 e 50 caracteres",
+	"apikey.owner_invalid":      "o responsável deve ter entre 3 e 100 caracteres",
+	"apikey.scopes_invalid":     "informe ao menos um escopo com 2 a 50 caracteres minúsculos, números ou _ : . -",
+	"apikey.expires_at_invalid": "a expiração deve ser uma data futura",
+	"apikey.tenant_invalid":     "tenant inválido, use de 2 a 32 caracteres minúsculos, números, _ ou -",
+	"apikey.id_invalid":         "ID inválido",
+	"apikey.not_found":          "chave de API não encontrada",
+	"apikey.revoked":            "chave de API revogada",
+	"apikey.expired":            "chave de API expirada",
+	"apikey.invalid":            "chave de API inválida",
+
+	// Infraestrutura
+	"tenant.required":   "tenant não informado",
+	"tenant.invalid":    "tenant inválido, use de 2 a 32 caracteres minúsculos, números, _ ou -",
+	"tenant.mismatch":   "tenant do header diferente do tenant da credencial",
+	"tenant.no_claim":   "credencial sem tenant",
+	"tenant.unknown":    "tenant não habilitado nesta instalação",
+	"quota_exceeded":    "cota diária de requisições excedida",
+	"log.level_invalid": "nível do log inválido, use debug, info, warn ou error",
+}
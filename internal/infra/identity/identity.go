@@ -0,0 +1,49 @@
+// Pacote com a identidade de quem está chamando a API, guardada no contexto da requisição.
+package identity
+
+import (
+	"context"
+	"slices"
+)
+
+type ctxKey struct{}
+
+// Identity é a identidade autenticada do chamador (usuário ou serviço).
+type Identity struct {
+	Subject string         // Identificador do usuário ou do serviço
+	Roles   []string       // Papéis do chamador. Ex: reader, operator, supervisor, admin
+	Scopes  []string       // Escopos concedidos ao token ou à chave de API
+	Claims  map[string]any // Claims originais do token, quando houver
+	Method  string         // Forma de autenticação. Ex: jwt, api_key
+}
+
+// Anonymous é a identidade usada quando a requisição não foi autenticada.
+var Anonymous = &Identity{Subject: "anonymous"}
+
+// NewContext retorna uma cópia do contexto com a identidade.
+func NewContext(ctx context.Context, id *Identity) context.Context {
+	return context.WithValue(ctx, ctxKey{}, id)
+}
+
+// FromContext retorna a identidade do contexto ou Anonymous se não houver.
+func FromContext(ctx context.Context) *Identity {
+	if id, ok := ctx.Value(ctxKey{}).(*Identity); ok && id != nil {
+		return id
+	}
+	return Anonymous
+}
+
+// HasRole indica se a identidade possui o papel.
+func (i *Identity) HasRole(role string) bool {
+	return slices.Contains(i.Roles, role)
+}
+
+// HasScope indica se a identidade possui o escopo.
+func (i *Identity) HasScope(scope string) bool {
+	return slices.Contains(i.Scopes, scope)
+}
+
+// IsAnonymous indica se a requisição não foi autenticada.
+func (i *Identity) IsAnonymous() bool {
+	return i == Anonymous
+}
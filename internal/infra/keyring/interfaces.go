@@ -0,0 +1,15 @@
+package keyring
+
+// IFieldCipher define a criptografia de campos sensíveis feita pela aplicação antes de gravar no banco.
+type IFieldCipher interface {
+	// Encrypt cifra o valor. O aad (dado adicional autenticado) amarra o valor cifrado ao campo/registro.
+	Encrypt(plain string, aad string) (string, error)
+	// Decrypt decifra o valor. Valores que não estão cifrados são retornados como estão.
+	Decrypt(value string, aad string) (string, error)
+	// BlindIndex retorna um hash determinístico com chave, usado para busca por igualdade e unicidade.
+	BlindIndex(field string, value string) string
+	// ActiveKeyID retorna o identificador da chave usada nas novas gravações.
+	ActiveKeyID() string
+	// KeyID retorna o identificador da chave usada no valor cifrado, ou "" se o valor não estiver cifrado.
+	KeyID(value string) string
+}
@@ -0,0 +1,171 @@
+// Pacote com a criptografia de campos (field-level encryption) usando chaves de um arquivo local.
+package keyring
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// Prefixo dos valores cifrados. Formato: enc:v1:<key_id>:<base64(nonce|ciphertext)>
+const encPrefix = "enc:v1:"
+
+var (
+	ErrKeyringInvalid = errors.New("arquivo de chaves inválido")
+	ErrKeyNotFound    = errors.New("chave de criptografia não encontrada no keyring")
+	ErrCiphertext     = errors.New("valor cifrado inválido")
+)
+
+// keyringFile é o formato do arquivo de chaves. As chaves são base64 de 32 bytes (AES-256 / HMAC-SHA256).
+//
+//	{
+//	  "active_key": "2025-10",
+//	  "keys": {"2025-01": "...", "2025-10": "..."},
+//	  "blind_index_key": "..."
+//	}
+type keyringFile struct {
+	ActiveKey     string            `json:"active_key"`
+	Keys          map[string]string `json:"keys"`
+	BlindIndexKey string            `json:"blind_index_key"`
+}
+
+// Keyring cifra com AES-256-GCM usando a chave ativa e decifra com qualquer chave do arquivo,
+// o que permite a rotação: adiciona-se uma nova chave, ela passa a ser a ativa e os registros
+// antigos são recifrados em lotes.
+type Keyring struct {
+	activeKey  string
+	aeads      map[string]cipher.AEAD
+	blindIndex []byte
+}
+
+// Load carrega o keyring de um arquivo JSON local.
+func Load(path string) (*Keyring, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao ler o arquivo de chaves %s: %w", path, err)
+	}
+	var f keyringFile
+	if err := json.Unmarshal(data, &f); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrKeyringInvalid, err)
+	}
+	return newKeyring(f)
+}
+
+func newKeyring(f keyringFile) (*Keyring, error) {
+	if f.ActiveKey == "" || len(f.Keys) == 0 {
+		return nil, fmt.Errorf("%w: active_key e keys são obrigatórios", ErrKeyringInvalid)
+	}
+	if _, ok := f.Keys[f.ActiveKey]; !ok {
+		return nil, fmt.Errorf("%w: active_key %q não existe em keys", ErrKeyringInvalid, f.ActiveKey)
+	}
+
+	k := &Keyring{activeKey: f.ActiveKey, aeads: map[string]cipher.AEAD{}}
+	for id, encoded := range f.Keys {
+		if strings.Contains(id, ":") {
+			return nil, fmt.Errorf("%w: o id da chave %q não pode conter ':'", ErrKeyringInvalid, id)
+		}
+		key, err := decodeKey(encoded)
+		if err != nil {
+			return nil, fmt.Errorf("%w: chave %q: %v", ErrKeyringInvalid, id, err)
+		}
+		block, err := aes.NewCipher(key)
+		if err != nil {
+			return nil, err
+		}
+		aead, err := cipher.NewGCM(block)
+		if err != nil {
+			return nil, err
+		}
+		k.aeads[id] = aead
+	}
+
+	blindIndex, err := decodeKey(f.BlindIndexKey)
+	if err != nil {
+		return nil, fmt.Errorf("%w: blind_index_key: %v", ErrKeyringInvalid, err)
+	}
+	k.blindIndex = blindIndex
+	return k, nil
+}
+
+func decodeKey(encoded string) ([]byte, error) {
+	key, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, err
+	}
+	if len(key) != 32 {
+		return nil, fmt.Errorf("a chave deve ter 32 bytes, tem %d", len(key))
+	}
+	return key, nil
+}
+
+// Encrypt cifra o valor com a chave ativa.
+func (k *Keyring) Encrypt(plain string, aad string) (string, error) {
+	aead := k.aeads[k.activeKey]
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+	sealed := aead.Seal(nonce, nonce, []byte(plain), []byte(aad))
+	return encPrefix + k.activeKey + ":" + base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+// Decrypt decifra o valor com a chave indicada nele. Valores em texto puro (registros anteriores
+// à criptografia) são retornados sem alteração até serem recifrados pela rotação.
+func (k *Keyring) Decrypt(value string, aad string) (string, error) {
+	if !strings.HasPrefix(value, encPrefix) {
+		return value, nil
+	}
+	keyID, payload, ok := strings.Cut(strings.TrimPrefix(value, encPrefix), ":")
+	if !ok {
+		return "", ErrCiphertext
+	}
+	aead, ok := k.aeads[keyID]
+	if !ok {
+		return "", fmt.Errorf("%w: %s", ErrKeyNotFound, keyID)
+	}
+	sealed, err := base64.StdEncoding.DecodeString(payload)
+	if err != nil || len(sealed) < aead.NonceSize() {
+		return "", ErrCiphertext
+	}
+	nonce, ciphertext := sealed[:aead.NonceSize()], sealed[aead.NonceSize():]
+	plain, err := aead.Open(nil, nonce, ciphertext, []byte(aad))
+	if err != nil {
+		return "", ErrCiphertext
+	}
+	return string(plain), nil
+}
+
+// BlindIndex retorna o HMAC-SHA256 (hex) do valor. A chave do blind index é separada das chaves
+// de criptografia e não participa da rotação, senão todos os índices teriam que ser recalculados.
+func (k *Keyring) BlindIndex(field string, value string) string {
+	mac := hmac.New(sha256.New, k.blindIndex)
+	mac.Write([]byte(field + ":" + value))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// ActiveKeyID retorna o identificador da chave ativa.
+func (k *Keyring) ActiveKeyID() string {
+	return k.activeKey
+}
+
+// KeyID retorna a chave usada no valor cifrado, ou "" para texto puro.
+func (k *Keyring) KeyID(value string) string {
+	return keyIDOf(value)
+}
+
+func keyIDOf(value string) string {
+	if !strings.HasPrefix(value, encPrefix) {
+		return ""
+	}
+	keyID, _, _ := strings.Cut(strings.TrimPrefix(value, encPrefix), ":")
+	return keyID
+}
@@ -0,0 +1,225 @@
+package keyring_test
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/valdinei-santos/cpf-backend/internal/infra/keyring"
+)
+
+// key retorna uma chave de 32 bytes em base64, preenchida com b
+func key(b byte) string {
+	return base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{b}, 32))
+}
+
+// writeKeyring grava o arquivo de chaves e retorna o caminho
+func writeKeyring(t *testing.T, path string, active string, keys map[string]string, blindIndexKey string) string {
+	t.Helper()
+	if path == "" {
+		path = filepath.Join(t.TempDir(), "keyring.json")
+	}
+	data, err := json.Marshal(map[string]any{"active_key": active, "keys": keys, "blind_index_key": blindIndexKey})
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(path, data, 0o600))
+	return path
+}
+
+// tamper altera um byte do conteúdo cifrado, mantendo o formato do valor
+func tamper(t *testing.T, value string) string {
+	t.Helper()
+	i := strings.LastIndex(value, ":")
+	sealed, err := base64.StdEncoding.DecodeString(value[i+1:])
+	require.NoError(t, err)
+	sealed[len(sealed)-1] ^= 0xff
+	return value[:i+1] + base64.StdEncoding.EncodeToString(sealed)
+}
+
+func TestKeyringDecrypt(t *testing.T) {
+	k, err := keyring.Load(writeKeyring(t, "", "2025-10", map[string]string{"2025-10": key(1)}, key(9)))
+	require.NoError(t, err)
+	cifrado, err := k.Encrypt("123.456.789-00", "cliente.documento:1")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name        string
+		value       string
+		aad         string
+		expected    string
+		expectedErr error
+	}{
+		{
+			name:     "Deve decifrar o valor cifrado com o mesmo AAD",
+			value:    cifrado,
+			aad:      "cliente.documento:1",
+			expected: "123.456.789-00",
+		},
+		{
+			name:        "Deve recusar o valor cifrado adulterado",
+			value:       tamper(t, cifrado),
+			aad:         "cliente.documento:1",
+			expectedErr: keyring.ErrCiphertext,
+		},
+		{
+			name:        "Deve recusar o valor cifrado com outro AAD",
+			value:       cifrado,
+			aad:         "cliente.documento:2",
+			expectedErr: keyring.ErrCiphertext,
+		},
+		{
+			name:        "Deve recusar a chave que não está no keyring",
+			value:       strings.Replace(cifrado, "2025-10", "2024-01", 1),
+			aad:         "cliente.documento:1",
+			expectedErr: keyring.ErrKeyNotFound,
+		},
+		{
+			name:        "Deve recusar o valor sem o id da chave",
+			value:       "enc:v1:sem-payload",
+			expectedErr: keyring.ErrCiphertext,
+		},
+		{
+			name:     "Deve retornar o texto puro sem alteração",
+			value:    "123.456.789-00",
+			expected: "123.456.789-00",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plain, err := k.Decrypt(tt.value, tt.aad)
+
+			assert.ErrorIs(t, err, tt.expectedErr)
+			assert.Equal(t, tt.expected, plain)
+		})
+	}
+}
+
+func TestKeyringEncrypt(t *testing.T) {
+	k, err := keyring.Load(writeKeyring(t, "", "2025-10", map[string]string{"2025-01": key(1), "2025-10": key(2)}, key(9)))
+	require.NoError(t, err)
+
+	first, err := k.Encrypt("11999990000", "cliente.telefone:1")
+	require.NoError(t, err)
+	second, err := k.Encrypt("11999990000", "cliente.telefone:1")
+	require.NoError(t, err)
+
+	assert.True(t, strings.HasPrefix(first, "enc:v1:2025-10:"))
+	assert.NotEqual(t, first, second, "cada valor cifrado deve ter o próprio nonce")
+	assert.Equal(t, "2025-10", k.ActiveKeyID())
+	assert.Equal(t, "2025-10", k.KeyID(first))
+	assert.Equal(t, "", k.KeyID("11999990000"))
+	assert.Equal(t, k.BlindIndex("cliente.documento", "12345678900"), k.BlindIndex("cliente.documento", "12345678900"))
+	assert.NotEqual(t, k.BlindIndex("cliente.documento", "12345678900"), k.BlindIndex("cliente.telefone", "12345678900"))
+}
+
+func TestLoad(t *testing.T) {
+	tests := []struct {
+		name   string
+		active string
+		keys   map[string]string
+		bidx   string
+	}{
+		{name: "Deve recusar o arquivo sem chaves", active: "2025-10", bidx: key(9)},
+		{name: "Deve recusar a chave ativa fora das chaves", active: "2025-10", keys: map[string]string{"2025-01": key(1)}, bidx: key(9)},
+		{name: "Deve recusar a chave com tamanho diferente de 32 bytes", active: "2025-10", keys: map[string]string{"2025-10": base64.StdEncoding.EncodeToString([]byte("curta"))}, bidx: key(9)},
+		{name: "Deve recusar o id da chave com ':'", active: "2025:10", keys: map[string]string{"2025:10": key(1)}, bidx: key(9)},
+		{name: "Deve recusar o arquivo sem a chave do blind index", active: "2025-10", keys: map[string]string{"2025-10": key(1)}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := keyring.Load(writeKeyring(t, "", tt.active, tt.keys, tt.bidx))
+
+			assert.ErrorIs(t, err, keyring.ErrKeyringInvalid)
+		})
+	}
+}
+
+func TestPlaintext(t *testing.T) {
+	cipher, err := keyring.NewFieldCipher("")
+	require.NoError(t, err)
+	require.IsType(t, &keyring.Plaintext{}, cipher)
+
+	k, err := keyring.Load(writeKeyring(t, "", "2025-10", map[string]string{"2025-10": key(1)}, key(9)))
+	require.NoError(t, err)
+	cifrado, err := k.Encrypt("123.456.789-00", "cliente.documento:1")
+	require.NoError(t, err)
+
+	plain, err := cipher.Encrypt("123.456.789-00", "cliente.documento:1")
+	assert.NoError(t, err)
+	assert.Equal(t, "123.456.789-00", plain)
+
+	plain, err = cipher.Decrypt("123.456.789-00", "cliente.documento:1")
+	assert.NoError(t, err)
+	assert.Equal(t, "123.456.789-00", plain)
+
+	_, err = cipher.Decrypt(cifrado, "cliente.documento:1")
+	assert.ErrorIs(t, err, keyring.ErrKeyNotFound, "valor cifrado sem keyring indica erro de configuração")
+
+	assert.Equal(t, "", cipher.ActiveKeyID())
+	assert.Equal(t, "2025-10", cipher.KeyID(cifrado))
+	assert.Equal(t, cipher.BlindIndex("cliente.documento", "12345678900"), cipher.BlindIndex("cliente.documento", "12345678900"))
+}
+
+func TestReloadableReload(t *testing.T) {
+	tests := []struct {
+		name           string
+		rewrite        func(t *testing.T, path string)
+		expectedErr    error
+		expectedActive string
+	}{
+		{
+			name: "Deve passar a cifrar com a nova chave ativa e continuar decifrando a anterior",
+			rewrite: func(t *testing.T, path string) {
+				writeKeyring(t, path, "2025-10", map[string]string{"2025-01": key(1), "2025-10": key(2)}, key(9))
+			},
+			expectedActive: "2025-10",
+		},
+		{
+			name: "Deve recusar a troca da chave do blind index e manter o keyring em uso",
+			rewrite: func(t *testing.T, path string) {
+				writeKeyring(t, path, "2025-10", map[string]string{"2025-01": key(1), "2025-10": key(2)}, key(8))
+			},
+			expectedErr:    keyring.ErrBlindIndexChanged,
+			expectedActive: "2025-01",
+		},
+		{
+			name: "Deve manter o keyring em uso quando o arquivo é inválido",
+			rewrite: func(t *testing.T, path string) {
+				require.NoError(t, os.WriteFile(path, []byte("{"), 0o600))
+			},
+			expectedErr:    keyring.ErrKeyringInvalid,
+			expectedActive: "2025-01",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := writeKeyring(t, "", "2025-01", map[string]string{"2025-01": key(1)}, key(9))
+			r, err := keyring.NewReloadable(path)
+			require.NoError(t, err)
+			antigo, err := r.Encrypt("123.456.789-00", "cliente.documento:1")
+			require.NoError(t, err)
+			bidx := r.BlindIndex("cliente.documento", "12345678900")
+
+			tt.rewrite(t, path)
+			err = r.Reload()
+
+			assert.ErrorIs(t, err, tt.expectedErr)
+			assert.Equal(t, tt.expectedActive, r.ActiveKeyID())
+			novo, err := r.Encrypt("123.456.789-00", "cliente.documento:1")
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedActive, r.KeyID(novo))
+			plain, err := r.Decrypt(antigo, "cliente.documento:1")
+			assert.NoError(t, err)
+			assert.Equal(t, "123.456.789-00", plain)
+			assert.Equal(t, bidx, r.BlindIndex("cliente.documento", "12345678900"))
+		})
+	}
+}
@@ -0,0 +1,48 @@
+package keyring
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+)
+
+// Plaintext é usado quando nenhum arquivo de chaves foi configurado (ambiente local/testes).
+// Os valores são gravados sem criptografia e o blind index é um SHA-256 sem chave.
+type Plaintext struct{}
+
+// NewPlaintext - cria o cipher sem criptografia
+func NewPlaintext() *Plaintext {
+	return &Plaintext{}
+}
+
+func (p *Plaintext) Encrypt(plain string, aad string) (string, error) {
+	return plain, nil
+}
+
+func (p *Plaintext) Decrypt(value string, aad string) (string, error) {
+	// Um valor cifrado sem keyring configurado indica erro de configuração
+	if keyID := keyIDOf(value); keyID != "" {
+		return "", ErrKeyNotFound
+	}
+	return value, nil
+}
+
+func (p *Plaintext) BlindIndex(field string, value string) string {
+	sum := sha256.Sum256([]byte(field + ":" + value))
+	return hex.EncodeToString(sum[:])
+}
+
+func (p *Plaintext) ActiveKeyID() string {
+	return ""
+}
+
+func (p *Plaintext) KeyID(value string) string {
+	return keyIDOf(value)
+}
+
+// NewFieldCipher retorna o keyring recarregável do arquivo informado ou, se o caminho for vazio, o Plaintext.
+func NewFieldCipher(path string) (IFieldCipher, error) {
+	if path == "" {
+		return NewPlaintext(), nil
+	}
+	return NewReloadable(path)
+}
@@ -0,0 +1,62 @@
+package keyring
+
+import (
+	"crypto/hmac"
+	"errors"
+	"sync/atomic"
+)
+
+var ErrBlindIndexChanged = errors.New("a blind_index_key não pode ser trocada com a API em execução")
+
+// Reloadable é o Keyring de um arquivo que pode ser recarregado sem reiniciar a API, por exemplo
+// depois de adicionar a nova chave ativa de uma rotação. Um arquivo inválido não substitui o
+// keyring em uso.
+type Reloadable struct {
+	path    string
+	current atomic.Pointer[Keyring]
+}
+
+// NewReloadable - carrega o keyring do arquivo
+func NewReloadable(path string) (*Reloadable, error) {
+	k, err := Load(path)
+	if err != nil {
+		return nil, err
+	}
+	r := &Reloadable{path: path}
+	r.current.Store(k)
+	return r, nil
+}
+
+// Reload - recarrega o arquivo. A chave do blind index não pode mudar, senão as buscas e os
+// índices únicos dos registros existentes deixariam de funcionar.
+func (r *Reloadable) Reload() error {
+	k, err := Load(r.path)
+	if err != nil {
+		return err
+	}
+	if !hmac.Equal(k.blindIndex, r.current.Load().blindIndex) {
+		return ErrBlindIndexChanged
+	}
+	r.current.Store(k)
+	return nil
+}
+
+func (r *Reloadable) Encrypt(plain string, aad string) (string, error) {
+	return r.current.Load().Encrypt(plain, aad)
+}
+
+func (r *Reloadable) Decrypt(value string, aad string) (string, error) {
+	return r.current.Load().Decrypt(value, aad)
+}
+
+func (r *Reloadable) BlindIndex(field string, value string) string {
+	return r.current.Load().BlindIndex(field, value)
+}
+
+func (r *Reloadable) ActiveKeyID() string {
+	return r.current.Load().ActiveKeyID()
+}
+
+func (r *Reloadable) KeyID(value string) string {
+	return r.current.Load().KeyID(value)
+}
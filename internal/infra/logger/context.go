@@ -0,0 +1,41 @@
+package logger
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/valdinei-santos/cpf-backend/internal/infra/requestinfo"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// contextHandler acrescenta aos registros dos métodos *Context o id da requisição, o usuário, o
+// tenant e o trace id e o span id do span do ctx, para relacionar as linhas do log de uma mesma
+// requisição e com o trace
+type contextHandler struct {
+	slog.Handler
+}
+
+func (h contextHandler) Handle(ctx context.Context, r slog.Record) error {
+	info := requestinfo.FromContext(ctx)
+	for _, attr := range []slog.Attr{
+		slog.String("request_id", info.RequestID),
+		slog.String("user_id", info.UserID),
+		slog.String("tenant_id", info.TenantID),
+	} {
+		if attr.Value.String() != "" {
+			r.AddAttrs(attr)
+		}
+	}
+	if sc := trace.SpanContextFromContext(ctx); sc.IsValid() {
+		r.AddAttrs(slog.String("trace_id", sc.TraceID().String()), slog.String("span_id", sc.SpanID().String()))
+	}
+	return h.Handler.Handle(ctx, r)
+}
+
+func (h contextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return contextHandler{h.Handler.WithAttrs(attrs)}
+}
+
+func (h contextHandler) WithGroup(name string) slog.Handler {
+	return contextHandler{h.Handler.WithGroup(name)}
+}
@@ -0,0 +1,42 @@
+package logger
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/valdinei-santos/cpf-backend/internal/domain/globalerr"
+	"github.com/valdinei-santos/cpf-backend/internal/infra/problem"
+)
+
+// LevelBody é o corpo da consulta e da alteração do nível do log
+type LevelBody struct {
+	Level string `json:"level" binding:"required" example:"debug"` // debug, info, warn ou error
+} //@name LogLevel
+
+// LevelHandler responde o nível atual do log
+func LevelHandler(lc ILevelController) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.JSON(http.StatusOK, LevelBody{Level: lc.Level()})
+	}
+}
+
+// SetLevelHandler altera o nível do log desta instância até o próximo reinício. A alteração fica
+// registrada no log com quem a fez.
+func SetLevelHandler(lc ILevelController, log ILogger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var body LevelBody
+		if err := c.ShouldBindJSON(&body); err != nil {
+			problem.Abort(c, globalerr.ErrBadRequest)
+			return
+		}
+		previous := lc.Level()
+		if err := lc.SetLevel(body.Level); err != nil {
+			var v globalerr.Validation
+			v.Add("level", err)
+			problem.Abort(c, v.Err())
+			return
+		}
+		log.WarnContext(c.Request.Context(), "Nível do log alterado", "de", previous, "para", lc.Level())
+		c.JSON(http.StatusOK, LevelBody{Level: lc.Level()})
+	}
+}
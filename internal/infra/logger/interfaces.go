@@ -23,3 +23,9 @@ type ILogger interface {
 	WarnContext(ctx context.Context, msg string, args ...any)
 	ErrorContext(ctx context.Context, msg string, args ...any)
 }
+
+// ILevelController consulta e altera o nível do log em execução. Implementado pelo SlogILogger.
+type ILevelController interface {
+	Level() string
+	SetLevel(level string) error
+}
@@ -2,8 +2,15 @@ package logger
 
 import (
 	"context"
+	"errors"
+	"io"
 	"log/slog"
 	"os"
+	"strings"
+	"syscall"
+	"time"
+
+	"gopkg.in/natefinch/lumberjack.v2"
 )
 
 /*
@@ -15,39 +22,163 @@ import (
 */
 type SlogILogger struct {
 	logger *slog.Logger
+	level  *slog.LevelVar  // Compartilhado pelos loggers derivados (With, WithContext), alterado pelo SetLevel
+	out    *os.File        // nil quando o log vai só para o arquivo
+	file   io.WriteCloser  // Arquivo com rotação, nil quando o log vai só para a saída padrão
+	ctx    context.Context // Contexto usado pelos métodos sem ctx, definido pelo WithContext
+}
+
+// Formatos de saída do log
+const (
+	FormatJSON = "json"
+	FormatText = "text"
+)
+
+// Destinos do log
+const (
+	OutputStdout = "stdout" // Padrão
+	OutputFile   = "file"   // Arquivo de File.Path, com rotação
+	OutputBoth   = "both"   // Saída padrão e arquivo
+)
+
+// Options são as configurações do log. As tags definem a chave no arquivo YAML e o sufixo da
+// variável de ambiente (LOG_*), ver o pacote config.
+type Options struct {
+	Level    string          `yaml:"level" env:"LEVEL"`        // debug, info, warn ou error. Alterável em execução, ver LevelHandler
+	Format   string          `yaml:"format" env:"FORMAT"`      // json ou text
+	Output   string          `yaml:"output" env:"OUTPUT"`      // stdout, file ou both
+	File     FileOptions     `yaml:"file" env:"FILE_"`         // Arquivo dos destinos file e both
+	Sampling SamplingOptions `yaml:"sampling" env:"SAMPLING_"` // Amostragem das linhas de debug repetidas
+}
+
+// FileOptions são o arquivo do log e a sua rotação. O arquivo é rotacionado ao atingir MaxSizeMB e
+// os rotacionados são apagados depois de MaxAgeDays dias ou quando passam de MaxBackups.
+type FileOptions struct {
+	Path       string `yaml:"path" env:"PATH"`
+	MaxSizeMB  int    `yaml:"max_size_mb" env:"MAX_SIZE_MB"`
+	MaxAgeDays int    `yaml:"max_age_days" env:"MAX_AGE_DAYS"` // 0 mantém pela idade
+	MaxBackups int    `yaml:"max_backups" env:"MAX_BACKUPS"`   // 0 mantém todos
+	Compress   bool   `yaml:"compress" env:"COMPRESS"`         // Compacta os rotacionados com gzip
+}
+
+// SamplingOptions limitam as linhas de debug com a mesma mensagem: a cada Tick são gravadas as
+// Initial primeiras e depois uma a cada Thereafter. Initial 0 desabilita a amostragem.
+type SamplingOptions struct {
+	Initial    int           `yaml:"initial" env:"INITIAL"`
+	Thereafter int           `yaml:"thereafter" env:"THEREAFTER"` // 0 descarta todas depois das iniciais
+	Tick       time.Duration `yaml:"tick" env:"TICK"`
+}
+
+// ErrLevelInvalid é retornado para um nível do log desconhecido
+var ErrLevelInvalid = errors.New("nível do log inválido, use debug, info, warn ou error")
+
+// ParseLevel converte o nível do log. Aceita debug, info, warn e error.
+func ParseLevel(value string) (slog.Level, error) {
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(value)); err != nil {
+		return level, ErrLevelInvalid
+	}
+	return level, nil
 }
 
-func NewSlogILogger() *SlogILogger {
-	handler := slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{
-		//Level: slog.LevelInfo,
-		Level: slog.LevelDebug,
-	})
-	log := slog.New(handler)
-	return &SlogILogger{logger: log}
+// NewSlogILogger - cria o logger com o nível, o formato, o destino e a amostragem das opções.
+// Nível inválido usa info.
+func NewSlogILogger(opts Options) *SlogILogger {
+	level, err := ParseLevel(opts.Level)
+	if err != nil {
+		level = slog.LevelInfo
+	}
+	l := &SlogILogger{level: new(slog.LevelVar), ctx: context.Background()}
+	l.level.Set(level)
+
+	var writers []io.Writer
+	if opts.Output != OutputFile {
+		l.out = os.Stdout
+		writers = append(writers, os.Stdout)
+	}
+	if opts.Output == OutputFile || opts.Output == OutputBoth {
+		l.file = &lumberjack.Logger{
+			Filename:   opts.File.Path,
+			MaxSize:    opts.File.MaxSizeMB,
+			MaxAge:     opts.File.MaxAgeDays,
+			MaxBackups: opts.File.MaxBackups,
+			Compress:   opts.File.Compress,
+		}
+		writers = append(writers, l.file)
+	}
+	out := io.MultiWriter(writers...)
+
+	handlerOptions := &slog.HandlerOptions{Level: l.level}
+	var handler slog.Handler = slog.NewJSONHandler(out, handlerOptions)
+	if opts.Format == FormatText {
+		handler = slog.NewTextHandler(out, handlerOptions)
+	}
+	if opts.Sampling.Initial > 0 {
+		handler = newSamplingHandler(handler, opts.Sampling)
+	}
+	l.logger = slog.New(contextHandler{handler})
+	return l
+}
+
+// Level retorna o nível atual do log
+func (l *SlogILogger) Level() string {
+	return strings.ToLower(l.level.Level().String())
+}
+
+// SetLevel altera o nível do log em execução, valendo para todos os loggers derivados deste
+func (l *SlogILogger) SetLevel(value string) error {
+	level, err := ParseLevel(value)
+	if err != nil {
+		return err
+	}
+	l.level.Set(level)
+	return nil
+}
+
+// Close grava no destino o que ainda estiver pendente e fecha o arquivo do log, chamado no
+// encerramento da API. Terminais e pipes não aceitam sync, e nesse caso não há nada pendente.
+func (l *SlogILogger) Close() error {
+	var err error
+	if l.out != nil {
+		err = l.out.Sync()
+		if errors.Is(err, syscall.EINVAL) || errors.Is(err, syscall.ENOTSUP) {
+			err = nil
+		}
+	}
+	if l.file != nil {
+		err = errors.Join(err, l.file.Close())
+	}
+	return err
 }
 
 func (l *SlogILogger) Debug(msg string, args ...any) {
-	l.logger.DebugContext(context.Background(), msg, args...)
+	l.logger.DebugContext(l.ctx, msg, args...)
 }
 
 func (l *SlogILogger) Info(msg string, args ...any) {
-	l.logger.InfoContext(context.Background(), msg, args...)
+	l.logger.InfoContext(l.ctx, msg, args...)
 }
 
 func (l *SlogILogger) Warn(msg string, args ...any) {
-	l.logger.WarnContext(context.Background(), msg, args...)
+	l.logger.WarnContext(l.ctx, msg, args...)
 }
 
 func (l *SlogILogger) Error(msg string, args ...any) {
-	l.logger.ErrorContext(context.Background(), msg, args...)
+	l.logger.ErrorContext(l.ctx, msg, args...)
 }
 
 func (l *SlogILogger) With(args ...any) ILogger {
-	return &SlogILogger{logger: l.logger.With(args...)}
+	derived := *l
+	derived.logger = l.logger.With(args...)
+	return &derived
 }
 
+// WithContext retorna um logger cujos métodos sem ctx (Debug, Info...) usam o ctx, incluindo nos
+// registros os campos da requisição e do trace
 func (l *SlogILogger) WithContext(ctx context.Context) ILogger {
-	return &SlogILogger{logger: l.logger}
+	derived := *l
+	derived.ctx = ctx
+	return &derived
 }
 
 func (l *SlogILogger) DebugContext(ctx context.Context, msg string, args ...any) {
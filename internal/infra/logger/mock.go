@@ -59,6 +59,7 @@ func (m *MockILogger) WithContext(ctx context.Context) ILogger {
 
 // DebugContext mocks the DebugContext logging method
 func (m *MockILogger) DebugContext(ctx context.Context, msg string, args ...any) {
+	m.DebugCalled = true
 	m.log("DEBUG_CONTEXT", msg, args...)
 }
 
@@ -74,6 +75,7 @@ func (m *MockILogger) WarnContext(ctx context.Context, msg string, args ...any)
 
 // ErrorContext mocks the ErrorContext logging method
 func (m *MockILogger) ErrorContext(ctx context.Context, msg string, args ...any) {
+	m.ErrorCalled = true
 	m.log("ERROR_CONTEXT", msg, args...)
 }
 
@@ -0,0 +1,69 @@
+package logger
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// samplingHandler descarta as linhas de debug repetidas: a cada tick grava as initial primeiras
+// com a mesma mensagem e depois uma a cada thereafter. Os demais níveis passam sem amostragem.
+type samplingHandler struct {
+	slog.Handler
+	sampler *sampler // Compartilhado pelos handlers derivados (WithAttrs, WithGroup)
+}
+
+type sampler struct {
+	mu         sync.Mutex
+	initial    int
+	thereafter int
+	tick       time.Duration
+	start      time.Time      // Início do intervalo atual
+	counts     map[string]int // Linhas de cada mensagem no intervalo atual
+}
+
+func newSamplingHandler(h slog.Handler, opts SamplingOptions) samplingHandler {
+	tick := opts.Tick
+	if tick <= 0 {
+		tick = time.Second
+	}
+	return samplingHandler{Handler: h, sampler: &sampler{
+		initial:    opts.Initial,
+		thereafter: opts.Thereafter,
+		tick:       tick,
+		counts:     map[string]int{},
+	}}
+}
+
+func (h samplingHandler) Handle(ctx context.Context, r slog.Record) error {
+	if r.Level < slog.LevelInfo && !h.sampler.allow(r.Message, r.Time) {
+		return nil
+	}
+	return h.Handler.Handle(ctx, r)
+}
+
+func (h samplingHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return samplingHandler{Handler: h.Handler.WithAttrs(attrs), sampler: h.sampler}
+}
+
+func (h samplingHandler) WithGroup(name string) slog.Handler {
+	return samplingHandler{Handler: h.Handler.WithGroup(name), sampler: h.sampler}
+}
+
+// allow conta a linha e indica se deve ser gravada. Os contadores são zerados a cada tick, o que
+// também limita o mapa às mensagens do intervalo atual.
+func (s *sampler) allow(msg string, now time.Time) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if now.Sub(s.start) >= s.tick {
+		s.start = now
+		clear(s.counts)
+	}
+	s.counts[msg]++
+	n := s.counts[msg]
+	if n <= s.initial {
+		return true
+	}
+	return s.thereafter > 0 && (n-s.initial)%s.thereafter == 0
+}
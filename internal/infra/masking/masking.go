@@ -0,0 +1,56 @@
+// Pacote com o mascaramento de dados pessoais (PII) nas respostas da API.
+package masking
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Mode define como os dados pessoais são exibidos na resposta.
+type Mode int
+
+const (
+	Masked   Mode = iota // Padrão: documento e telefone mascarados
+	Unmasked             // Dados completos, apenas para quem tem permissão
+)
+
+// Documento mascara o documento. CPF: ***.456.789-**, CNPJ: **.345.678/****-**.
+// Outros formatos mantêm apenas os 3 últimos caracteres.
+func Documento(documento string, mode Mode) string {
+	if mode == Unmasked {
+		return documento
+	}
+	digits := onlyDigits(documento)
+	switch len(digits) {
+	case 11:
+		return "***." + digits[3:6] + "." + digits[6:9] + "-**"
+	case 14:
+		return "**." + digits[2:5] + "." + digits[5:8] + "/****-**"
+	}
+	return maskAllButLast(documento, 3)
+}
+
+// Telefone mascara o telefone, mantendo apenas os 4 últimos dígitos.
+func Telefone(telefone string, mode Mode) string {
+	if mode == Unmasked {
+		return telefone
+	}
+	return maskAllButLast(telefone, 4)
+}
+
+func maskAllButLast(s string, visible int) string {
+	r := []rune(s)
+	if len(r) <= visible {
+		return strings.Repeat("*", len(r))
+	}
+	return strings.Repeat("*", len(r)-visible) + string(r[len(r)-visible:])
+}
+
+func onlyDigits(s string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsDigit(r) {
+			return r
+		}
+		return -1
+	}, s)
+}
@@ -0,0 +1,51 @@
+package masking
+
+import (
+	"context"
+
+	"github.com/valdinei-santos/cpf-backend/internal/infra/logger"
+	"github.com/valdinei-santos/cpf-backend/internal/infra/rbac"
+)
+
+// PermissionUnmask é a permissão para ver o documento e o telefone completos com ?unmask=true
+const PermissionUnmask rbac.Permission = "pii:unmask"
+
+// IAuditSink registra os pedidos de unmask, permitidos ou negados, na auditoria. Ex: log de acesso (LGPD)
+type IAuditSink interface {
+	RecordUnmask(ctx context.Context, resource string, granted bool) error
+}
+
+// Policy decide se o chamador pode ver os dados pessoais completos. Por padrão todos recebem os
+// dados mascarados; os completos só são retornados com ?unmask=true para quem tem a permissão
+// PermissionUnmask, e cada pedido é registrado no IAuditSink.
+type Policy struct {
+	authz rbac.IAuthorizer
+	audit IAuditSink
+	log   logger.ILogger
+}
+
+// NewPolicy - cria a política com o autorizador da permissão e o registro de auditoria dos pedidos
+func NewPolicy(authz rbac.IAuthorizer, audit IAuditSink, log logger.ILogger) *Policy {
+	return &Policy{authz: authz, audit: audit, log: log}
+}
+
+// Resolve retorna o modo de exibição para a requisição. Retorna o erro do IAuthorizer
+// (globalerr.ErrUnauthorized ou globalerr.ErrForbidden) quando o unmask foi pedido por quem não tem
+// permissão. Se o registro na auditoria falhar os dados completos não são liberados. O resource
+// identifica o que foi pedido. Ex: cliente:<id>
+func (p *Policy) Resolve(ctx context.Context, unmaskRequested bool, resource string) (Mode, error) {
+	if !unmaskRequested {
+		return Masked, nil
+	}
+	err := p.authz.Check(ctx, PermissionUnmask)
+	if auditErr := p.audit.RecordUnmask(ctx, resource, err == nil); auditErr != nil {
+		p.log.ErrorContext(ctx, auditErr.Error(), "mtd", "p.audit.RecordUnmask", "resource", resource)
+		if err == nil {
+			return Masked, auditErr
+		}
+	}
+	if err != nil {
+		return Masked, err
+	}
+	return Unmasked, nil
+}
@@ -0,0 +1,113 @@
+package masking_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/valdinei-santos/cpf-backend/internal/domain/globalerr"
+	"github.com/valdinei-santos/cpf-backend/internal/infra/identity"
+	"github.com/valdinei-santos/cpf-backend/internal/infra/logger"
+	"github.com/valdinei-santos/cpf-backend/internal/infra/masking"
+	"github.com/valdinei-santos/cpf-backend/internal/infra/rbac"
+)
+
+// auditSink guarda os pedidos de unmask registrados
+type auditSink struct {
+	granted []bool
+	err     error
+}
+
+func (s *auditSink) RecordUnmask(_ context.Context, _ string, granted bool) error {
+	s.granted = append(s.granted, granted)
+	return s.err
+}
+
+func TestPolicyResolve(t *testing.T) {
+	supervisor := &identity.Identity{Subject: "maria", Roles: []string{"supervisor"}, Method: "jwt"}
+	operator := &identity.Identity{Subject: "joao", Roles: []string{"operator"}, Method: "jwt"}
+	apiKey := &identity.Identity{Subject: "svc", Scopes: []string{"pii:unmask"}, Method: "api_key"}
+
+	tests := []struct {
+		name          string
+		id            *identity.Identity
+		unmask        bool
+		auditErr      error
+		expectedMode  masking.Mode
+		expectedErr   error
+		expectRecords []bool
+	}{
+		{
+			name:         "Deve mascarar sem pedido de unmask e sem registrar",
+			id:           supervisor,
+			expectedMode: masking.Masked,
+		},
+		{
+			name:          "Deve liberar o unmask para o papel com a permissão e registrar",
+			id:            supervisor,
+			unmask:        true,
+			expectedMode:  masking.Unmasked,
+			expectRecords: []bool{true},
+		},
+		{
+			name:          "Deve liberar o unmask para a chave de API com o escopo",
+			id:            apiKey,
+			unmask:        true,
+			expectedMode:  masking.Unmasked,
+			expectRecords: []bool{true},
+		},
+		{
+			name:          "Deve negar o unmask sem a permissão e registrar a negação",
+			id:            operator,
+			unmask:        true,
+			expectedMode:  masking.Masked,
+			expectedErr:   globalerr.ErrForbidden,
+			expectRecords: []bool{false},
+		},
+		{
+			name:          "Deve negar o unmask ao anônimo",
+			unmask:        true,
+			expectedMode:  masking.Masked,
+			expectedErr:   globalerr.ErrUnauthorized,
+			expectRecords: []bool{false},
+		},
+		{
+			name:          "Não deve liberar o unmask se o registro falhar",
+			id:            supervisor,
+			unmask:        true,
+			auditErr:      globalerr.ErrInternal,
+			expectedMode:  masking.Masked,
+			expectedErr:   globalerr.ErrInternal,
+			expectRecords: []bool{true},
+		},
+		{
+			name:          "Deve manter a negação se o registro falhar",
+			id:            operator,
+			unmask:        true,
+			auditErr:      errors.New("erro de conexão com o banco de dados"),
+			expectedMode:  masking.Masked,
+			expectedErr:   globalerr.ErrForbidden,
+			expectRecords: []bool{false},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.id != nil {
+				ctx = identity.NewContext(ctx, tt.id)
+			}
+			log := logger.NewMockILogger()
+			sink := &auditSink{err: tt.auditErr}
+			policy := masking.NewPolicy(rbac.NewAuthorizer(rbac.DefaultPolicy(), false, log), sink, log)
+
+			mode, err := policy.Resolve(ctx, tt.unmask, "cliente:lista")
+
+			assert.Equal(t, tt.expectedMode, mode)
+			assert.Equal(t, tt.expectedErr, err)
+			assert.Equal(t, tt.expectRecords, sink.granted)
+			assert.Equal(t, tt.auditErr != nil, log.ErrorCalled)
+		})
+	}
+}
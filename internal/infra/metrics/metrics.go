@@ -0,0 +1,88 @@
+// Pacote com as métricas da API no formato do Prometheus: requisições HTTP por rota, operações no
+// MongoDB, pool de conexões e runtime do Go.
+package metrics
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+)
+
+const namespace = "cpf"
+
+// Metrics guarda os coletores em um registro próprio, exposto no /metrics e resumido no /status
+type Metrics struct {
+	registry  *prometheus.Registry
+	startTime time.Time
+
+	httpRequests  *prometheus.CounterVec
+	httpDuration  *prometheus.HistogramVec
+	httpInFlight  prometheus.Gauge
+	mongoDuration *prometheus.HistogramVec
+	poolOpen      *prometheus.GaugeVec
+	poolInUse     *prometheus.GaugeVec
+	poolFailures  *prometheus.CounterVec
+}
+
+// New - cria as métricas e registra os coletores do runtime do Go e do processo
+func New() *Metrics {
+	m := &Metrics{
+		registry:  prometheus.NewRegistry(),
+		startTime: time.Now(),
+		httpRequests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "http_requests_total",
+			Help:      "Requisições HTTP atendidas, por método, rota e status.",
+		}, []string{"method", "route", "status"}),
+		httpDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "http_request_duration_seconds",
+			Help:      "Duração das requisições HTTP, por método, rota e status.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"method", "route", "status"}),
+		httpInFlight: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "http_requests_in_flight",
+			Help:      "Requisições HTTP em andamento.",
+		}),
+		mongoDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "mongo_command_duration_seconds",
+			Help:      "Duração dos comandos enviados ao MongoDB, por comando e resultado.",
+			Buckets:   []float64{.0005, .001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5},
+		}, []string{"command", "status"}),
+		poolOpen: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "mongo_pool_connections",
+			Help:      "Conexões abertas no pool do MongoDB, por servidor.",
+		}, []string{"address"}),
+		poolInUse: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "mongo_pool_connections_in_use",
+			Help:      "Conexões do pool do MongoDB em uso, por servidor.",
+		}, []string{"address"}),
+		poolFailures: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "mongo_pool_checkout_failures_total",
+			Help:      "Falhas ao obter uma conexão do pool do MongoDB, por servidor e motivo.",
+		}, []string{"address", "reason"}),
+	}
+	m.registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		m.httpRequests, m.httpDuration, m.httpInFlight,
+		m.mongoDuration, m.poolOpen, m.poolInUse, m.poolFailures,
+	)
+	return m
+}
+
+// StartTime retorna o início da API, usado no uptime
+func (m *Metrics) StartTime() time.Time {
+	return m.startTime
+}
+
+// Registry retorna o registro com os coletores, para registrar métricas de outros pacotes
+func (m *Metrics) Registry() *prometheus.Registry {
+	return m.registry
+}
@@ -0,0 +1,41 @@
+package metrics
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/event"
+)
+
+// CommandMonitor retorna o monitor do driver do MongoDB que registra a duração de cada comando
+// (find, insert, update...) e se falhou
+func (m *Metrics) CommandMonitor() *event.CommandMonitor {
+	return &event.CommandMonitor{
+		Succeeded: func(_ context.Context, e *event.CommandSucceededEvent) {
+			m.mongoDuration.WithLabelValues(e.CommandName, "ok").Observe(e.Duration.Seconds())
+		},
+		Failed: func(_ context.Context, e *event.CommandFailedEvent) {
+			m.mongoDuration.WithLabelValues(e.CommandName, "error").Observe(e.Duration.Seconds())
+		},
+	}
+}
+
+// PoolMonitor retorna o monitor do driver do MongoDB que acompanha as conexões abertas e em uso
+// no pool de cada servidor
+func (m *Metrics) PoolMonitor() *event.PoolMonitor {
+	return &event.PoolMonitor{
+		Event: func(e *event.PoolEvent) {
+			switch e.Type {
+			case event.ConnectionCreated:
+				m.poolOpen.WithLabelValues(e.Address).Inc()
+			case event.ConnectionClosed:
+				m.poolOpen.WithLabelValues(e.Address).Dec()
+			case event.GetSucceeded:
+				m.poolInUse.WithLabelValues(e.Address).Inc()
+			case event.ConnectionReturned:
+				m.poolInUse.WithLabelValues(e.Address).Dec()
+			case event.GetFailed:
+				m.poolFailures.WithLabelValues(e.Address, e.Reason).Inc()
+			}
+		},
+	}
+}
@@ -0,0 +1,131 @@
+package metrics
+
+import (
+	"time"
+
+	dto "github.com/prometheus/client_model/go"
+)
+
+// Summary é o resumo das métricas exibido no /status
+type Summary struct {
+	Uptime  string         `json:"uptime"`
+	HTTP    HTTPSummary    `json:"http"`
+	Mongo   MongoSummary   `json:"mongo"`
+	Runtime RuntimeSummary `json:"runtime"`
+}
+
+// HTTPSummary resume as requisições atendidas
+type HTTPSummary struct {
+	Requests     uint64                  `json:"requests"`
+	InFlight     float64                 `json:"in_flight"`
+	ByStatus     map[string]uint64       `json:"by_status"` // Por classe: 2xx, 4xx, 5xx...
+	AvgLatencyMs float64                 `json:"avg_latency_ms"`
+	Routes       map[string]RouteSummary `json:"routes"` // Por método e rota. Ex: GET /api/v1/cliente/:id
+}
+
+// RouteSummary resume as requisições de uma rota
+type RouteSummary struct {
+	Requests     uint64  `json:"requests"`
+	AvgLatencyMs float64 `json:"avg_latency_ms"`
+}
+
+// MongoSummary resume os comandos e o pool de conexões do MongoDB
+type MongoSummary struct {
+	Commands             uint64  `json:"commands"`
+	Errors               uint64  `json:"errors"`
+	AvgLatencyMs         float64 `json:"avg_latency_ms"`
+	ConnectionsOpen      float64 `json:"connections_open"`
+	ConnectionsInUse     float64 `json:"connections_in_use"`
+	PoolCheckoutFailures float64 `json:"pool_checkout_failures"`
+}
+
+// RuntimeSummary resume o runtime do Go
+type RuntimeSummary struct {
+	Goroutines     float64 `json:"goroutines"`
+	HeapAllocBytes float64 `json:"heap_alloc_bytes"`
+}
+
+// Summary - resume as métricas coletadas, as mesmas expostas no /metrics
+func (m *Metrics) Summary() (*Summary, error) {
+	families, err := m.registry.Gather()
+	if err != nil {
+		return nil, err
+	}
+
+	s := &Summary{
+		Uptime: time.Since(m.startTime).Round(time.Second).String(),
+		HTTP:   HTTPSummary{ByStatus: map[string]uint64{}, Routes: map[string]RouteSummary{}},
+	}
+	var httpSum, mongoSum float64
+	for _, f := range families {
+		switch f.GetName() {
+		case namespace + "_http_request_duration_seconds":
+			for _, metric := range f.GetMetric() {
+				labels := labelMap(metric)
+				h := metric.GetHistogram()
+				key := labels["method"] + " " + labels["route"]
+				route := s.HTTP.Routes[key]
+				route.Requests += h.GetSampleCount()
+				route.AvgLatencyMs += h.GetSampleSum() // Soma, convertida em média abaixo
+				s.HTTP.Routes[key] = route
+				s.HTTP.ByStatus[labels["status"][:1]+"xx"] += h.GetSampleCount()
+				s.HTTP.Requests += h.GetSampleCount()
+				httpSum += h.GetSampleSum()
+			}
+		case namespace + "_http_requests_in_flight":
+			s.HTTP.InFlight = sumGauges(f)
+		case namespace + "_mongo_command_duration_seconds":
+			for _, metric := range f.GetMetric() {
+				h := metric.GetHistogram()
+				s.Mongo.Commands += h.GetSampleCount()
+				if labelMap(metric)["status"] == "error" {
+					s.Mongo.Errors += h.GetSampleCount()
+				}
+				mongoSum += h.GetSampleSum()
+			}
+		case namespace + "_mongo_pool_connections":
+			s.Mongo.ConnectionsOpen = sumGauges(f)
+		case namespace + "_mongo_pool_connections_in_use":
+			s.Mongo.ConnectionsInUse = sumGauges(f)
+		case namespace + "_mongo_pool_checkout_failures_total":
+			s.Mongo.PoolCheckoutFailures = sumGauges(f)
+		case "go_goroutines":
+			s.Runtime.Goroutines = sumGauges(f)
+		case "go_memstats_heap_alloc_bytes":
+			s.Runtime.HeapAllocBytes = sumGauges(f)
+		}
+	}
+
+	for key, route := range s.HTTP.Routes {
+		route.AvgLatencyMs = avgMs(route.AvgLatencyMs, route.Requests)
+		s.HTTP.Routes[key] = route
+	}
+	s.HTTP.AvgLatencyMs = avgMs(httpSum, s.HTTP.Requests)
+	s.Mongo.AvgLatencyMs = avgMs(mongoSum, s.Mongo.Commands)
+	return s, nil
+}
+
+func labelMap(metric *dto.Metric) map[string]string {
+	labels := make(map[string]string, len(metric.GetLabel()))
+	for _, l := range metric.GetLabel() {
+		labels[l.GetName()] = l.GetValue()
+	}
+	return labels
+}
+
+// sumGauges soma os valores de todas as séries de um gauge ou counter
+func sumGauges(f *dto.MetricFamily) float64 {
+	var total float64
+	for _, metric := range f.GetMetric() {
+		total += metric.GetGauge().GetValue() + metric.GetCounter().GetValue()
+	}
+	return total
+}
+
+// avgMs converte a soma em segundos de um histograma na média em milissegundos
+func avgMs(sumSeconds float64, count uint64) float64 {
+	if count == 0 {
+		return 0
+	}
+	return float64(int64(sumSeconds/float64(count)*1e6)) / 1000
+}
@@ -0,0 +1,81 @@
+package migration
+
+import (
+	"context"
+	"errors"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Códigos de erro do MongoDB tratados como "nada a fazer"
+const (
+	codeNamespaceNotFound = 26 // Collection inexistente
+	codeIndexNotFound     = 27
+)
+
+// createIndexes cria os índices em cada collection. Índices já existentes com a mesma definição
+// são ignorados pelo MongoDB.
+func createIndexes(ctx context.Context, colls []*mongo.Collection, models []mongo.IndexModel) error {
+	for _, coll := range colls {
+		if _, err := coll.Indexes().CreateMany(ctx, models); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// dropIndexes remove os índices de cada collection, ignorando os que não existem
+func dropIndexes(ctx context.Context, colls []*mongo.Collection, names ...string) error {
+	for _, coll := range colls {
+		for _, name := range names {
+			if _, err := coll.Indexes().DropOne(ctx, name); err != nil && !ignorable(err) {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// setValidator aplica a regra de validação ($jsonSchema) na collection, criando-a se ainda não
+// existir. O nível moderate não valida as alterações de documentos antigos que já não atendiam a
+// regra, para que eles possam ser corrigidos aos poucos.
+func setValidator(ctx context.Context, coll *mongo.Collection, schema bson.M) error {
+	validator := bson.M{"$jsonSchema": schema}
+	err := coll.Database().RunCommand(ctx, bson.D{
+		{Key: "collMod", Value: coll.Name()},
+		{Key: "validator", Value: validator},
+		{Key: "validationLevel", Value: "moderate"},
+		{Key: "validationAction", Value: "error"},
+	}).Err()
+	if !isCode(err, codeNamespaceNotFound) {
+		return err
+	}
+	return coll.Database().CreateCollection(ctx, coll.Name(), options.CreateCollection().
+		SetValidator(validator).
+		SetValidationLevel("moderate").
+		SetValidationAction("error"))
+}
+
+// removeValidator remove a regra de validação da collection
+func removeValidator(ctx context.Context, coll *mongo.Collection) error {
+	err := coll.Database().RunCommand(ctx, bson.D{
+		{Key: "collMod", Value: coll.Name()},
+		{Key: "validator", Value: bson.M{}},
+		{Key: "validationLevel", Value: "off"},
+	}).Err()
+	if ignorable(err) {
+		return nil
+	}
+	return err
+}
+
+func ignorable(err error) bool {
+	return isCode(err, codeNamespaceNotFound) || isCode(err, codeIndexNotFound)
+}
+
+func isCode(err error, code int) bool {
+	var cmdErr mongo.CommandError
+	return errors.As(err, &cmdErr) && int(cmdErr.Code) == code
+}
@@ -0,0 +1,101 @@
+package migration
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/valdinei-santos/cpf-backend/internal/infra/logger"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// Id do documento da trava na collection das migrações
+const lockID = "lock"
+
+// lock é a trava das migrações: um documento com o dono e a validade. Uma trava vencida (a
+// execução que a tinha morreu sem liberar) pode ser tomada por outra execução.
+type lock struct {
+	coll  *mongo.Collection
+	ttl   time.Duration
+	owner string
+	log   logger.ILogger
+}
+
+func newLock(coll *mongo.Collection, ttl time.Duration, log logger.ILogger) *lock {
+	host, _ := os.Hostname()
+	return &lock{coll: coll, ttl: ttl, owner: fmt.Sprintf("%s:%d:%s", host, os.Getpid(), uuid.NewString()), log: log}
+}
+
+// acquire obtém a trava ou retorna ErrLocked. Enquanto a trava estiver com esta execução ela é
+// renovada a cada terço da validade; se a renovação falhar o contexto retornado é cancelado. A
+// função release libera a trava.
+func (l *lock) acquire(ctx context.Context) (context.Context, func(), error) {
+	now := time.Now().UTC()
+	_, err := l.coll.InsertOne(ctx, bson.M{"_id": lockID, "owner": l.owner, "locked_at": now, "expires_at": now.Add(l.ttl)})
+	if mongo.IsDuplicateKeyError(err) {
+		// Toma a trava vencida
+		result, updateErr := l.coll.UpdateOne(ctx,
+			bson.M{"_id": lockID, "expires_at": bson.M{"$lt": now}},
+			bson.M{"$set": bson.M{"owner": l.owner, "locked_at": now, "expires_at": now.Add(l.ttl)}})
+		if updateErr != nil {
+			return nil, nil, updateErr
+		}
+		if result.MatchedCount == 0 {
+			return nil, nil, ErrLocked
+		}
+		l.log.Warn("Trava vencida das migrações assumida por esta execução")
+	} else if err != nil {
+		return nil, nil, err
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	stop := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		ticker := time.NewTicker(l.ttl / 3)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-stop:
+				return
+			case <-ticker.C:
+				if err := l.refresh(ctx); err != nil {
+					l.log.Error("Trava das migrações perdida, execução interrompida", "err", err.Error())
+					cancel()
+					return
+				}
+			}
+		}
+	}()
+
+	release := func() {
+		close(stop)
+		<-done
+		cancel()
+		// A liberação não usa o contexto da execução, que pode ter sido cancelado
+		releaseCtx, cancelRelease := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancelRelease()
+		if _, err := l.coll.DeleteOne(releaseCtx, bson.M{"_id": lockID, "owner": l.owner}); err != nil {
+			l.log.Error("Erro ao liberar a trava das migrações", "err", err.Error())
+		}
+	}
+	return ctx, release, nil
+}
+
+// refresh renova a validade da trava, se ela ainda for desta execução
+func (l *lock) refresh(ctx context.Context) error {
+	result, err := l.coll.UpdateOne(ctx,
+		bson.M{"_id": lockID, "owner": l.owner},
+		bson.M{"$set": bson.M{"expires_at": time.Now().UTC().Add(l.ttl)}})
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return ErrLocked
+	}
+	return nil
+}
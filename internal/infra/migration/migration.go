@@ -0,0 +1,262 @@
+// Pacote com as migrações versionadas do MongoDB: índices, regras de validação e a evolução dos
+// documentos. As migrações aplicadas ficam registradas numa collection, com uma trava que impede
+// duas execuções ao mesmo tempo (várias instâncias subindo, ou o comando migrate com a API).
+package migration
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/valdinei-santos/cpf-backend/internal/infra/database"
+	"github.com/valdinei-santos/cpf-backend/internal/infra/logger"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var (
+	ErrLocked       = errors.New("outra execução das migrações está em andamento")
+	ErrIrreversible = errors.New("a migração não pode ser revertida")
+)
+
+// Options são as configurações das migrações. As tags definem a chave no arquivo YAML e o sufixo da
+// variável de ambiente (MIGRATION_*), ver o pacote config.
+type Options struct {
+	OnStart bool          `yaml:"on_start" env:"ON_START"` // Aplica as migrações pendentes na subida da API
+	LockTTL time.Duration `yaml:"lock_ttl" env:"LOCK_TTL"` // Validade da trava, renovada enquanto a execução estiver ativa
+}
+
+// Env é o que as migrações recebem para alterar o banco
+type Env struct {
+	DB          *mongo.Database   // Database base, com as collections compartilhadas pelos tenants (ex: apikey)
+	Tenants     *database.Tenants // Collections de cada tenant (ver Tenants.All e Tenants.IndexKeys)
+	Collections database.Collections
+}
+
+// Migration é uma alteração do banco. Up deve ser idempotente: se falhar no meio, a versão não é
+// registrada e a próxima execução a aplica de novo. Down nil indica uma migração irreversível.
+type Migration struct {
+	Version     int64 // Maior que zero e única. Define a ordem de aplicação
+	Description string
+	Up          func(ctx context.Context, env Env) error
+	Down        func(ctx context.Context, env Env) error
+}
+
+// Situação de uma versão
+const (
+	StatusApplied = "aplicada"
+	StatusPending = "pendente"
+	StatusUnknown = "desconhecida" // Registrada no banco, mas ausente desta versão da API
+)
+
+// Status é a situação de uma versão
+type Status struct {
+	Version     int64
+	Description string
+	Status      string
+	AppliedAt   *time.Time
+}
+
+// record é o registro de uma versão aplicada na collection das migrações
+type record struct {
+	ID          int64     `bson:"_id"`
+	Version     int64     `bson:"version"`
+	Description string    `bson:"description"`
+	AppliedAt   time.Time `bson:"applied_at"`
+	DurationMs  int64     `bson:"duration_ms"`
+}
+
+// Migrator aplica e reverte as migrações
+type Migrator struct {
+	env        Env
+	coll       *mongo.Collection
+	migrations []Migration
+	lock       *lock
+	log        logger.ILogger
+}
+
+// New cria o Migrator com as migrações, que são ordenadas pela versão. Retorna erro se houver
+// versões repetidas ou menores que 1.
+func New(env Env, migrations []Migration, opts Options, log logger.ILogger) (*Migrator, error) {
+	sorted := slices.Clone(migrations)
+	slices.SortFunc(sorted, func(a, b Migration) int { return cmp.Compare(a.Version, b.Version) })
+	for i, m := range sorted {
+		if m.Version < 1 || m.Up == nil {
+			return nil, fmt.Errorf("migração %d inválida: a versão deve ser maior que zero e o Up é obrigatório", m.Version)
+		}
+		if i > 0 && sorted[i-1].Version == m.Version {
+			return nil, fmt.Errorf("migração %d repetida", m.Version)
+		}
+	}
+	coll := env.DB.Collection(env.Collections.Migrations)
+	return &Migrator{
+		env:        env,
+		coll:       coll,
+		migrations: sorted,
+		lock:       newLock(coll, opts.LockTTL, log),
+		log:        log,
+	}, nil
+}
+
+// Up aplica em ordem as migrações pendentes até a versão target, inclusive. target 0 aplica todas.
+// Retorna as versões aplicadas.
+func (m *Migrator) Up(ctx context.Context, target int64) ([]int64, error) {
+	var done []int64
+	err := m.locked(ctx, func(ctx context.Context) error {
+		applied, err := m.applied(ctx)
+		if err != nil {
+			return err
+		}
+		for _, mig := range m.migrations {
+			if target > 0 && mig.Version > target {
+				break
+			}
+			if _, ok := applied[mig.Version]; ok {
+				continue
+			}
+			m.log.Info("Aplicando a migração", "version", mig.Version, "description", mig.Description)
+			start := time.Now()
+			if err := mig.Up(ctx, m.env); err != nil {
+				return fmt.Errorf("migração %d (%s): %w", mig.Version, mig.Description, err)
+			}
+			_, err := m.coll.InsertOne(ctx, record{
+				ID:          mig.Version,
+				Version:     mig.Version,
+				Description: mig.Description,
+				AppliedAt:   time.Now().UTC(),
+				DurationMs:  time.Since(start).Milliseconds(),
+			})
+			if err != nil {
+				return fmt.Errorf("registro da migração %d: %w", mig.Version, err)
+			}
+			done = append(done, mig.Version)
+		}
+		return nil
+	})
+	return done, err
+}
+
+// Reapply executa de novo, em ordem, o Up das migrações já aplicadas, sem alterar os registros.
+// Serve para os databases criados depois da migração (tenants novos no TENANT_MODE=database), já
+// que o Up é idempotente. Retorna as versões executadas.
+func (m *Migrator) Reapply(ctx context.Context) ([]int64, error) {
+	var done []int64
+	err := m.locked(ctx, func(ctx context.Context) error {
+		applied, err := m.applied(ctx)
+		if err != nil {
+			return err
+		}
+		for _, mig := range m.migrations {
+			if _, ok := applied[mig.Version]; !ok {
+				continue
+			}
+			m.log.Info("Reaplicando a migração", "version", mig.Version, "description", mig.Description)
+			if err := mig.Up(ctx, m.env); err != nil {
+				return fmt.Errorf("migração %d (%s): %w", mig.Version, mig.Description, err)
+			}
+			done = append(done, mig.Version)
+		}
+		return nil
+	})
+	return done, err
+}
+
+// Down reverte as últimas steps migrações aplicadas, da mais recente para a mais antiga. Retorna
+// as versões revertidas.
+func (m *Migrator) Down(ctx context.Context, steps int) ([]int64, error) {
+	var done []int64
+	err := m.locked(ctx, func(ctx context.Context) error {
+		applied, err := m.applied(ctx)
+		if err != nil {
+			return err
+		}
+		for i := len(m.migrations) - 1; i >= 0 && len(done) < steps; i-- {
+			mig := m.migrations[i]
+			if _, ok := applied[mig.Version]; !ok {
+				continue
+			}
+			if mig.Down == nil {
+				return fmt.Errorf("migração %d (%s): %w", mig.Version, mig.Description, ErrIrreversible)
+			}
+			m.log.Info("Revertendo a migração", "version", mig.Version, "description", mig.Description)
+			if err := mig.Down(ctx, m.env); err != nil {
+				return fmt.Errorf("migração %d (%s): %w", mig.Version, mig.Description, err)
+			}
+			if _, err := m.coll.DeleteOne(ctx, bson.M{"_id": mig.Version}); err != nil {
+				return fmt.Errorf("registro da migração %d: %w", mig.Version, err)
+			}
+			done = append(done, mig.Version)
+		}
+		return nil
+	})
+	return done, err
+}
+
+// Status retorna a situação de cada versão, incluindo as registradas no banco que esta versão da
+// API não conhece (aplicadas por uma versão mais nova).
+func (m *Migrator) Status(ctx context.Context) ([]Status, error) {
+	applied, err := m.applied(ctx)
+	if err != nil {
+		return nil, err
+	}
+	list := make([]Status, 0, len(m.migrations))
+	for _, mig := range m.migrations {
+		s := Status{Version: mig.Version, Description: mig.Description, Status: StatusPending}
+		if r, ok := applied[mig.Version]; ok {
+			s.Status, s.AppliedAt = StatusApplied, &r.AppliedAt
+			delete(applied, mig.Version)
+		}
+		list = append(list, s)
+	}
+	for _, r := range applied {
+		list = append(list, Status{Version: r.Version, Description: r.Description, Status: StatusUnknown, AppliedAt: &r.AppliedAt})
+	}
+	slices.SortFunc(list, func(a, b Status) int { return cmp.Compare(a.Version, b.Version) })
+	return list, nil
+}
+
+// Pending retorna as versões ainda não aplicadas
+func (m *Migrator) Pending(ctx context.Context) ([]int64, error) {
+	list, err := m.Status(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var pending []int64
+	for _, s := range list {
+		if s.Status == StatusPending {
+			pending = append(pending, s.Version)
+		}
+	}
+	return pending, nil
+}
+
+// applied retorna as versões registradas na collection, sem o documento da trava
+func (m *Migrator) applied(ctx context.Context) (map[int64]record, error) {
+	cursor, err := m.coll.Find(ctx, bson.M{"version": bson.M{"$exists": true}}, options.Find().SetSort(bson.D{{Key: "version", Value: 1}}))
+	if err != nil {
+		return nil, err
+	}
+	var records []record
+	if err := cursor.All(ctx, &records); err != nil {
+		return nil, err
+	}
+	applied := make(map[int64]record, len(records))
+	for _, r := range records {
+		applied[r.Version] = r
+	}
+	return applied, nil
+}
+
+// locked executa fn com a trava. O contexto de fn é cancelado se a trava for perdida.
+func (m *Migrator) locked(ctx context.Context, fn func(ctx context.Context) error) error {
+	ctx, release, err := m.lock.acquire(ctx)
+	if err != nil {
+		return err
+	}
+	defer release()
+	return fn(ctx)
+}
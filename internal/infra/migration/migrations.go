@@ -0,0 +1,14 @@
+package migration
+
+// All retorna as migrações da API. Uma alteração nova do banco entra no fim da lista com a próxima
+// versão; as migrações já publicadas não devem ser alteradas, pois os bancos que já as aplicaram
+// não as executam de novo.
+//
+// As collections dos tenants são obtidas com env.Tenants.All. No TENANT_MODE=database, os tenants
+// criados depois de uma migração recebem-na com o migrate up -reapply.
+func All() []Migration {
+	return []Migration{
+		v0001Indexes,
+		v0002Validation,
+	}
+}
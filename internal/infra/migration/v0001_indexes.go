@@ -0,0 +1,69 @@
+package migration
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// v0001Indexes cria os índices das consultas por id, que percorriam a collection inteira, e das
+// consultas do consentimento por cliente e por finalidade. Os índices criados pelos repositórios
+// na subida (blind index do documento, cadeia de hashes, prefixo da chave de API) continuam com eles.
+var v0001Indexes = Migration{
+	Version:     1,
+	Description: "índices das consultas por id e do consentimento",
+	Up: func(ctx context.Context, env Env) error {
+		clientes, err := env.Tenants.All(ctx, env.Collections.Cliente)
+		if err != nil {
+			return err
+		}
+		if err := createIndexes(ctx, clientes, []mongo.IndexModel{{
+			Keys:    env.Tenants.IndexKeys(bson.D{{Key: "id", Value: 1}}),
+			Options: options.Index().SetName("uniq_id").SetUnique(true),
+		}}); err != nil {
+			return err
+		}
+
+		consentimentos, err := env.Tenants.All(ctx, env.Collections.Consentimento)
+		if err != nil {
+			return err
+		}
+		if err := createIndexes(ctx, consentimentos, []mongo.IndexModel{
+			{
+				Keys:    env.Tenants.IndexKeys(bson.D{{Key: "cliente_id", Value: 1}, {Key: "finalidade", Value: 1}, {Key: "created_at", Value: -1}}),
+				Options: options.Index().SetName("idx_cliente_finalidade_created_at"),
+			},
+			{
+				Keys:    env.Tenants.IndexKeys(bson.D{{Key: "finalidade", Value: 1}, {Key: "created_at", Value: -1}}),
+				Options: options.Index().SetName("idx_finalidade_created_at"),
+			},
+		}); err != nil {
+			return err
+		}
+
+		// As chaves de API ficam no database base, sem tenant
+		return createIndexes(ctx, []*mongo.Collection{env.DB.Collection(env.Collections.ApiKey)}, []mongo.IndexModel{{
+			Keys:    bson.D{{Key: "id", Value: 1}},
+			Options: options.Index().SetName("uniq_id").SetUnique(true),
+		}})
+	},
+	Down: func(ctx context.Context, env Env) error {
+		clientes, err := env.Tenants.All(ctx, env.Collections.Cliente)
+		if err != nil {
+			return err
+		}
+		if err := dropIndexes(ctx, clientes, "uniq_id"); err != nil {
+			return err
+		}
+		consentimentos, err := env.Tenants.All(ctx, env.Collections.Consentimento)
+		if err != nil {
+			return err
+		}
+		if err := dropIndexes(ctx, consentimentos, "idx_cliente_finalidade_created_at", "idx_finalidade_created_at"); err != nil {
+			return err
+		}
+		return dropIndexes(ctx, []*mongo.Collection{env.DB.Collection(env.Collections.ApiKey)}, "uniq_id")
+	},
+}
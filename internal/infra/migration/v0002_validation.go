@@ -0,0 +1,98 @@
+package migration
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// Regra de validação do cliente. O documento e o telefone podem estar cifrados, por isso apenas o
+// tipo é verificado; os limites de tamanho ficam com os value objects do domínio.
+var clienteSchema = bson.M{
+	"bsonType": "object",
+	"required": bson.A{"id", "nome", "documento", "telefone", "bloqueado", "created_at"},
+	"properties": bson.M{
+		"id":             bson.M{"bsonType": "binData"},
+		"tenant":         bson.M{"bsonType": "string"},
+		"nome":           bson.M{"bsonType": "string"},
+		"documento":      bson.M{"bsonType": "string"},
+		"documento_bidx": bson.M{"bsonType": "string"},
+		"telefone":       bson.M{"bsonType": "string"},
+		"enc_key":        bson.M{"bsonType": "string"},
+		"bloqueado":      bson.M{"bsonType": "bool"},
+		"created_at":     bson.M{"bsonType": "date"},
+		"updated_at":     bson.M{"bsonType": "date"},
+	},
+}
+
+// Regra de validação do histórico de consentimentos. A base legal não é restrita à lista do domínio
+// para que uma base nova não dependa de uma migração.
+var consentimentoSchema = bson.M{
+	"bsonType": "object",
+	"required": bson.A{"id", "cliente_id", "finalidade", "base_legal", "canal", "versao_politica", "status", "concedido_em", "created_at"},
+	"properties": bson.M{
+		"id":              bson.M{"bsonType": "binData"},
+		"cliente_id":      bson.M{"bsonType": "binData"},
+		"tenant":          bson.M{"bsonType": "string"},
+		"finalidade":      bson.M{"bsonType": "string"},
+		"base_legal":      bson.M{"bsonType": "string"},
+		"canal":           bson.M{"bsonType": "string"},
+		"versao_politica": bson.M{"bsonType": "string"},
+		"status":          bson.M{"enum": bson.A{"concedido", "revogado"}},
+		"concedido_em":    bson.M{"bsonType": "date"},
+		"revogado_em":     bson.M{"bsonType": "date"},
+		"created_at":      bson.M{"bsonType": "date"},
+	},
+}
+
+// v0002Validation aplica as regras de validação ($jsonSchema) do cliente e do consentimento, para que
+// uma gravação fora do formato esperado (outro serviço ou uma correção manual) seja recusada.
+var v0002Validation = Migration{
+	Version:     2,
+	Description: "regras de validação do cliente e do consentimento",
+	Up: func(ctx context.Context, env Env) error {
+		return forEachCollection(ctx, env, func(ctx context.Context, name string, schema bson.M) error {
+			colls, err := env.Tenants.All(ctx, name)
+			if err != nil {
+				return err
+			}
+			for _, coll := range colls {
+				if err := setValidator(ctx, coll, schema); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+	},
+	Down: func(ctx context.Context, env Env) error {
+		return forEachCollection(ctx, env, func(ctx context.Context, name string, _ bson.M) error {
+			colls, err := env.Tenants.All(ctx, name)
+			if err != nil {
+				return err
+			}
+			for _, coll := range colls {
+				if err := removeValidator(ctx, coll); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+	},
+}
+
+// forEachCollection chama fn com o nome e a regra de cada collection validada, na ordem
+func forEachCollection(ctx context.Context, env Env, fn func(ctx context.Context, name string, schema bson.M) error) error {
+	validated := []struct {
+		name   string
+		schema bson.M
+	}{
+		{env.Collections.Cliente, clienteSchema},
+		{env.Collections.Consentimento, consentimentoSchema},
+	}
+	for _, v := range validated {
+		if err := fn(ctx, v.name, v.schema); err != nil {
+			return err
+		}
+	}
+	return nil
+}
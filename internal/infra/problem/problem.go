@@ -0,0 +1,187 @@
+// Pacote com as respostas de erro da API no formato application/problem+json (RFC 7807). Cada erro
+// conhecido é registrado com o status HTTP e um código estável, que o cliente pode usar no lugar da
+// mensagem. As mensagens vêm dos catálogos do pacote i18n, no idioma do Accept-Language.
+package problem
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+	"github.com/valdinei-santos/cpf-backend/internal/domain/globalerr"
+	"github.com/valdinei-santos/cpf-backend/internal/infra/i18n"
+	"github.com/valdinei-santos/cpf-backend/internal/infra/requestinfo"
+	"github.com/valdinei-santos/cpf-backend/internal/infra/timeout"
+)
+
+// MediaType é o Content-Type das respostas de erro
+const MediaType = "application/problem+json"
+
+// Código dos campos inválidos cujo erro não está registrado
+const codeInvalid = "invalid"
+
+// TypeBase é o prefixo do type dos problemas, seguido do código. Ex: urn:cpf-backend:problem:cliente.not_found
+const TypeBase = "urn:cpf-backend:problem:"
+
+// Problem é o corpo das respostas de erro
+type Problem struct {
+	Type      string         `json:"type" example:"urn:cpf-backend:problem:cliente.not_found"`
+	Code      string         `json:"code" example:"cliente.not_found"` // Código estável do erro
+	Status    int            `json:"status" example:"404"`
+	Title     string         `json:"title" example:"recurso não encontrado"`  // Descrição do status, no idioma do Accept-Language
+	Detail    string         `json:"detail" example:"cliente não encontrado"` // Mensagem do código, no idioma do Accept-Language
+	Instance  string         `json:"instance,omitempty" example:"/api/v1/cliente/43310521-a934-11f0-ae2b-fabc94dc9b50"`
+	Errors    []FieldProblem `json:"errors,omitempty"` // Campos inválidos, nas respostas 422
+	RequestID string         `json:"request_id,omitempty"`
+	TraceID   string         `json:"trace_id,omitempty"`
+
+	language string // Idioma das mensagens, devolvido no header Content-Language
+} //@name Problem
+
+// FieldProblem é um campo inválido da entrada
+type FieldProblem struct {
+	Field  string `json:"field" example:"nome"`
+	Code   string `json:"code" example:"cliente.nome_invalid"`
+	Detail string `json:"detail" example:"o nome deve ter entre 3 e 50 caracteres"` // No idioma do Accept-Language
+} //@name FieldProblem
+
+// Entry associa um erro ao status HTTP e ao código retornados. O detail é a mensagem do código nos
+// catálogos do pacote i18n; sem ela, a mensagem do erro.
+type Entry struct {
+	Err    error
+	Status int
+	Code   string
+}
+
+// Erros comuns da API. Os erros dos módulos e da infraestrutura são registrados na subida com Register.
+var defaults = []Entry{
+	{globalerr.ErrBadRequest, http.StatusBadRequest, "bad_request"},
+	{globalerr.ErrValidation, http.StatusUnprocessableEntity, "validation_failed"},
+	{globalerr.ErrUnauthorized, http.StatusUnauthorized, "unauthorized"},
+	{globalerr.ErrForbidden, http.StatusForbidden, "forbidden"},
+	{globalerr.ErrNotFound, http.StatusNotFound, "not_found"},
+	{globalerr.ErrRouteNotFound, http.StatusNotFound, "route_not_found"},
+	{globalerr.ErrMethodNotAllowed, http.StatusMethodNotAllowed, "method_not_allowed"},
+	{globalerr.ErrDuplicatekey, http.StatusConflict, "duplicate_key"},
+	{globalerr.ErrTooManyRequests, http.StatusTooManyRequests, "too_many_requests"},
+	{globalerr.ErrCanceled, timeout.StatusClientClosedRequest, "canceled"},
+	{globalerr.ErrInternal, http.StatusInternalServerError, "internal"},
+	{globalerr.ErrSaveInDatabase, http.StatusInternalServerError, "database_write"},
+	{globalerr.ErrConnectionInDatabase, http.StatusServiceUnavailable, "database_unavailable"},
+	{globalerr.ErrTimeout, http.StatusGatewayTimeout, "timeout"},
+}
+
+var (
+	mu       sync.RWMutex
+	registry = append([]Entry{}, defaults...)
+)
+
+// Register registra os erros. Um erro já registrado tem o status e o código substituídos.
+func Register(entries ...Entry) {
+	mu.Lock()
+	defer mu.Unlock()
+	for _, e := range entries {
+		if i := indexOf(e.Err); i >= 0 {
+			registry[i] = e
+			continue
+		}
+		registry = append(registry, e)
+	}
+}
+
+// Lookup retorna o registro do erro, comparando com errors.Is na ordem do registro. Erros não
+// registrados retornam o registro do globalerr.ErrInternal, sem expor a mensagem original.
+func Lookup(err error) (Entry, bool) {
+	mu.RLock()
+	defer mu.RUnlock()
+	for _, e := range registry {
+		if errors.Is(err, e.Err) {
+			return e, true
+		}
+	}
+	return registry[indexOf(globalerr.ErrInternal)], false
+}
+
+func indexOf(err error) int {
+	for i, e := range registry {
+		if e.Err == err {
+			return i
+		}
+	}
+	return -1
+}
+
+// New monta o problema do erro com as mensagens no idioma do Accept-Language (ver o pacote
+// i18n). Um *globalerr.ValidationError vira 422 com todos os campos inválidos. Um erro 5xx causado
+// pelo prazo da operação ou pelo cancelamento da requisição vira 504 ou 499.
+func New(c *gin.Context, err error) *Problem {
+	ctx := c.Request.Context()
+	lang := i18n.Negotiate(c.GetHeader(i18n.HeaderAcceptLanguage))
+	entry, _ := Lookup(err)
+
+	var fields []FieldProblem
+	var validationErr *globalerr.ValidationError
+	if errors.As(err, &validationErr) {
+		entry, _ = Lookup(globalerr.ErrValidation)
+		for _, f := range validationErr.Fields {
+			// Erros de campo fora do registro usam o código genérico, sem expor a mensagem original
+			code := codeInvalid
+			if fieldEntry, ok := Lookup(f.Err); ok {
+				code = fieldEntry.Code
+			}
+			fields = append(fields, FieldProblem{Field: f.Field, Code: code, Detail: message(lang, code, f.Err)})
+		}
+	} else if entry.Status >= http.StatusInternalServerError {
+		switch status, _ := timeout.Status(ctx, err); status {
+		case http.StatusGatewayTimeout:
+			entry, _ = Lookup(globalerr.ErrTimeout)
+		case timeout.StatusClientClosedRequest:
+			entry, _ = Lookup(globalerr.ErrCanceled)
+		}
+	}
+
+	title, ok := i18n.Message(lang, "status."+strconv.Itoa(entry.Status))
+	if !ok {
+		title = http.StatusText(entry.Status)
+	}
+	info := requestinfo.FromContext(ctx)
+	return &Problem{
+		Type:      TypeBase + entry.Code,
+		Code:      entry.Code,
+		Status:    entry.Status,
+		Title:     title,
+		Detail:    message(lang, entry.Code, entry.Err),
+		Instance:  c.Request.URL.Path,
+		Errors:    fields,
+		RequestID: info.RequestID,
+		TraceID:   info.TraceID,
+		language:  lang,
+	}
+}
+
+// message retorna a mensagem do código no idioma, ou a mensagem do erro se o código não estiver
+// nos catálogos
+func message(lang, code string, err error) string {
+	if msg, ok := i18n.Message(lang, code); ok {
+		return msg
+	}
+	return err.Error()
+}
+
+// Write responde o erro no formato application/problem+json, no idioma do Accept-Language
+func Write(c *gin.Context, err error) {
+	p := New(c, err)
+	c.Header("Content-Type", MediaType)
+	c.Header("Content-Language", p.language)
+	c.Writer.Header().Add("Vary", i18n.HeaderAcceptLanguage)
+	c.JSON(p.Status, p)
+}
+
+// Abort responde o erro no formato application/problem+json e interrompe a requisição. Usado
+// pelos middlewares.
+func Abort(c *gin.Context, err error) {
+	Write(c, err)
+	c.Abort()
+}
@@ -0,0 +1,200 @@
+package problem_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/valdinei-santos/cpf-backend/internal/domain/globalerr"
+	"github.com/valdinei-santos/cpf-backend/internal/infra/i18n"
+	"github.com/valdinei-santos/cpf-backend/internal/infra/problem"
+	"github.com/valdinei-santos/cpf-backend/internal/infra/timeout"
+)
+
+var (
+	errNomeInvalido = errors.New("o nome deve ter entre 3 e 50 caracteres")
+	errConflito     = errors.New("conflito de teste")
+)
+
+// validation monta o erro de validação com um campo registrado e outro fora do registro
+func validation() error {
+	var v globalerr.Validation
+	v.Add("nome", errNomeInvalido)
+	v.Add("email", errors.New("formato inesperado"))
+	return v.Err()
+}
+
+func TestWrite(t *testing.T) {
+	problem.Register(
+		problem.Entry{Err: errNomeInvalido, Status: http.StatusBadRequest, Code: "teste.nome_invalid"},
+		problem.Entry{Err: errConflito, Status: http.StatusBadRequest, Code: "teste.conflito"},
+	)
+	// Registrar de novo substitui o status e o código
+	problem.Register(problem.Entry{Err: errConflito, Status: http.StatusConflict, Code: "teste.conflito"})
+
+	expired, cancelExpired := context.WithTimeout(context.Background(), 0)
+	defer cancelExpired()
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	tests := []struct {
+		name             string
+		err              error
+		ctx              context.Context
+		acceptLanguage   string
+		expectedStatus   int
+		expectedCode     string
+		expectedTitle    string
+		expectedDetail   string
+		expectedLanguage string
+		expectedFields   []problem.FieldProblem
+	}{
+		{
+			name:             "Deve responder o status e o código do erro registrado",
+			err:              fmt.Errorf("buscar cliente: %w", globalerr.ErrNotFound),
+			expectedStatus:   http.StatusNotFound,
+			expectedCode:     "not_found",
+			expectedTitle:    "recurso não encontrado",
+			expectedDetail:   "não encontrado",
+			expectedLanguage: i18n.PtBR,
+		},
+		{
+			name:             "Deve responder 403 para o acesso negado",
+			err:              globalerr.ErrForbidden,
+			expectedStatus:   http.StatusForbidden,
+			expectedCode:     "forbidden",
+			expectedLanguage: i18n.PtBR,
+		},
+		{
+			name:             "Deve responder 500 sem expor a mensagem do erro não registrado",
+			err:              errors.New("senha do banco na mensagem"),
+			expectedStatus:   http.StatusInternalServerError,
+			expectedCode:     "internal",
+			expectedDetail:   "erro interno no servidor",
+			expectedLanguage: i18n.PtBR,
+		},
+		{
+			name:             "Deve usar o registro substituído e a mensagem do erro sem catálogo",
+			err:              errConflito,
+			expectedStatus:   http.StatusConflict,
+			expectedCode:     "teste.conflito",
+			expectedDetail:   "conflito de teste",
+			expectedLanguage: i18n.PtBR,
+		},
+		{
+			name:             "Deve responder 422 com os campos inválidos",
+			err:              validation(),
+			expectedStatus:   http.StatusUnprocessableEntity,
+			expectedCode:     "validation_failed",
+			expectedTitle:    "entidade inutilizável",
+			expectedDetail:   "um ou mais campos são inválidos",
+			expectedLanguage: i18n.PtBR,
+			expectedFields: []problem.FieldProblem{
+				{Field: "nome", Code: "teste.nome_invalid", Detail: "o nome deve ter entre 3 e 50 caracteres"},
+				{Field: "email", Code: "invalid", Detail: "valor inválido"},
+			},
+		},
+		{
+			name:             "Deve responder as mensagens no idioma do Accept-Language",
+			err:              globalerr.ErrNotFound,
+			acceptLanguage:   "en-GB,en;q=0.9",
+			expectedStatus:   http.StatusNotFound,
+			expectedCode:     "not_found",
+			expectedTitle:    "resource not found",
+			expectedDetail:   "not found",
+			expectedLanguage: i18n.EnUS,
+		},
+		{
+			name:             "Deve responder 504 para o erro interno com o prazo esgotado",
+			err:              globalerr.ErrSaveInDatabase,
+			ctx:              expired,
+			expectedStatus:   http.StatusGatewayTimeout,
+			expectedCode:     "timeout",
+			expectedLanguage: i18n.PtBR,
+		},
+		{
+			name:             "Deve responder 499 para o erro interno com a requisição cancelada",
+			err:              globalerr.ErrSaveInDatabase,
+			ctx:              canceled,
+			expectedStatus:   timeout.StatusClientClosedRequest,
+			expectedCode:     "canceled",
+			expectedLanguage: i18n.PtBR,
+		},
+		{
+			name:             "Deve manter o status 4xx com a requisição cancelada",
+			err:              globalerr.ErrNotFound,
+			ctx:              canceled,
+			expectedStatus:   http.StatusNotFound,
+			expectedCode:     "not_found",
+			expectedLanguage: i18n.PtBR,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+			r := gin.New()
+			r.GET("/cliente/:id", func(c *gin.Context) { problem.Write(c, tt.err) })
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, "/cliente/123", nil)
+			if tt.ctx != nil {
+				req = req.WithContext(tt.ctx)
+			}
+			if tt.acceptLanguage != "" {
+				req.Header.Set(i18n.HeaderAcceptLanguage, tt.acceptLanguage)
+			}
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			assert.Equal(t, problem.MediaType, w.Header().Get("Content-Type"))
+			assert.Equal(t, tt.expectedLanguage, w.Header().Get("Content-Language"))
+			assert.Equal(t, i18n.HeaderAcceptLanguage, w.Header().Get("Vary"))
+
+			var p problem.Problem
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &p))
+			assert.Equal(t, tt.expectedStatus, p.Status)
+			assert.Equal(t, tt.expectedCode, p.Code)
+			assert.Equal(t, problem.TypeBase+tt.expectedCode, p.Type)
+			assert.Equal(t, "/cliente/123", p.Instance)
+			if tt.expectedTitle != "" {
+				assert.Equal(t, tt.expectedTitle, p.Title)
+			}
+			if tt.expectedDetail != "" {
+				assert.Equal(t, tt.expectedDetail, p.Detail)
+			}
+			assert.Equal(t, tt.expectedFields, p.Errors)
+		})
+	}
+}
+
+func TestLookup(t *testing.T) {
+	tests := []struct {
+		name           string
+		err            error
+		expectedOK     bool
+		expectedStatus int
+		expectedCode   string
+	}{
+		{name: "Deve encontrar o erro padrão", err: globalerr.ErrTooManyRequests, expectedOK: true, expectedStatus: http.StatusTooManyRequests, expectedCode: "too_many_requests"},
+		{name: "Deve encontrar o erro encapsulado", err: fmt.Errorf("salvar: %w", globalerr.ErrDuplicatekey), expectedOK: true, expectedStatus: http.StatusConflict, expectedCode: "duplicate_key"},
+		{name: "Deve retornar o erro interno para o erro não registrado", err: errors.New("desconhecido"), expectedOK: false, expectedStatus: http.StatusInternalServerError, expectedCode: "internal"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entry, ok := problem.Lookup(tt.err)
+
+			assert.Equal(t, tt.expectedOK, ok)
+			assert.Equal(t, tt.expectedStatus, entry.Status)
+			assert.Equal(t, tt.expectedCode, entry.Code)
+		})
+	}
+}
@@ -0,0 +1,107 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/valdinei-santos/cpf-backend/internal/domain/globalerr"
+)
+
+// Limiter aplica ao chamador o limite de leitura ou de escrita e a cota diária
+type Limiter struct {
+	store    Store
+	read     Rate
+	write    Rate
+	quota    int
+	combined bool // Leituras e escritas no mesmo limite (ver NewIPLimiter)
+	now      func() time.Time
+}
+
+// Decision é o resultado dos limites aplicados à requisição
+type Decision struct {
+	Result        // Limite mais restritivo entre o rate limit e a cota diária
+	Policy string // Limites aplicados, no formato do header RateLimit-Policy
+	Reason error  // Quando negado: globalerr.ErrTooManyRequests ou ErrQuotaExceeded
+}
+
+// NewLimiter - cria o Limiter. Rate desabilitado ou quota zero desligam o respectivo limite.
+func NewLimiter(store Store, read Rate, write Rate, quota int) *Limiter {
+	return &Limiter{
+		store: store,
+		read:  read,
+		write: write,
+		quota: quota,
+		now:   time.Now,
+	}
+}
+
+// NewIPLimiter - cria o Limiter com um único limite para leituras e escritas, sem cota diária.
+// Usado por IP antes da autenticação, para conter tentativas com credenciais inválidas.
+func NewIPLimiter(store Store, rate Rate) *Limiter {
+	return &Limiter{
+		store:    store,
+		read:     rate,
+		write:    rate,
+		combined: true,
+		now:      time.Now,
+	}
+}
+
+// Enabled indica se algum limite está configurado
+func (l *Limiter) Enabled() bool {
+	return l.read.Enabled() || l.write.Enabled() || l.quota > 0
+}
+
+// Check consome uma requisição dos limites do principal (chave de API, usuário ou IP). Requisição
+// negada pelo rate limit não consome a cota diária, e a negada pela cota não gasta o token do rate limit.
+func (l *Limiter) Check(ctx context.Context, principal string, write bool) (Decision, error) {
+	now := l.now()
+	class, rate := "read", l.read
+	if write {
+		class, rate = "write", l.write
+	}
+	if l.combined {
+		class = "all"
+	}
+
+	policies := []string{}
+	if rate.Enabled() {
+		policies = append(policies, fmt.Sprintf("%d;w=%d", rate.Limit, int(rate.Period.Seconds())))
+	}
+	if l.quota > 0 {
+		policies = append(policies, fmt.Sprintf("%d;w=%d", l.quota, int((24*time.Hour).Seconds())))
+	}
+	d := Decision{Result: Result{Allowed: true}, Policy: strings.Join(policies, ", ")}
+
+	if rate.Enabled() {
+		res, err := l.store.Take(ctx, principal+"|"+class, rate, now)
+		if err != nil {
+			return d, err
+		}
+		d.Result = res
+		if !res.Allowed {
+			d.Reason = globalerr.ErrTooManyRequests
+			return d, nil
+		}
+	}
+
+	if l.quota > 0 {
+		res, err := l.store.Consume(ctx, principal+"|quota", l.quota, now)
+		if err != nil {
+			return d, err
+		}
+		if !rate.Enabled() || !res.Allowed || res.Remaining < d.Remaining {
+			d.Result = res
+		}
+		if !res.Allowed {
+			d.Reason = ErrQuotaExceeded
+			if rate.Enabled() {
+				// A falha na devolução só antecipa o rate limit, a requisição já foi negada pela cota
+				_ = l.store.Refund(ctx, principal+"|"+class, rate, now)
+			}
+		}
+	}
+	return d, nil
+}
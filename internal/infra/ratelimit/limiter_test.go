@@ -0,0 +1,156 @@
+package ratelimit_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/valdinei-santos/cpf-backend/internal/domain/globalerr"
+	"github.com/valdinei-santos/cpf-backend/internal/infra/ratelimit"
+)
+
+// clockStore usa o relógio do teste no lugar do horário informado pelo Limiter
+type clockStore struct {
+	store ratelimit.Store
+	now   time.Time
+}
+
+func (s *clockStore) Take(ctx context.Context, key string, rate ratelimit.Rate, _ time.Time) (ratelimit.Result, error) {
+	return s.store.Take(ctx, key, rate, s.now)
+}
+
+func (s *clockStore) Refund(ctx context.Context, key string, rate ratelimit.Rate, _ time.Time) error {
+	return s.store.Refund(ctx, key, rate, s.now)
+}
+
+func (s *clockStore) Consume(ctx context.Context, key string, quota int, _ time.Time) (ratelimit.Result, error) {
+	return s.store.Consume(ctx, key, quota, s.now)
+}
+
+func newClockStore() *clockStore {
+	return &clockStore{store: ratelimit.NewMemoryStore(), now: start}
+}
+
+func TestLimiterCheck(t *testing.T) {
+	read := ratelimit.Rate{Limit: 2, Period: time.Minute}
+	write := ratelimit.Rate{Limit: 1, Period: time.Minute}
+
+	tests := []struct {
+		name       string
+		limiter    func(store ratelimit.Store) *ratelimit.Limiter
+		writes     []bool // Requisições feitas antes da verificada
+		write      bool
+		wantAllow  bool
+		wantReason error
+		wantPolicy string
+	}{
+		{
+			name:       "Deve aceitar a leitura dentro do limite",
+			limiter:    func(s ratelimit.Store) *ratelimit.Limiter { return ratelimit.NewLimiter(s, read, write, 0) },
+			wantAllow:  true,
+			wantPolicy: "2;w=60",
+		},
+		{
+			name:       "Deve recusar a escrita acima do limite de escrita",
+			limiter:    func(s ratelimit.Store) *ratelimit.Limiter { return ratelimit.NewLimiter(s, read, write, 0) },
+			writes:     []bool{true},
+			write:      true,
+			wantReason: globalerr.ErrTooManyRequests,
+			wantPolicy: "1;w=60",
+		},
+		{
+			name:       "Deve manter leitura e escrita em limites separados",
+			limiter:    func(s ratelimit.Store) *ratelimit.Limiter { return ratelimit.NewLimiter(s, read, write, 0) },
+			writes:     []bool{true},
+			wantAllow:  true,
+			wantPolicy: "2;w=60",
+		},
+		{
+			name:       "Deve recusar pela cota diária",
+			limiter:    func(s ratelimit.Store) *ratelimit.Limiter { return ratelimit.NewLimiter(s, read, write, 1) },
+			writes:     []bool{false},
+			wantReason: ratelimit.ErrQuotaExceeded,
+			wantPolicy: "2;w=60, 1;w=86400",
+		},
+		{
+			name:       "Deve compartilhar o limite entre leitura e escrita no limite por IP",
+			limiter:    func(s ratelimit.Store) *ratelimit.Limiter { return ratelimit.NewIPLimiter(s, write) },
+			writes:     []bool{true},
+			wantReason: globalerr.ErrTooManyRequests,
+			wantPolicy: "1;w=60",
+		},
+		{
+			name: "Deve aceitar tudo sem limites configurados",
+			limiter: func(s ratelimit.Store) *ratelimit.Limiter {
+				return ratelimit.NewLimiter(s, ratelimit.Rate{}, ratelimit.Rate{}, 0)
+			},
+			writes:    []bool{true, true, true},
+			write:     true,
+			wantAllow: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := tt.limiter(newClockStore())
+			for _, w := range tt.writes {
+				_, err := l.Check(context.Background(), "user:a", w)
+				require.NoError(t, err)
+			}
+			d, err := l.Check(context.Background(), "user:a", tt.write)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantAllow, d.Allowed)
+			assert.Equal(t, tt.wantReason, d.Reason)
+			assert.Equal(t, tt.wantPolicy, d.Policy)
+		})
+	}
+}
+
+func TestLimiterCheckNaoConsomeCotaQuandoRecusado(t *testing.T) {
+	store := newClockStore()
+	l := ratelimit.NewLimiter(store, ratelimit.Rate{Limit: 1, Period: time.Minute}, ratelimit.Rate{}, 2)
+
+	first, _ := l.Check(context.Background(), "user:a", false)
+	denied, _ := l.Check(context.Background(), "user:a", false)
+	store.now = store.now.Add(time.Minute)
+	second, _ := l.Check(context.Background(), "user:a", false)
+
+	assert.True(t, first.Allowed)
+	assert.Equal(t, globalerr.ErrTooManyRequests, denied.Reason)
+	assert.True(t, second.Allowed, "a requisição recusada pelo rate limit não deve consumir a cota")
+	assert.Equal(t, 0, second.Remaining)
+}
+
+func TestLimiterCheckNaoGastaTokenQuandoCotaRecusa(t *testing.T) {
+	store := newClockStore()
+	read := ratelimit.Rate{Limit: 2, Period: time.Minute}
+	l := ratelimit.NewLimiter(store, read, ratelimit.Rate{}, 1)
+	other := ratelimit.NewLimiter(store, read, ratelimit.Rate{}, 0)
+
+	first, _ := l.Check(context.Background(), "user:a", false)
+	denied, _ := l.Check(context.Background(), "user:a", false)
+	// Sem a cota, o mesmo bucket ainda tem o token que a requisição negada pela cota devolveu
+	after, _ := other.Check(context.Background(), "user:a", false)
+
+	assert.True(t, first.Allowed)
+	assert.Equal(t, ratelimit.ErrQuotaExceeded, denied.Reason)
+	assert.True(t, after.Allowed)
+	assert.Equal(t, 0, after.Remaining)
+}
+
+func TestLimiterCheckReiniciaCotaNoDiaSeguinte(t *testing.T) {
+	store := newClockStore()
+	l := ratelimit.NewLimiter(store, ratelimit.Rate{}, ratelimit.Rate{}, 1)
+
+	first, _ := l.Check(context.Background(), "apikey:1", true)
+	denied, _ := l.Check(context.Background(), "apikey:1", true)
+	store.now = store.now.Add(12 * time.Hour) // Meia-noite UTC
+	next, _ := l.Check(context.Background(), "apikey:1", true)
+
+	assert.True(t, first.Allowed)
+	assert.Equal(t, ratelimit.ErrQuotaExceeded, denied.Reason)
+	assert.Equal(t, 12*time.Hour, denied.RetryAfter)
+	assert.True(t, next.Allowed)
+}
@@ -0,0 +1,127 @@
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+)
+
+// sweepInterval é o intervalo da limpeza dos buckets e contadores que não são mais usados
+const sweepInterval = time.Minute
+
+// MemoryStore guarda os limites na memória da instância. Usa token bucket para o rate limit:
+// o bucket comporta Limit requisições e é reabastecido continuamente à taxa de Limit por Period.
+type MemoryStore struct {
+	mu        sync.Mutex
+	buckets   map[string]*bucket
+	counters  map[string]*counter
+	lastSweep time.Time
+}
+
+type bucket struct {
+	tokens  float64
+	period  time.Duration
+	updated time.Time
+}
+
+type counter struct {
+	count int
+	end   time.Time
+}
+
+// NewMemoryStore - cria o MemoryStore
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		buckets:  map[string]*bucket{},
+		counters: map[string]*counter{},
+	}
+}
+
+// Take consome um token do bucket da chave
+func (s *MemoryStore) Take(_ context.Context, key string, rate Rate, now time.Time) (Result, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.sweep(now)
+
+	capacity := float64(rate.Limit)
+	perSecond := capacity / rate.Period.Seconds()
+
+	b, ok := s.buckets[key]
+	if !ok {
+		b = &bucket{tokens: capacity, updated: now}
+		s.buckets[key] = b
+	}
+	b.period = rate.Period
+	b.tokens = math.Min(capacity, b.tokens+now.Sub(b.updated).Seconds()*perSecond)
+	b.updated = now
+
+	res := Result{Limit: rate.Limit}
+	if b.tokens >= 1 {
+		b.tokens--
+		res.Allowed = true
+	} else {
+		res.RetryAfter = seconds((1 - b.tokens) / perSecond)
+	}
+	res.Remaining = int(b.tokens)
+	res.Reset = seconds((capacity - b.tokens) / perSecond)
+	return res, nil
+}
+
+// Refund devolve um token ao bucket da chave, sem passar da capacidade
+func (s *MemoryStore) Refund(_ context.Context, key string, rate Rate, _ time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if b, ok := s.buckets[key]; ok {
+		b.tokens = math.Min(float64(rate.Limit), b.tokens+1)
+	}
+	return nil
+}
+
+// Consume incrementa o contador do dia da chave
+func (s *MemoryStore) Consume(_ context.Context, key string, quota int, now time.Time) (Result, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.sweep(now)
+
+	_, end := dayWindow(now)
+	c, ok := s.counters[key]
+	if !ok || !c.end.Equal(end) {
+		c = &counter{end: end}
+		s.counters[key] = c
+	}
+
+	res := Result{Limit: quota, Reset: end.Sub(now)}
+	if c.count < quota {
+		c.count++
+		res.Allowed = true
+	} else {
+		res.RetryAfter = res.Reset
+	}
+	res.Remaining = quota - c.count
+	return res, nil
+}
+
+// sweep remove os buckets já cheios e os contadores de dias anteriores. Deve ser chamado com o lock.
+func (s *MemoryStore) sweep(now time.Time) {
+	if now.Sub(s.lastSweep) < sweepInterval {
+		return
+	}
+	s.lastSweep = now
+	for key, b := range s.buckets {
+		if now.Sub(b.updated) >= b.period {
+			delete(s.buckets, key)
+		}
+	}
+	for key, c := range s.counters {
+		if !now.Before(c.end) {
+			delete(s.counters, key)
+		}
+	}
+}
+
+// seconds converte segundos fracionados em time.Duration
+func seconds(s float64) time.Duration {
+	return time.Duration(s * float64(time.Second))
+}
@@ -0,0 +1,138 @@
+package ratelimit_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/valdinei-santos/cpf-backend/internal/infra/ratelimit"
+)
+
+var start = time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+
+func TestMemoryStoreTake(t *testing.T) {
+	rate := ratelimit.Rate{Limit: 10, Period: 10 * time.Second} // 1 token por segundo
+
+	tests := []struct {
+		name          string
+		takes         []time.Duration // Momento de cada requisição, a partir de start
+		wantAllowed   bool            // Resultado da última requisição
+		wantRemaining int
+		wantRetry     time.Duration
+	}{
+		{
+			name:          "Deve aceitar a rajada até o limite",
+			takes:         repeat(0, 10),
+			wantAllowed:   true,
+			wantRemaining: 0,
+		},
+		{
+			name:        "Deve recusar a requisição depois da rajada, com o tempo até o próximo token",
+			takes:       repeat(0, 11),
+			wantAllowed: false,
+			wantRetry:   time.Second,
+		},
+		{
+			name:          "Deve reabastecer os tokens proporcionalmente ao tempo",
+			takes:         append(repeat(0, 10), 3*time.Second),
+			wantAllowed:   true,
+			wantRemaining: 2, // 3 tokens reabastecidos, 1 consumido
+		},
+		{
+			name:          "Deve limitar o reabastecimento à capacidade do bucket",
+			takes:         append(repeat(0, 10), time.Hour),
+			wantAllowed:   true,
+			wantRemaining: 9,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := ratelimit.NewMemoryStore()
+			var res ratelimit.Result
+			for _, at := range tt.takes {
+				var err error
+				res, err = store.Take(context.Background(), "ip:10.0.0.1|read", rate, start.Add(at))
+				require.NoError(t, err)
+			}
+			assert.Equal(t, tt.wantAllowed, res.Allowed)
+			assert.Equal(t, rate.Limit, res.Limit)
+			assert.Equal(t, tt.wantRemaining, res.Remaining)
+			assert.InDelta(t, tt.wantRetry, res.RetryAfter, float64(time.Millisecond))
+		})
+	}
+}
+
+func TestMemoryStoreTakeSeparaAsChaves(t *testing.T) {
+	store := ratelimit.NewMemoryStore()
+	rate := ratelimit.Rate{Limit: 1, Period: time.Minute}
+
+	first, _ := store.Take(context.Background(), "user:a|read", rate, start)
+	second, _ := store.Take(context.Background(), "user:a|read", rate, start)
+	other, _ := store.Take(context.Background(), "user:b|read", rate, start)
+
+	assert.True(t, first.Allowed)
+	assert.False(t, second.Allowed)
+	assert.True(t, other.Allowed)
+}
+
+func TestMemoryStoreConsume(t *testing.T) {
+	tests := []struct {
+		name          string
+		consumes      []time.Time
+		wantAllowed   bool
+		wantRemaining int
+		wantReset     time.Duration
+	}{
+		{
+			name:          "Deve aceitar até a cota do dia",
+			consumes:      []time.Time{start, start, start},
+			wantAllowed:   true,
+			wantRemaining: 0,
+			wantReset:     12 * time.Hour,
+		},
+		{
+			name:          "Deve recusar depois da cota, até o fim do dia UTC",
+			consumes:      []time.Time{start, start, start, start.Add(11 * time.Hour)},
+			wantAllowed:   false,
+			wantRemaining: 0,
+			wantReset:     time.Hour,
+		},
+		{
+			name:          "Deve reiniciar a cota no dia seguinte",
+			consumes:      []time.Time{start, start, start, start.Add(12 * time.Hour)},
+			wantAllowed:   true,
+			wantRemaining: 2,
+			wantReset:     24 * time.Hour,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := ratelimit.NewMemoryStore()
+			var res ratelimit.Result
+			for _, now := range tt.consumes {
+				var err error
+				res, err = store.Consume(context.Background(), "apikey:1|quota", 3, now)
+				require.NoError(t, err)
+			}
+			assert.Equal(t, tt.wantAllowed, res.Allowed)
+			assert.Equal(t, tt.wantRemaining, res.Remaining)
+			assert.Equal(t, tt.wantReset, res.Reset)
+			if !tt.wantAllowed {
+				assert.Equal(t, res.Reset, res.RetryAfter)
+			}
+		})
+	}
+}
+
+// repeat retorna n requisições no mesmo momento
+func repeat(at time.Duration, n int) []time.Duration {
+	list := make([]time.Duration, n)
+	for i := range list {
+		list[i] = at
+	}
+	return list
+}
@@ -0,0 +1,71 @@
+package ratelimit
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/valdinei-santos/cpf-backend/internal/infra/identity"
+	"github.com/valdinei-santos/cpf-backend/internal/infra/logger"
+	"github.com/valdinei-santos/cpf-backend/internal/infra/problem"
+)
+
+// Middleware aplica os limites do Limiter ao dono da requisição retornado por principal (ver
+// Principal e IPPrincipal) e informa o consumo nos headers RateLimit-*. Excedido o limite responde
+// 429 com o Retry-After. Falha no Store não bloqueia a requisição.
+func Middleware(l *Limiter, principalOf func(c *gin.Context) string, log logger.ILogger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.Request.Method == http.MethodOptions {
+			c.Next()
+			return
+		}
+
+		write := c.Request.Method != http.MethodGet && c.Request.Method != http.MethodHead
+		principal := principalOf(c)
+		d, err := l.Check(c.Request.Context(), principal, write)
+		if err != nil {
+			log.WarnContext(c.Request.Context(), "Erro ao verificar o rate limit, requisição liberada", "err", err.Error(), "principal", principal)
+			c.Next()
+			return
+		}
+
+		if d.Policy != "" {
+			c.Header("RateLimit-Limit", strconv.Itoa(d.Limit))
+			c.Header("RateLimit-Remaining", strconv.Itoa(d.Remaining))
+			c.Header("RateLimit-Reset", ceilSeconds(d.Reset))
+			c.Header("RateLimit-Policy", d.Policy)
+		}
+		if !d.Allowed {
+			log.WarnContext(c.Request.Context(), d.Reason.Error(), "principal", principal, "path", c.Request.URL.Path)
+			c.Header("Retry-After", ceilSeconds(d.RetryAfter))
+			problem.Abort(c, d.Reason)
+			return
+		}
+		c.Next()
+	}
+}
+
+// Principal identifica o dono dos limites da requisição: a chave de API, o usuário autenticado ou
+// o IP, nessa ordem. Deve ser usado depois dos middlewares de autenticação.
+func Principal(c *gin.Context) string {
+	id := identity.FromContext(c.Request.Context())
+	if keyID, ok := id.Claims["api_key_id"].(string); ok && id.Method == "api_key" {
+		return "apikey:" + keyID
+	}
+	if !id.IsAnonymous() {
+		return "user:" + id.Subject
+	}
+	return IPPrincipal(c)
+}
+
+// IPPrincipal identifica a requisição apenas pelo IP, para o limite aplicado antes da autenticação
+func IPPrincipal(c *gin.Context) string {
+	return "ip:" + c.ClientIP()
+}
+
+// ceilSeconds arredonda para cima, em segundos inteiros, como esperado pelos headers
+func ceilSeconds(d time.Duration) string {
+	return strconv.Itoa(int(math.Ceil(d.Seconds())))
+}
@@ -0,0 +1,134 @@
+package ratelimit_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/valdinei-santos/cpf-backend/internal/infra/identity"
+	"github.com/valdinei-santos/cpf-backend/internal/infra/logger"
+	"github.com/valdinei-santos/cpf-backend/internal/infra/ratelimit"
+)
+
+// failingStore simula o Store indisponível
+type failingStore struct{}
+
+func (failingStore) Take(context.Context, string, ratelimit.Rate, time.Time) (ratelimit.Result, error) {
+	return ratelimit.Result{}, errors.New("store indisponível")
+}
+
+func (failingStore) Refund(context.Context, string, ratelimit.Rate, time.Time) error {
+	return errors.New("store indisponível")
+}
+
+func (failingStore) Consume(context.Context, string, int, time.Time) (ratelimit.Result, error) {
+	return ratelimit.Result{}, errors.New("store indisponível")
+}
+
+// newRouter monta a rota GET /ping com o middleware e uma identidade opcional no contexto
+func newRouter(mw gin.HandlerFunc, id *identity.Identity) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(func(c *gin.Context) {
+		if id != nil {
+			c.Request = c.Request.WithContext(identity.NewContext(c.Request.Context(), id))
+		}
+		c.Next()
+	})
+	r.Use(mw)
+	r.GET("/ping", func(c *gin.Context) { c.Status(http.StatusNoContent) })
+	return r
+}
+
+func get(r *gin.Engine) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/ping", nil)
+	req.RemoteAddr = "10.0.0.1:1234"
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestMiddleware(t *testing.T) {
+	rate := ratelimit.Rate{Limit: 1, Period: time.Minute}
+
+	t.Run("Deve liberar a requisição quando o Store falha", func(t *testing.T) {
+		log := logger.NewMockILogger()
+		r := newRouter(ratelimit.Middleware(ratelimit.NewIPLimiter(failingStore{}, rate), ratelimit.IPPrincipal, log), nil)
+
+		w := get(r)
+
+		assert.Equal(t, http.StatusNoContent, w.Code)
+		assert.Empty(t, w.Header().Get("RateLimit-Limit"))
+	})
+
+	t.Run("Deve informar o consumo nos headers RateLimit", func(t *testing.T) {
+		r := newRouter(ratelimit.Middleware(ratelimit.NewIPLimiter(ratelimit.NewMemoryStore(), rate), ratelimit.IPPrincipal, logger.NewMockILogger()), nil)
+
+		w := get(r)
+
+		assert.Equal(t, http.StatusNoContent, w.Code)
+		assert.Equal(t, "1", w.Header().Get("RateLimit-Limit"))
+		assert.Equal(t, "0", w.Header().Get("RateLimit-Remaining"))
+		assert.Equal(t, "1;w=60", w.Header().Get("RateLimit-Policy"))
+	})
+
+	t.Run("Deve responder 429 com Retry-After acima do limite", func(t *testing.T) {
+		r := newRouter(ratelimit.Middleware(ratelimit.NewIPLimiter(ratelimit.NewMemoryStore(), rate), ratelimit.IPPrincipal, logger.NewMockILogger()), nil)
+
+		get(r)
+		w := get(r)
+
+		assert.Equal(t, http.StatusTooManyRequests, w.Code)
+		assert.Equal(t, "60", w.Header().Get("Retry-After"))
+		assert.Contains(t, w.Header().Get("Content-Type"), "application/problem+json")
+	})
+}
+
+func TestPrincipal(t *testing.T) {
+	tests := []struct {
+		name string
+		id   *identity.Identity
+		want string
+	}{
+		{
+			name: "Deve usar a chave de API",
+			id:   &identity.Identity{Subject: "svc", Method: "api_key", Claims: map[string]any{"api_key_id": "k1"}},
+			want: "apikey:k1",
+		},
+		{
+			name: "Deve usar o usuário quando a claim api_key_id vem de outro método",
+			id:   &identity.Identity{Subject: "maria", Method: "jwt", Claims: map[string]any{"api_key_id": "k1"}},
+			want: "user:maria",
+		},
+		{
+			name: "Deve usar o usuário autenticado",
+			id:   &identity.Identity{Subject: "maria", Method: "jwt"},
+			want: "user:maria",
+		},
+		{
+			name: "Deve usar o IP sem identidade",
+			want: "ip:10.0.0.1",
+		},
+		{
+			name: "Deve usar o IP para o anônimo",
+			id:   identity.Anonymous,
+			want: "ip:10.0.0.1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got string
+			r := newRouter(func(c *gin.Context) { got = ratelimit.Principal(c) }, tt.id)
+
+			get(r)
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
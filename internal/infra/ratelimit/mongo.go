@@ -0,0 +1,83 @@
+package ratelimit
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// MongoStore guarda os contadores em uma collection compartilhada entre as instâncias da API.
+// Cada limite é uma janela fixa (um documento por chave e janela, removido pelo índice TTL), uma
+// aproximação do token bucket do MemoryStore que admite até o dobro do limite na virada da janela.
+type MongoStore struct {
+	collection *mongo.Collection
+}
+
+// NewMongoStore - cria o MongoStore sobre a collection informada
+func NewMongoStore(db *mongo.Database, collectionName string) *MongoStore {
+	return &MongoStore{collection: db.Collection(collectionName)}
+}
+
+// EnsureIndexes - cria o índice TTL que remove as janelas encerradas
+func (s *MongoStore) EnsureIndexes(ctx context.Context) error {
+	_, err := s.collection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "expires_at", Value: 1}},
+		Options: options.Index().SetName("ttl_expires_at").SetExpireAfterSeconds(0),
+	})
+	return err
+}
+
+// Take incrementa o contador da janela de rate.Period atual da chave
+func (s *MongoStore) Take(ctx context.Context, key string, rate Rate, now time.Time) (Result, error) {
+	start := now.Truncate(rate.Period)
+	return s.increment(ctx, key, rate.Limit, start, start.Add(rate.Period), now)
+}
+
+// Refund decrementa o contador da janela de rate.Period em que o Take foi feito
+func (s *MongoStore) Refund(ctx context.Context, key string, rate Rate, now time.Time) error {
+	id := key + "|" + strconv.FormatInt(now.Truncate(rate.Period).Unix(), 10)
+	_, err := s.collection.UpdateOne(ctx, bson.M{"_id": id, "count": bson.M{"$gt": 0}}, bson.M{"$inc": bson.M{"count": -1}})
+	return err
+}
+
+// Consume incrementa o contador do dia da chave
+func (s *MongoStore) Consume(ctx context.Context, key string, quota int, now time.Time) (Result, error) {
+	start, end := dayWindow(now)
+	return s.increment(ctx, key, quota, start, end, now)
+}
+
+func (s *MongoStore) increment(ctx context.Context, key string, limit int, start, end, now time.Time) (Result, error) {
+	id := key + "|" + strconv.FormatInt(start.Unix(), 10)
+	opts := options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After)
+	update := bson.M{
+		"$inc":         bson.M{"count": 1},
+		"$setOnInsert": bson.M{"expires_at": end},
+	}
+
+	var doc struct {
+		Count int `bson:"count"`
+	}
+	err := s.collection.FindOneAndUpdate(ctx, bson.M{"_id": id}, update, opts).Decode(&doc)
+	if mongo.IsDuplicateKeyError(err) {
+		// Duas instâncias criaram a janela ao mesmo tempo, a segunda tentativa só incrementa
+		err = s.collection.FindOneAndUpdate(ctx, bson.M{"_id": id}, update, opts).Decode(&doc)
+	}
+	if err != nil {
+		return Result{}, err
+	}
+
+	res := Result{
+		Allowed:   doc.Count <= limit,
+		Limit:     limit,
+		Remaining: max(0, limit-doc.Count),
+		Reset:     end.Sub(now),
+	}
+	if !res.Allowed {
+		res.RetryAfter = res.Reset
+	}
+	return res, nil
+}
@@ -0,0 +1,94 @@
+// Pacote com a limitação de requisições (rate limit) e a cota diária por chave de API, usuário ou IP.
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Formas de armazenar os contadores
+const (
+	StoreMemory = "memory" // Na memória da instância (padrão)
+	StoreMongo  = "mongo"  // Na collection ratelimit, compartilhado entre as instâncias
+)
+
+var ErrQuotaExceeded = errors.New("cota diária de requisições excedida")
+
+// Rate é o limite de Limit requisições a cada Period. Limit zero desabilita o limite.
+type Rate struct {
+	Limit  int
+	Period time.Duration
+}
+
+// ParseRate converte o formato <requisições>/<período>. Ex: 300/1m, 20/1s. Vazio ou "0" desabilita.
+func ParseRate(value string) (Rate, error) {
+	value = strings.TrimSpace(value)
+	if value == "" || value == "0" {
+		return Rate{}, nil
+	}
+	limit, period, ok := strings.Cut(value, "/")
+	if !ok {
+		return Rate{}, fmt.Errorf("limite %q fora do formato <requisições>/<período>", value)
+	}
+	n, err := strconv.Atoi(limit)
+	if err != nil || n < 0 {
+		return Rate{}, fmt.Errorf("quantidade de requisições inválida em %q", value)
+	}
+	d, err := time.ParseDuration(period)
+	if err != nil || d <= 0 {
+		return Rate{}, fmt.Errorf("período inválido em %q", value)
+	}
+	return Rate{Limit: n, Period: d}, nil
+}
+
+// UnmarshalText permite carregar o limite no formato de ParseRate do arquivo de configuração
+func (r *Rate) UnmarshalText(text []byte) error {
+	rate, err := ParseRate(string(text))
+	if err != nil {
+		return err
+	}
+	*r = rate
+	return nil
+}
+
+// String retorna o limite no formato de ParseRate
+func (r Rate) String() string {
+	if !r.Enabled() {
+		return "0"
+	}
+	return fmt.Sprintf("%d/%s", r.Limit, r.Period)
+}
+
+// Enabled indica se o limite está ativo
+func (r Rate) Enabled() bool {
+	return r.Limit > 0 && r.Period > 0
+}
+
+// Result é o resultado da verificação de um limite
+type Result struct {
+	Allowed    bool
+	Limit      int
+	Remaining  int
+	Reset      time.Duration // Tempo até o limite ser totalmente restabelecido
+	RetryAfter time.Duration // Quando negado, tempo até a próxima requisição ser aceita
+}
+
+// Store guarda os contadores dos limites
+type Store interface {
+	// Take consome uma requisição do limite rate da chave
+	Take(ctx context.Context, key string, rate Rate, now time.Time) (Result, error)
+	// Refund devolve a requisição consumida pelo Take em now, quando ela é negada por outro limite
+	Refund(ctx context.Context, key string, rate Rate, now time.Time) error
+	// Consume consome uma requisição da cota diária (dia UTC) da chave
+	Consume(ctx context.Context, key string, quota int, now time.Time) (Result, error)
+}
+
+// dayWindow retorna o início e o fim do dia UTC de now, janela da cota diária
+func dayWindow(now time.Time) (time.Time, time.Time) {
+	start := now.UTC().Truncate(24 * time.Hour)
+	return start, start.Add(24 * time.Hour)
+}
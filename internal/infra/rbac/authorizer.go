@@ -0,0 +1,114 @@
+package rbac
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/valdinei-santos/cpf-backend/internal/domain/globalerr"
+	"github.com/valdinei-santos/cpf-backend/internal/infra/auth"
+	"github.com/valdinei-santos/cpf-backend/internal/infra/identity"
+	"github.com/valdinei-santos/cpf-backend/internal/infra/logger"
+	"github.com/valdinei-santos/cpf-backend/internal/infra/problem"
+)
+
+// IAuthorizer verifica as permissões do chamador. Usado pelos casos de uso que precisam de uma
+// verificação além da feita na rota. Ex: só supervisor pode alterar o bloqueio no update.
+type IAuthorizer interface {
+	Check(ctx context.Context, perm Permission) error
+}
+
+// Authorizer aplica a Policy à identidade da requisição. A permissão é concedida por um papel
+// do chamador (token JWT) ou por um escopo com o mesmo nome da permissão (chave de API).
+type Authorizer struct {
+	policy         *Policy
+	allowAnonymous bool
+	log            logger.ILogger
+}
+
+// RoleAdmin é o papel exigido, além da permissão, nas rotas de administração (chaves de API e
+// nível do log)
+const RoleAdmin = "admin"
+
+// NewAuthorizer - cria o autorizador. As requisições sem credencial são recusadas, a não ser com
+// allowAnonymous (AUTH_ALLOW_ANONYMOUS, só no perfil dev), que concede a elas todas as permissões.
+func NewAuthorizer(policy *Policy, allowAnonymous bool, log logger.ILogger) *Authorizer {
+	return &Authorizer{policy: policy, allowAnonymous: allowAnonymous, log: log}
+}
+
+// Can indica se a identidade possui a permissão.
+func (a *Authorizer) Can(id *identity.Identity, perm Permission) bool {
+	if id.IsAnonymous() {
+		return a.allowAnonymous
+	}
+	for _, role := range id.Roles {
+		if a.policy.Allows(role, perm) {
+			return true
+		}
+	}
+	return id.HasScope(string(perm))
+}
+
+// Check retorna globalerr.ErrUnauthorized se a requisição não foi autenticada e
+// globalerr.ErrForbidden se o chamador não possui a permissão.
+func (a *Authorizer) Check(ctx context.Context, perm Permission) error {
+	id := identity.FromContext(ctx)
+	if a.Can(id, perm) {
+		return nil
+	}
+	if id.IsAnonymous() {
+		return globalerr.ErrUnauthorized
+	}
+	a.log.WarnContext(ctx, "Acesso negado", "subject", id.Subject, "roles", strings.Join(id.Roles, ","), "permission", string(perm))
+	return globalerr.ErrForbidden
+}
+
+// Require é o middleware que protege a rota com a permissão. Responde 401 sem credencial e 403 se
+// faltar a permissão.
+func (a *Authorizer) Require(perm Permission) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.Request.Method == http.MethodOptions {
+			c.Next()
+			return
+		}
+		if err := a.Check(c.Request.Context(), perm); err != nil {
+			abort(c, err)
+			return
+		}
+		c.Next()
+	}
+}
+
+// RequireRole libera a rota apenas para identidades autenticadas com o papel, mesmo com
+// allowAnonymous. Responde 401 sem credencial e 403 se faltar o papel. Chaves de API não têm papéis.
+func (a *Authorizer) RequireRole(role string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.Request.Method == http.MethodOptions {
+			c.Next()
+			return
+		}
+		ctx := c.Request.Context()
+		id := identity.FromContext(ctx)
+		if id.IsAnonymous() {
+			abort(c, globalerr.ErrUnauthorized)
+			return
+		}
+		if !id.HasRole(role) {
+			a.log.WarnContext(ctx, "Acesso negado", "subject", id.Subject, "roles", strings.Join(id.Roles, ","), "role", role)
+			abort(c, globalerr.ErrForbidden)
+			return
+		}
+		c.Next()
+	}
+}
+
+// abort responde 401 (com o header WWW-Authenticate) ou 403
+func abort(c *gin.Context, err error) {
+	if errors.Is(err, globalerr.ErrUnauthorized) {
+		auth.Unauthorized(c, "Bearer", "")
+		return
+	}
+	problem.Abort(c, err)
+}
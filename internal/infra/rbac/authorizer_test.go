@@ -0,0 +1,154 @@
+package rbac_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/valdinei-santos/cpf-backend/internal/domain/globalerr"
+	"github.com/valdinei-santos/cpf-backend/internal/infra/identity"
+	"github.com/valdinei-santos/cpf-backend/internal/infra/logger"
+	"github.com/valdinei-santos/cpf-backend/internal/infra/rbac"
+)
+
+var (
+	reader   = &identity.Identity{Subject: "ana", Roles: []string{"reader"}, Method: "jwt"}
+	operator = &identity.Identity{Subject: "joao", Roles: []string{"reader", "operator"}, Method: "jwt"}
+	admin    = &identity.Identity{Subject: "root", Roles: []string{"admin"}, Method: "jwt"}
+	apiKey   = &identity.Identity{Subject: "svc", Scopes: []string{"cliente:read", "apikey:manage"}, Method: "api_key"}
+)
+
+func TestAuthorizerCan(t *testing.T) {
+	tests := []struct {
+		name           string
+		id             *identity.Identity
+		perm           rbac.Permission
+		allowAnonymous bool
+		expected       bool
+	}{
+		{name: "Deve liberar a permissão concedida pelo papel", id: reader, perm: "cliente:read", expected: true},
+		{name: "Deve negar a permissão que nenhum papel concede", id: reader, perm: "cliente:write", expected: false},
+		{name: "Deve somar as permissões de todos os papéis", id: operator, perm: "cliente:write", expected: true},
+		{name: "Deve liberar qualquer permissão com o curinga", id: admin, perm: "cliente:delete", expected: true},
+		{name: "Deve liberar a permissão concedida pelo escopo da chave de API", id: apiKey, perm: "cliente:read", expected: true},
+		{name: "Deve negar a permissão fora dos escopos da chave de API", id: apiKey, perm: "cliente:delete", expected: false},
+		{name: "Deve negar a requisição sem credencial", id: identity.Anonymous, perm: "cliente:read", expected: false},
+		{name: "Deve liberar a requisição sem credencial com allowAnonymous", id: identity.Anonymous, perm: "cliente:delete", allowAnonymous: true, expected: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			authz := rbac.NewAuthorizer(rbac.DefaultPolicy(), tt.allowAnonymous, logger.NewMockILogger())
+
+			assert.Equal(t, tt.expected, authz.Can(tt.id, tt.perm))
+		})
+	}
+}
+
+func TestAuthorizerCheck(t *testing.T) {
+	tests := []struct {
+		name        string
+		id          *identity.Identity
+		perm        rbac.Permission
+		expectedErr error
+		expectWarn  bool
+	}{
+		{name: "Deve liberar quem tem a permissão", id: reader, perm: "cliente:read"},
+		{name: "Deve retornar ErrForbidden e registrar quem não tem a permissão", id: reader, perm: "cliente:write", expectedErr: globalerr.ErrForbidden, expectWarn: true},
+		{name: "Deve retornar ErrUnauthorized sem credencial", id: nil, perm: "cliente:read", expectedErr: globalerr.ErrUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			log := logger.NewMockILogger()
+			authz := rbac.NewAuthorizer(rbac.DefaultPolicy(), false, log)
+			ctx := context.Background()
+			if tt.id != nil {
+				ctx = identity.NewContext(ctx, tt.id)
+			}
+
+			err := authz.Check(ctx, tt.perm)
+
+			assert.ErrorIs(t, err, tt.expectedErr)
+			// Só o acesso negado a quem está autenticado fica registrado
+			assert.Equal(t, tt.expectWarn, len(log.GetLogs("default")) > 0)
+		})
+	}
+}
+
+// serve executa a requisição na rota protegida pelo middleware, com a identidade no contexto
+func serve(mw gin.HandlerFunc, method string, id *identity.Identity) *httptest.ResponseRecorder {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(func(c *gin.Context) {
+		if id != nil {
+			c.Request = c.Request.WithContext(identity.NewContext(c.Request.Context(), id))
+		}
+		c.Next()
+	})
+	r.Handle(method, "/recurso", mw, func(c *gin.Context) { c.Status(http.StatusNoContent) })
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(method, "/recurso", nil))
+	return w
+}
+
+func TestAuthorizerRequire(t *testing.T) {
+	tests := []struct {
+		name           string
+		id             *identity.Identity
+		method         string
+		allowAnonymous bool
+		expectedStatus int
+	}{
+		{name: "Deve liberar quem tem a permissão", id: reader, method: http.MethodGet, expectedStatus: http.StatusNoContent},
+		{name: "Deve responder 403 sem a permissão", id: apiKey, method: http.MethodGet, expectedStatus: http.StatusForbidden},
+		{name: "Deve responder 401 sem credencial", method: http.MethodGet, expectedStatus: http.StatusUnauthorized},
+		{name: "Deve liberar sem credencial com allowAnonymous", method: http.MethodGet, allowAnonymous: true, expectedStatus: http.StatusNoContent},
+		{name: "Deve liberar o preflight do CORS sem credencial", method: http.MethodOptions, expectedStatus: http.StatusNoContent},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			authz := rbac.NewAuthorizer(rbac.DefaultPolicy(), tt.allowAnonymous, logger.NewMockILogger())
+
+			w := serve(authz.Require("consentimento:read"), tt.method, tt.id)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedStatus == http.StatusUnauthorized {
+				assert.Contains(t, w.Header().Get("WWW-Authenticate"), "Bearer")
+			}
+			if tt.expectedStatus >= http.StatusBadRequest {
+				assert.Contains(t, w.Header().Get("Content-Type"), "application/problem+json")
+			}
+		})
+	}
+}
+
+func TestAuthorizerRequireRole(t *testing.T) {
+	tests := []struct {
+		name           string
+		id             *identity.Identity
+		allowAnonymous bool
+		expectedStatus int
+	}{
+		{name: "Deve liberar quem tem o papel", id: admin, expectedStatus: http.StatusNoContent},
+		{name: "Deve responder 403 sem o papel", id: operator, expectedStatus: http.StatusForbidden},
+		{name: "Deve responder 403 para a chave de API, mesmo com o escopo", id: apiKey, expectedStatus: http.StatusForbidden},
+		{name: "Deve responder 401 sem credencial", expectedStatus: http.StatusUnauthorized},
+		{name: "Deve responder 401 sem credencial mesmo com allowAnonymous", allowAnonymous: true, expectedStatus: http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			authz := rbac.NewAuthorizer(rbac.DefaultPolicy(), tt.allowAnonymous, logger.NewMockILogger())
+
+			w := serve(authz.RequireRole(rbac.RoleAdmin), http.MethodPost, tt.id)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+		})
+	}
+}
@@ -0,0 +1,68 @@
+// Pacote com a autorização por papéis (RBAC). Cada rota e caso de uso declara a permissão que exige
+// e o Authorizer verifica se algum papel (ou escopo) do chamador concede essa permissão.
+package rbac
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// Permission é uma ação protegida da API. Ex: cliente:read, cliente:delete
+type Permission string
+
+// Wildcard concede todas as permissões ao papel
+const Wildcard Permission = "*"
+
+// Policy é o mapeamento de papel para permissões, lido do arquivo RBAC_FILE.
+//
+//	{"roles": {"reader": ["cliente:read"], "admin": ["*"]}}
+type Policy struct {
+	Roles map[string][]Permission `json:"roles"`
+}
+
+// DefaultPolicy é a política usada quando RBAC_FILE não está configurado. Cada papel inclui as
+// permissões do papel anterior: reader < operator < supervisor < admin. O supervisor também vê os
+// dados pessoais sem máscara (pii:unmask). O auditor só consulta o log de acesso aos dados dos
+// clientes e verifica a integridade da auditoria.
+func DefaultPolicy() *Policy {
+	reader := []Permission{"cliente:read", "consentimento:read"}
+	operator := append(append([]Permission{}, reader...), "cliente:write", "consentimento:write")
+	supervisor := append(append([]Permission{}, operator...), "cliente:block", "pii:unmask")
+	return &Policy{Roles: map[string][]Permission{
+		"reader":     reader,
+		"operator":   operator,
+		"supervisor": supervisor,
+		"admin":      {Wildcard},
+		"auditor":    {"acesso:read", "auditoria:verify"},
+	}}
+}
+
+// LoadPolicy - lê a política do arquivo JSON. Com o path vazio retorna a DefaultPolicy.
+func LoadPolicy(path string) (*Policy, error) {
+	if path == "" {
+		return DefaultPolicy(), nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var p Policy
+	if err := json.Unmarshal(data, &p); err != nil {
+		return nil, fmt.Errorf("arquivo de papéis inválido: %w", err)
+	}
+	if len(p.Roles) == 0 {
+		return nil, fmt.Errorf("arquivo de papéis sem papéis configurados")
+	}
+	return &p, nil
+}
+
+// Allows indica se o papel concede a permissão.
+func (p *Policy) Allows(role string, perm Permission) bool {
+	for _, granted := range p.Roles[role] {
+		if granted == perm || granted == Wildcard {
+			return true
+		}
+	}
+	return false
+}
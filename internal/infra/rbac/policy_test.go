@@ -0,0 +1,100 @@
+package rbac_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/valdinei-santos/cpf-backend/internal/infra/rbac"
+)
+
+func TestDefaultPolicyAllows(t *testing.T) {
+	policy := rbac.DefaultPolicy()
+
+	tests := []struct {
+		name     string
+		role     string
+		perm     rbac.Permission
+		expected bool
+	}{
+		{name: "Deve liberar a leitura para o reader", role: "reader", perm: "cliente:read", expected: true},
+		{name: "Deve negar a escrita para o reader", role: "reader", perm: "cliente:write", expected: false},
+		{name: "Deve herdar as permissões do reader no operator", role: "operator", perm: "consentimento:read", expected: true},
+		{name: "Deve liberar a escrita para o operator", role: "operator", perm: "cliente:write", expected: true},
+		{name: "Deve negar o bloqueio para o operator", role: "operator", perm: "cliente:block", expected: false},
+		{name: "Deve liberar o unmask para o supervisor", role: "supervisor", perm: "pii:unmask", expected: true},
+		{name: "Deve negar a exclusão para o supervisor", role: "supervisor", perm: "cliente:delete", expected: false},
+		{name: "Deve liberar qualquer permissão para o admin", role: "admin", perm: "apikey:manage", expected: true},
+		{name: "Deve liberar a verificação da auditoria para o auditor", role: "auditor", perm: "auditoria:verify", expected: true},
+		{name: "Deve negar os dados dos clientes para o auditor", role: "auditor", perm: "cliente:read", expected: false},
+		{name: "Deve negar tudo para um papel desconhecido", role: "visitante", perm: "cliente:read", expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, policy.Allows(tt.role, tt.perm))
+		})
+	}
+}
+
+func TestLoadPolicy(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+		return path
+	}
+
+	tests := []struct {
+		name        string
+		path        string
+		expectErr   bool
+		expectRoles []string
+	}{
+		{
+			name:        "Deve usar a política padrão sem arquivo",
+			path:        "",
+			expectRoles: []string{"admin", "auditor", "operator", "reader", "supervisor"},
+		},
+		{
+			name:        "Deve ler os papéis do arquivo",
+			path:        write("rbac.json", `{"roles": {"leitor": ["cliente:read"], "admin": ["*"]}}`),
+			expectRoles: []string{"admin", "leitor"},
+		},
+		{
+			name:      "Deve retornar erro com o JSON inválido",
+			path:      write("invalido.json", `{"roles": [`),
+			expectErr: true,
+		},
+		{
+			name:      "Deve retornar erro sem papéis configurados",
+			path:      write("vazio.json", `{"roles": {}}`),
+			expectErr: true,
+		},
+		{
+			name:      "Deve retornar erro com o arquivo inexistente",
+			path:      filepath.Join(dir, "inexistente.json"),
+			expectErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			policy, err := rbac.LoadPolicy(tt.path)
+
+			if tt.expectErr {
+				assert.Error(t, err)
+				assert.Nil(t, policy)
+				return
+			}
+			require.NoError(t, err)
+			roles := make([]string, 0, len(policy.Roles))
+			for role := range policy.Roles {
+				roles = append(roles, role)
+			}
+			assert.ElementsMatch(t, tt.expectRoles, roles)
+		})
+	}
+}
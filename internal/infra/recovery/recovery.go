@@ -0,0 +1,67 @@
+// Pacote com a recuperação dos pânicos dos handlers, para que a API responda no formato padrão de
+// erro e registre a causa no log.
+package recovery
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"runtime/debug"
+	"syscall"
+
+	"github.com/gin-gonic/gin"
+	"github.com/valdinei-santos/cpf-backend/internal/domain/globalerr"
+	"github.com/valdinei-santos/cpf-backend/internal/infra/logger"
+	"github.com/valdinei-santos/cpf-backend/internal/infra/problem"
+	"github.com/valdinei-santos/cpf-backend/internal/infra/tracing"
+)
+
+// Middleware recupera o pânico do handler, registra no log o valor e o stack com o id da requisição
+// e responde 500 no formato padrão de erro. Deve ficar depois do log de acesso e das métricas,
+// para que a requisição apareça neles com o status 500.
+func Middleware(log logger.ILogger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		defer func() {
+			rec := recover()
+			if rec == nil {
+				return
+			}
+			// http.ErrAbortHandler interrompe a resposta de propósito e é tratado pelo servidor
+			if rec == http.ErrAbortHandler {
+				panic(rec)
+			}
+
+			ctx := c.Request.Context()
+			err := fmt.Errorf("panic: %v", rec)
+			tracing.RecordError(ctx, err)
+			if brokenConnection(rec) {
+				// O cliente fechou a conexão, não há a quem responder
+				log.WarnContext(ctx, "Conexão encerrada pelo cliente durante a resposta", "err", err.Error())
+				c.Abort()
+				return
+			}
+			log.ErrorContext(ctx, "Pânico ao atender a requisição", "panic", fmt.Sprint(rec),
+				"method", c.Request.Method, "path", c.Request.URL.Path, "stack", string(debug.Stack()))
+			if c.Writer.Written() {
+				c.Abort()
+				return
+			}
+			problem.Abort(c, globalerr.ErrInternal)
+		}()
+		c.Next()
+	}
+}
+
+// brokenConnection indica se o pânico veio da escrita numa conexão já fechada pelo cliente
+func brokenConnection(rec any) bool {
+	err, ok := rec.(error)
+	if !ok {
+		return false
+	}
+	var opErr *net.OpError
+	var sysErr *os.SyscallError
+	return errors.As(err, &opErr) && errors.As(opErr.Err, &sysErr) &&
+		(errors.Is(sysErr.Err, syscall.EPIPE) || errors.Is(sysErr.Err, syscall.ECONNRESET))
+}
@@ -0,0 +1,120 @@
+// Pacote com os dados da requisição HTTP usados fora dos controllers, guardados no contexto.
+package requestinfo
+
+import (
+	"context"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// PurposeHeader é o header com a finalidade do acesso aos dados pessoais, registrada no log de acesso
+const PurposeHeader = "X-Access-Purpose"
+
+// RequestIDHeader é o header com o id da requisição, recebido do chamador ou gerado pela API e
+// devolvido na resposta
+const RequestIDHeader = "X-Request-ID"
+
+// Tamanho máximo da finalidade gravada, o restante é descartado
+const maxPurpose = 200
+
+// Tamanho máximo do X-Request-ID aceito do chamador
+const maxRequestID = 128
+
+// Info são os dados da requisição
+type Info struct {
+	RequestID  string // X-Request-ID recebido ou gerado
+	IP         string // IP do chamador
+	Finalidade string // Finalidade informada no header X-Access-Purpose
+	UserID     string // Subject da credencial, preenchido pela autenticação
+	TenantID   string // Tenant da requisição, preenchido pelo middleware do tenant
+	TraceID    string // Trace da requisição, preenchido pelo middleware do tracing
+}
+
+// holder guarda os dados no contexto. Os middlewares seguintes completam os dados sem trocar o
+// contexto, para que os logs de toda a requisição tenham os mesmos campos
+type holder struct {
+	mu   sync.RWMutex
+	info Info
+}
+
+type ctxKey struct{}
+
+// NewContext retorna uma cópia do contexto com os dados da requisição.
+func NewContext(ctx context.Context, info Info) context.Context {
+	return context.WithValue(ctx, ctxKey{}, &holder{info: info})
+}
+
+// FromContext retorna os dados da requisição, vazios fora de uma requisição HTTP.
+func FromContext(ctx context.Context) Info {
+	h, ok := ctx.Value(ctxKey{}).(*holder)
+	if !ok {
+		return Info{}
+	}
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return h.info
+}
+
+// SetUserID registra o usuário autenticado da requisição. Não faz nada fora de uma requisição HTTP.
+func SetUserID(ctx context.Context, id string) {
+	update(ctx, func(info *Info) { info.UserID = id })
+}
+
+// SetTenantID registra o tenant da requisição. Não faz nada fora de uma requisição HTTP.
+func SetTenantID(ctx context.Context, id string) {
+	update(ctx, func(info *Info) { info.TenantID = id })
+}
+
+// SetTraceID registra o trace da requisição. Não faz nada fora de uma requisição HTTP.
+func SetTraceID(ctx context.Context, id string) {
+	update(ctx, func(info *Info) { info.TraceID = id })
+}
+
+func update(ctx context.Context, fn func(*Info)) {
+	h, ok := ctx.Value(ctxKey{}).(*holder)
+	if !ok {
+		return
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	fn(&h.info)
+}
+
+// Middleware guarda o id, o IP e a finalidade da requisição no contexto. O X-Request-ID do
+// chamador é mantido quando válido, senão é gerado um novo. O id volta no header da resposta.
+func Middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		requestID := c.GetHeader(RequestIDHeader)
+		if !validRequestID(requestID) {
+			requestID = uuid.NewString()
+		}
+		c.Header(RequestIDHeader, requestID)
+
+		finalidade := c.GetHeader(PurposeHeader)
+		if len(finalidade) > maxPurpose {
+			finalidade = finalidade[:maxPurpose]
+		}
+		info := Info{RequestID: requestID, IP: c.ClientIP(), Finalidade: finalidade}
+		c.Request = c.Request.WithContext(NewContext(c.Request.Context(), info))
+		c.Next()
+	}
+}
+
+// validRequestID aceita ids de até 128 caracteres com letras, números, '-', '_', '.' e ':',
+// para que o valor possa ir para os logs e headers sem escape
+func validRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestID {
+		return false
+	}
+	for _, r := range id {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+		case r == '-', r == '_', r == '.', r == ':':
+		default:
+			return false
+		}
+	}
+	return true
+}
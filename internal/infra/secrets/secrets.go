@@ -0,0 +1,106 @@
+// Pacote com a leitura de segredos de arquivos (Docker secrets, secrets do Kubernetes) e a
+// recarga dos arquivos alterados sem reiniciar a API.
+package secrets
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/valdinei-santos/cpf-backend/internal/infra/logger"
+)
+
+// ReadFile lê o segredo do arquivo, sem a quebra de linha final que os editores e o echo acrescentam
+func ReadFile(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("erro ao ler o segredo %s: %w", path, err)
+	}
+	return strings.TrimRight(string(data), "\r\n"), nil
+}
+
+// Reloader é um componente que recarrega o seu segredo do disco. Em caso de erro o segredo
+// anterior deve continuar em uso.
+type Reloader interface {
+	Reload() error
+}
+
+// ReloaderFunc adapta uma função para Reloader
+type ReloaderFunc func() error
+
+func (f ReloaderFunc) Reload() error {
+	return f()
+}
+
+// Watcher verifica periodicamente os arquivos de segredos e chama o Reloader de cada arquivo
+// alterado. A comparação é pelo conteúdo, porque os secrets do Kubernetes são trocados por links
+// simbólicos e a data de modificação do link não muda.
+type Watcher struct {
+	interval time.Duration
+	log      logger.ILogger
+	mu       sync.Mutex
+	files    []*watched
+}
+
+type watched struct {
+	path     string
+	reloader Reloader
+	sum      [sha256.Size]byte
+}
+
+// NewWatcher - cria o Watcher que verifica os arquivos a cada interval
+func NewWatcher(interval time.Duration, log logger.ILogger) *Watcher {
+	return &Watcher{interval: interval, log: log}
+}
+
+// Watch - passa a verificar o arquivo. O conteúdo atual é a referência para as próximas verificações.
+func (w *Watcher) Watch(path string, reloader Reloader) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	f := &watched{path: path, reloader: reloader}
+	if data, err := os.ReadFile(path); err == nil {
+		f.sum = sha256.Sum256(data)
+	}
+	w.files = append(w.files, f)
+}
+
+// Run verifica os arquivos a cada intervalo, até o ctx ser cancelado
+func (w *Watcher) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			w.check()
+		}
+	}
+}
+
+func (w *Watcher) check() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	for _, f := range w.files {
+		data, err := os.ReadFile(f.path)
+		if err != nil {
+			// Durante a troca o arquivo pode ficar ausente por um instante
+			w.log.Warn("Erro ao verificar o arquivo de segredo", "arquivo", f.path, "err", err.Error())
+			continue
+		}
+		sum := sha256.Sum256(data)
+		if sum == f.sum {
+			continue
+		}
+		// Um conteúdo inválido é registrado uma vez e tentado de novo só quando o arquivo mudar
+		f.sum = sum
+		w.log.Info("Arquivo de segredo alterado", "arquivo", f.path)
+		if err := f.reloader.Reload(); err != nil {
+			w.log.Error("Erro ao recarregar o segredo, o anterior continua em uso", "arquivo", f.path, "err", err.Error())
+		}
+	}
+}
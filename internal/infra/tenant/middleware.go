@@ -0,0 +1,68 @@
+package tenant
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/valdinei-santos/cpf-backend/internal/infra/identity"
+	"github.com/valdinei-santos/cpf-backend/internal/infra/logger"
+	"github.com/valdinei-santos/cpf-backend/internal/infra/problem"
+	"github.com/valdinei-santos/cpf-backend/internal/infra/rbac"
+	"github.com/valdinei-santos/cpf-backend/internal/infra/requestinfo"
+)
+
+// Authorizer verifica se o chamador pode escolher outro tenant pelo header (ver rbac.Authorizer)
+type Authorizer interface {
+	Can(id *identity.Identity, perm rbac.Permission) bool
+}
+
+// Middleware resolve o tenant da requisição e o coloca no contexto. O tenant vem da claim indicada
+// em claim (token JWT ou chave de API). O header X-Tenant-ID só é aceito de quem tem a
+// PermissionCrossTenant; para os demais, um header diferente da claim é recusado. Com a lista
+// allowed (TENANT_ALLOWED), os tenants fora dela são recusados.
+func Middleware(claim string, allowed []string, authz Authorizer, log logger.ILogger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.Request.Method == http.MethodOptions {
+			c.Next()
+			return
+		}
+
+		ctx := c.Request.Context()
+		caller := identity.FromContext(ctx)
+		header := c.GetHeader(Header)
+		fromClaim, _ := caller.Claims[claim].(string)
+		id := fromClaim
+		if header != "" && header != fromClaim {
+			if !authz.Can(caller, PermissionCrossTenant) {
+				log.WarnContext(ctx, ErrTenantMismatch.Error(), "header", header, "claim", fromClaim, "subject", caller.Subject, "ip", c.ClientIP())
+				problem.Abort(c, ErrTenantMismatch)
+				return
+			}
+			id = header
+		}
+
+		if id == "" {
+			// Sem a permissão, a credencial precisa ter o tenant
+			if !authz.Can(caller, PermissionCrossTenant) {
+				log.WarnContext(ctx, ErrTenantNoClaim.Error(), "claim", claim, "subject", caller.Subject, "ip", c.ClientIP())
+				problem.Abort(c, ErrTenantNoClaim)
+				return
+			}
+			problem.Abort(c, ErrTenantRequired)
+			return
+		}
+		if err := Validate(id); err != nil {
+			problem.Abort(c, err)
+			return
+		}
+		if !Allowed(allowed, id) {
+			log.WarnContext(ctx, ErrTenantUnknown.Error(), "tenant", id, "subject", caller.Subject, "ip", c.ClientIP())
+			problem.Abort(c, ErrTenantUnknown)
+			return
+		}
+
+		requestinfo.SetTenantID(ctx, id)
+		c.Request = c.Request.WithContext(NewContext(ctx, id))
+		c.Next()
+	}
+}
@@ -0,0 +1,175 @@
+package tenant_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/valdinei-santos/cpf-backend/internal/infra/identity"
+	"github.com/valdinei-santos/cpf-backend/internal/infra/logger"
+	"github.com/valdinei-santos/cpf-backend/internal/infra/problem"
+	"github.com/valdinei-santos/cpf-backend/internal/infra/rbac"
+	"github.com/valdinei-santos/cpf-backend/internal/infra/tenant"
+)
+
+// registerErrors registra os erros do tenant como na subida da API (ver routes.registerErrors)
+func registerErrors() {
+	problem.Register(
+		problem.Entry{Err: tenant.ErrTenantRequired, Status: http.StatusBadRequest, Code: "tenant.required"},
+		problem.Entry{Err: tenant.ErrTenantInvalid, Status: http.StatusBadRequest, Code: "tenant.invalid"},
+		problem.Entry{Err: tenant.ErrTenantMismatch, Status: http.StatusForbidden, Code: "tenant.mismatch"},
+		problem.Entry{Err: tenant.ErrTenantNoClaim, Status: http.StatusForbidden, Code: "tenant.no_claim"},
+		problem.Entry{Err: tenant.ErrTenantUnknown, Status: http.StatusForbidden, Code: "tenant.unknown"},
+	)
+}
+
+func TestMiddleware(t *testing.T) {
+	registerErrors()
+
+	// Usuários com o tenant na claim; só o admin tem a permissão tenant:cross na política padrão
+	operator := func(tenantID string) *identity.Identity {
+		return &identity.Identity{Subject: "joao", Roles: []string{"operator"}, Claims: map[string]any{"tenant": tenantID}}
+	}
+	admin := func(tenantID string) *identity.Identity {
+		claims := map[string]any{}
+		if tenantID != "" {
+			claims["tenant"] = tenantID
+		}
+		return &identity.Identity{Subject: "root", Roles: []string{"admin"}, Claims: claims}
+	}
+
+	tests := []struct {
+		name           string
+		id             *identity.Identity
+		header         string
+		allowed        []string
+		expectedStatus int
+		expectedTenant string
+		expectedCode   string
+	}{
+		{
+			name:           "Deve usar o tenant da claim",
+			id:             operator("loja-a"),
+			expectedStatus: http.StatusOK,
+			expectedTenant: "loja-a",
+		},
+		{
+			name:           "Deve aceitar o header igual ao tenant da claim",
+			id:             operator("loja-a"),
+			header:         "loja-a",
+			expectedStatus: http.StatusOK,
+			expectedTenant: "loja-a",
+		},
+		{
+			name:           "Deve recusar o header diferente da claim sem tenant:cross",
+			id:             operator("loja-a"),
+			header:         "loja-b",
+			expectedStatus: http.StatusForbidden,
+			expectedCode:   "tenant.mismatch",
+		},
+		{
+			name:           "Deve usar o header diferente da claim com tenant:cross",
+			id:             admin("loja-a"),
+			header:         "loja-b",
+			expectedStatus: http.StatusOK,
+			expectedTenant: "loja-b",
+		},
+		{
+			name:           "Deve usar o header sem tenant na claim com tenant:cross",
+			id:             admin(""),
+			header:         "loja-b",
+			expectedStatus: http.StatusOK,
+			expectedTenant: "loja-b",
+		},
+		{
+			name:           "Deve recusar a credencial sem tenant sem tenant:cross",
+			id:             operator(""),
+			expectedStatus: http.StatusForbidden,
+			expectedCode:   "tenant.no_claim",
+		},
+		{
+			name:           "Deve exigir o header de quem tem tenant:cross sem tenant na claim",
+			id:             admin(""),
+			expectedStatus: http.StatusBadRequest,
+			expectedCode:   "tenant.required",
+		},
+		{
+			name:           "Deve recusar o tenant em formato inválido",
+			id:             admin(""),
+			header:         "Loja A",
+			expectedStatus: http.StatusBadRequest,
+			expectedCode:   "tenant.invalid",
+		},
+		{
+			name:           "Deve aceitar o tenant da lista de habilitados",
+			id:             operator("loja-a"),
+			allowed:        []string{"loja-a", "loja-b"},
+			expectedStatus: http.StatusOK,
+			expectedTenant: "loja-a",
+		},
+		{
+			name:           "Deve recusar o tenant fora da lista de habilitados",
+			id:             admin("loja-a"),
+			header:         "loja-c",
+			allowed:        []string{"loja-a", "loja-b"},
+			expectedStatus: http.StatusForbidden,
+			expectedCode:   "tenant.unknown",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			authz := rbac.NewAuthorizer(rbac.DefaultPolicy(), false, logger.NewMockILogger())
+			gin.SetMode(gin.TestMode)
+			r := gin.New()
+			r.Use(func(c *gin.Context) {
+				c.Request = c.Request.WithContext(identity.NewContext(c.Request.Context(), tt.id))
+				c.Next()
+			})
+			r.Use(tenant.Middleware("tenant", tt.allowed, authz, logger.NewMockILogger()))
+			r.GET("/cliente", func(c *gin.Context) {
+				id, _ := tenant.FromContext(c.Request.Context())
+				c.String(http.StatusOK, id)
+			})
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, "/cliente", nil)
+			if tt.header != "" {
+				req.Header.Set(tenant.Header, tt.header)
+			}
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedStatus == http.StatusOK {
+				assert.Equal(t, tt.expectedTenant, w.Body.String())
+				return
+			}
+			var p problem.Problem
+			if assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &p)) {
+				assert.Equal(t, tt.expectedCode, p.Code)
+			}
+		})
+	}
+}
+
+func TestAllowed(t *testing.T) {
+	tests := []struct {
+		name     string
+		allowed  []string
+		id       string
+		expected bool
+	}{
+		{name: "Deve aceitar qualquer tenant com a lista vazia", allowed: nil, id: "loja-a", expected: true},
+		{name: "Deve aceitar o tenant da lista", allowed: []string{"loja-a", "loja-b"}, id: "loja-b", expected: true},
+		{name: "Deve recusar o tenant fora da lista", allowed: []string{"loja-a"}, id: "loja-b", expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tenant.Allowed(tt.allowed, tt.id))
+		})
+	}
+}
@@ -0,0 +1,63 @@
+// Pacote com o tenant (unidade de negócio) da requisição, guardado no contexto.
+package tenant
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"slices"
+
+	"github.com/valdinei-santos/cpf-backend/internal/infra/rbac"
+)
+
+// Modos de isolamento entre os tenants
+const (
+	ModeNone     = "none"     // Um único tenant, sem isolamento (padrão)
+	ModeField    = "field"    // Collection compartilhada com o campo tenant em cada registro
+	ModeDatabase = "database" // Um database por tenant
+)
+
+// Header é o header com o tenant de outra unidade, aceito só com a PermissionCrossTenant
+const Header = "X-Tenant-ID"
+
+// PermissionCrossTenant - permissão para escolher o tenant pelo header X-Tenant-ID, além do tenant
+// da credencial. Concedida pelo papel admin na política padrão.
+const PermissionCrossTenant rbac.Permission = "tenant:cross"
+
+var (
+	ErrTenantRequired = errors.New("tenant não informado")
+	ErrTenantInvalid  = errors.New("tenant inválido, use de 2 a 32 caracteres minúsculos, números, _ ou -")
+	ErrTenantMismatch = errors.New("tenant do header diferente do tenant da credencial")
+	ErrTenantNoClaim  = errors.New("credencial sem tenant")
+	ErrTenantUnknown  = errors.New("tenant não habilitado nesta instalação")
+)
+
+// O formato é restrito porque o tenant também compõe o nome do database no ModeDatabase
+var tenantRegex = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]{1,31}$`)
+
+type ctxKey struct{}
+
+// Validate verifica o formato do identificador do tenant.
+func Validate(id string) error {
+	if !tenantRegex.MatchString(id) {
+		return ErrTenantInvalid
+	}
+	return nil
+}
+
+// Allowed indica se o tenant está na lista de tenants habilitados (TENANT_ALLOWED). Lista vazia
+// aceita qualquer tenant.
+func Allowed(allowed []string, id string) bool {
+	return len(allowed) == 0 || slices.Contains(allowed, id)
+}
+
+// NewContext retorna uma cópia do contexto com o tenant.
+func NewContext(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, ctxKey{}, id)
+}
+
+// FromContext retorna o tenant do contexto. O ok é false quando a requisição não tem tenant.
+func FromContext(ctx context.Context) (string, bool) {
+	id, ok := ctx.Value(ctxKey{}).(string)
+	return id, ok && id != ""
+}
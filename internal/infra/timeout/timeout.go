@@ -0,0 +1,70 @@
+// Pacote com os prazos das operações da API e a conversão dos erros de contexto em status HTTP.
+package timeout
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// Status usado quando o cliente desiste da requisição antes da resposta (convenção do nginx). A
+// resposta não chega ao cliente, mas fica no log e nas métricas.
+const StatusClientClosedRequest = 499
+
+// Policy define o prazo de cada operação. Zero desabilita o prazo.
+type Policy struct {
+	Read   time.Duration            // Consultas (GET e HEAD)
+	Write  time.Duration            // Gravações (POST, PUT, PATCH e DELETE)
+	Routes map[string]time.Duration // Exceções, pelo método e o template da rota. Ex: "GET /api/v1/cliente/auditoria/verificar"
+}
+
+// For retorna o prazo da operação
+func (p Policy) For(method, route string) time.Duration {
+	if d, ok := p.Routes[method+" "+route]; ok {
+		return d
+	}
+	if method == http.MethodGet || method == http.MethodHead {
+		return p.Read
+	}
+	return p.Write
+}
+
+// Middleware aplica o prazo da operação ao contexto da requisição, repassado aos casos de uso e ao
+// MongoDB. Deve vir antes dos middlewares que acessam o banco (autenticação e rate limit), para que
+// o prazo valha para a requisição inteira.
+func Middleware(p Policy) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		d := p.For(c.Request.Method, c.FullPath())
+		if d <= 0 {
+			c.Next()
+			return
+		}
+		ctx, cancel := context.WithTimeout(c.Request.Context(), d)
+		defer cancel()
+		c.Request = c.Request.WithContext(ctx)
+		c.Next()
+	}
+}
+
+// Status retorna o status HTTP de um erro causado pelo fim do contexto da requisição: 504 quando o
+// prazo da operação esgotou e 499 quando o cliente cancelou a requisição. ok é false para os
+// demais erros. O contexto também é consultado, porque nem todo erro preserva a causa.
+func Status(ctx context.Context, err error) (status int, ok bool) {
+	switch {
+	case errors.Is(err, context.DeadlineExceeded), mongo.IsTimeout(err):
+		return http.StatusGatewayTimeout, true
+	case errors.Is(err, context.Canceled):
+		return StatusClientClosedRequest, true
+	}
+	switch ctx.Err() {
+	case context.DeadlineExceeded:
+		return http.StatusGatewayTimeout, true
+	case context.Canceled:
+		return StatusClientClosedRequest, true
+	}
+	return 0, false
+}
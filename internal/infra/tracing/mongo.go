@@ -0,0 +1,65 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"go.mongodb.org/mongo-driver/event"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Comandos internos do driver (handshake e autenticação), que não são rastreados
+var ignoredCommands = map[string]bool{
+	"hello": true, "isMaster": true, "ismaster": true,
+	"saslStart": true, "saslContinue": true, "authenticate": true, "endSessions": true,
+}
+
+// MongoMonitor retorna o monitor de comandos do driver do MongoDB que cria um span para cada
+// comando, filho do span do contexto da operação
+func MongoMonitor() *event.CommandMonitor {
+	var spans sync.Map // Span de cada comando em andamento, pela conexão e o id da requisição
+	key := func(connectionID string, requestID int64) string {
+		return fmt.Sprintf("%s/%d", connectionID, requestID)
+	}
+	end := func(connectionID string, requestID int64, failure string) {
+		value, ok := spans.LoadAndDelete(key(connectionID, requestID))
+		if !ok {
+			return
+		}
+		span := value.(trace.Span)
+		if failure != "" {
+			span.RecordError(errors.New(failure))
+			span.SetStatus(codes.Error, failure)
+		}
+		span.End()
+	}
+
+	return &event.CommandMonitor{
+		Started: func(ctx context.Context, e *event.CommandStartedEvent) {
+			if ignoredCommands[e.CommandName] {
+				return
+			}
+			collection, _ := e.Command.Lookup(e.CommandName).StringValueOK()
+			_, span := Start(ctx, "mongodb "+e.CommandName,
+				trace.WithSpanKind(trace.SpanKindClient),
+				trace.WithAttributes(
+					semconv.DBSystemNameMongoDB,
+					semconv.DBNamespace(e.DatabaseName),
+					semconv.DBOperationName(e.CommandName),
+					semconv.DBCollectionName(collection),
+				),
+			)
+			spans.Store(key(e.ConnectionID, e.RequestID), span)
+		},
+		Succeeded: func(_ context.Context, e *event.CommandSucceededEvent) {
+			end(e.ConnectionID, e.RequestID, "")
+		},
+		Failed: func(_ context.Context, e *event.CommandFailedEvent) {
+			end(e.ConnectionID, e.RequestID, e.Failure)
+		},
+	}
+}
@@ -0,0 +1,116 @@
+// Pacote com o rastreamento distribuído (OpenTelemetry) da API: spans das requisições HTTP, dos
+// casos de uso e dos comandos do MongoDB, propagados pelo header traceparent (W3C).
+package tracing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Destinos dos spans
+const (
+	ExporterNone   = "none"   // Padrão. Os spans não são exportados, mas o traceparent recebido continua nos logs e respostas
+	ExporterOTLP   = "otlp"   // Coletor OTLP/HTTP (Jaeger, Tempo, OpenTelemetry Collector...)
+	ExporterStdout = "stdout" // JSON na saída padrão, para desenvolvimento sem coletor
+	ExporterFile   = "file"   // JSON no arquivo de File, para desenvolvimento sem coletor
+)
+
+// Nome do tracer dos spans criados pela API
+const tracerName = "github.com/valdinei-santos/cpf-backend"
+
+// Options são as configurações do rastreamento. As tags definem a chave no arquivo YAML e o sufixo
+// da variável de ambiente (TRACING_*), ver o pacote config.
+type Options struct {
+	Exporter    string  `yaml:"exporter" env:"EXPORTER"`         // none, otlp, stdout ou file
+	Endpoint    string  `yaml:"endpoint" env:"ENDPOINT"`         // host:porta do coletor OTLP/HTTP. Vazio usa OTEL_EXPORTER_OTLP_ENDPOINT ou localhost:4318
+	Insecure    bool    `yaml:"insecure" env:"INSECURE"`         // Envia ao coletor sem TLS
+	File        string  `yaml:"file" env:"FILE"`                 // Arquivo do exporter file
+	SampleRatio float64 `yaml:"sample_ratio" env:"SAMPLE_RATIO"` // Fração das requisições rastreadas, de 0 a 1. A decisão de quem chama (traceparent) é respeitada
+	ServiceName string  `yaml:"service_name" env:"SERVICE_NAME"` // Nome do serviço nos spans
+}
+
+// Setup - configura o provider e o propagador W3C globais do OpenTelemetry. O shutdown retornado
+// envia os spans pendentes e deve ser chamado no encerramento da API.
+func Setup(ctx context.Context, opts Options) (shutdown func(context.Context) error, err error) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+	if opts.Exporter == "" || opts.Exporter == ExporterNone {
+		return func(context.Context) error { return nil }, nil
+	}
+
+	var exporter sdktrace.SpanExporter
+	var file io.Closer
+	switch opts.Exporter {
+	case ExporterOTLP:
+		var clientOpts []otlptracehttp.Option
+		if opts.Endpoint != "" {
+			clientOpts = append(clientOpts, otlptracehttp.WithEndpoint(opts.Endpoint))
+		}
+		if opts.Insecure {
+			clientOpts = append(clientOpts, otlptracehttp.WithInsecure())
+		}
+		exporter, err = otlptracehttp.New(ctx, clientOpts...)
+	case ExporterStdout:
+		exporter, err = stdouttrace.New()
+	case ExporterFile:
+		f, openErr := os.OpenFile(opts.File, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+		if openErr != nil {
+			return nil, fmt.Errorf("erro ao abrir o arquivo de traces %s: %w", opts.File, openErr)
+		}
+		file = f
+		exporter, err = stdouttrace.New(stdouttrace.WithWriter(f))
+	default:
+		return nil, fmt.Errorf("exporter de traces inválido: %s", opts.Exporter)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("erro ao criar o exporter de traces %s: %w", opts.Exporter, err)
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(resource.NewSchemaless(semconv.ServiceName(opts.ServiceName))),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(opts.SampleRatio))),
+	)
+	otel.SetTracerProvider(provider)
+
+	return func(ctx context.Context) error {
+		err := provider.Shutdown(ctx)
+		if file != nil {
+			err = errors.Join(err, file.Close())
+		}
+		return err
+	}, nil
+}
+
+// Start - inicia um span filho do span do ctx. Usado nos casos de uso: ctx, span := tracing.Start(ctx, "get.Execute")
+func Start(ctx context.Context, name string, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
+	return otel.Tracer(tracerName).Start(ctx, name, opts...)
+}
+
+// TraceID retorna o trace id do span do ctx, vazio quando não há span
+func TraceID(ctx context.Context) string {
+	sc := trace.SpanContextFromContext(ctx)
+	if !sc.HasTraceID() {
+		return ""
+	}
+	return sc.TraceID().String()
+}
+
+// RecordError registra o erro no span do ctx e o marca com falha
+func RecordError(ctx context.Context, err error) {
+	span := trace.SpanFromContext(ctx)
+	span.RecordError(err)
+	span.SetStatus(codes.Error, err.Error())
+}
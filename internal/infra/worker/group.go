@@ -0,0 +1,75 @@
+// Pacote com o grupo das rotinas em segundo plano (workers) da API, encerradas juntas no shutdown.
+package worker
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/valdinei-santos/cpf-backend/internal/infra/logger"
+)
+
+// Group executa os workers com um contexto comum, cancelado no Stop
+type Group struct {
+	ctx     context.Context
+	cancel  context.CancelFunc
+	wg      sync.WaitGroup
+	log     logger.ILogger
+	mu      sync.Mutex
+	running map[string]bool
+}
+
+// NewGroup - cria o grupo de workers
+func NewGroup(log logger.ILogger) *Group {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Group{ctx: ctx, cancel: cancel, log: log, running: map[string]bool{}}
+}
+
+// Go - executa o worker em uma goroutine. run deve retornar quando o ctx for cancelado. Um panic no
+// worker é registrado no log e o worker fica como parado, sem derrubar a API.
+func (g *Group) Go(name string, run func(ctx context.Context)) {
+	g.mu.Lock()
+	g.running[name] = true
+	g.mu.Unlock()
+
+	g.wg.Add(1)
+	go func() {
+		defer g.wg.Done()
+		defer func() {
+			if r := recover(); r != nil {
+				g.log.Error("Worker interrompido por panic", "worker", name, "err", fmt.Sprint(r))
+			}
+			g.mu.Lock()
+			g.running[name] = false
+			g.mu.Unlock()
+		}()
+		run(g.ctx)
+	}()
+}
+
+// Status retorna os workers iniciados e se cada um continua em execução
+func (g *Group) Status() map[string]bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	status := make(map[string]bool, len(g.running))
+	for name, running := range g.running {
+		status[name] = running
+	}
+	return status
+}
+
+// Stop - cancela os workers e espera que terminem, até o ctx expirar
+func (g *Group) Stop(ctx context.Context) error {
+	g.cancel()
+	done := make(chan struct{})
+	go func() {
+		g.wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("workers ainda em execução: %w", ctx.Err())
+	}
+}
@@ -0,0 +1,17 @@
+// Pacote com erros locais do domínio.
+package domainerr
+
+import "errors"
+
+var (
+	ErrApiKeyNomeInvalid      = errors.New("o nome deve ter entre 3 e 50 caracteres")
+	ErrApiKeyOwnerInvalid     = errors.New("o responsável deve ter entre 3 e 100 caracteres")
+	ErrApiKeyScopeInvalid     = errors.New("informe ao menos um escopo com 2 a 50 caracteres minúsculos, números ou _ : . -")
+	ErrApiKeyExpiracaoInvalid = errors.New("a expiração deve ser uma data futura")
+	ErrApiKeyTenantInvalid    = errors.New("tenant inválido, use de 2 a 32 caracteres minúsculos, números, _ ou -")
+	ErrApiKeyIDInvalid        = errors.New("ID inválido")
+	ErrApiKeyNotFound         = errors.New("chave de API não encontrada")
+	ErrApiKeyRevogada         = errors.New("chave de API revogada")
+	ErrApiKeyExpirada         = errors.New("chave de API expirada")
+	ErrApiKeyInvalida         = errors.New("chave de API inválida")
+)
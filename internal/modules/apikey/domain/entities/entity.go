@@ -0,0 +1,155 @@
+package entities
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/valdinei-santos/cpf-backend/internal/domain/globalerr"
+	domainvo "github.com/valdinei-santos/cpf-backend/internal/domain/vo"
+	"github.com/valdinei-santos/cpf-backend/internal/modules/apikey/domain/domainerr"
+	"github.com/valdinei-santos/cpf-backend/internal/modules/apikey/domain/vo"
+)
+
+// secretPrefix identifica as chaves da API em logs e em ferramentas de varredura de segredos
+const secretPrefix = "cpfk"
+
+// ApiKey representa uma chave de API usada por clientes serviço a serviço (jobs batch).
+// Só o hash do segredo é gravado, o segredo é exibido uma única vez na criação e na rotação.
+type ApiKey struct {
+	ID         domainvo.ID     `bson:"id"`
+	Nome       vo.NomeApiKey   `bson:"nome"`
+	Owner      vo.OwnerApiKey  `bson:"owner"`
+	Tenant     vo.TenantApiKey `bson:"tenant,omitempty"`
+	Scopes     vo.ScopesApiKey `bson:"scopes"`
+	Prefix     string          `bson:"prefix"`
+	Hash       string          `bson:"hash"`
+	ExpiresAt  *time.Time      `bson:"expires_at,omitempty"`
+	LastUsedAt *time.Time      `bson:"last_used_at,omitempty"`
+	RevokedAt  *time.Time      `bson:"revoked_at,omitempty"`
+	CreatedAt  time.Time       `bson:"created_at"`
+	UpdatedAt  time.Time       `bson:"updated_at"`
+}
+
+// NewApiKey - cria uma nova chave e retorna o segredo em texto puro junto com a entidade.
+// Com tenant, as requisições feitas com a chave ficam restritas a ele. Com dados inválidos retorna
+// um *globalerr.ValidationError com todos os campos inválidos.
+func NewApiKey(nome, owner, tenant string, scopes []string, expiresAt *time.Time) (*ApiKey, string, error) {
+	uuidVO, err := uuid.NewUUID()
+	if err != nil {
+		return nil, "", err
+	}
+	var v globalerr.Validation
+	nomeVO, err := vo.NewNomeApiKey(nome)
+	v.Add("nome", err)
+	ownerVO, err := vo.NewOwnerApiKey(owner)
+	v.Add("owner", err)
+	tenantVO, err := vo.NewTenantApiKey(tenant)
+	v.Add("tenant", err)
+	scopesVO, err := vo.NewScopesApiKey(scopes)
+	v.Add("scopes", err)
+	now := time.Now()
+	if expiresAt != nil && !expiresAt.After(now) {
+		v.Add("expires_at", domainerr.ErrApiKeyExpiracaoInvalid)
+	}
+	if err := v.Err(); err != nil {
+		return nil, "", err
+	}
+
+	k := &ApiKey{
+		ID:        domainvo.FromUUID(uuidVO),
+		Nome:      nomeVO,
+		Owner:     ownerVO,
+		Tenant:    tenantVO,
+		Scopes:    scopesVO,
+		ExpiresAt: expiresAt,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	secret, err := k.newSecret()
+	if err != nil {
+		return nil, "", err
+	}
+	return k, secret, nil
+}
+
+// Rotate - gera um novo segredo. O segredo anterior deixa de ser aceito.
+func (k *ApiKey) Rotate() (string, error) {
+	if k.RevokedAt != nil {
+		return "", domainerr.ErrApiKeyRevogada
+	}
+	secret, err := k.newSecret()
+	if err != nil {
+		return "", err
+	}
+	k.UpdatedAt = time.Now()
+	return secret, nil
+}
+
+// Revoke - revoga a chave. O registro é mantido para auditoria.
+func (k *ApiKey) Revoke() error {
+	if k.RevokedAt != nil {
+		return domainerr.ErrApiKeyRevogada
+	}
+	now := time.Now()
+	k.RevokedAt = &now
+	k.UpdatedAt = now
+	return nil
+}
+
+// Validate - verifica se o segredo confere e se a chave está vigente
+func (k *ApiKey) Validate(secret string, now time.Time) error {
+	hash := hashSecret(secret)
+	if subtle.ConstantTimeCompare([]byte(hash), []byte(k.Hash)) != 1 {
+		return domainerr.ErrApiKeyInvalida
+	}
+	if k.RevokedAt != nil {
+		return domainerr.ErrApiKeyRevogada
+	}
+	if k.ExpiresAt != nil && !now.Before(*k.ExpiresAt) {
+		return domainerr.ErrApiKeyExpirada
+	}
+	return nil
+}
+
+// Ativa - indica se a chave não foi revogada e não expirou
+func (k *ApiKey) Ativa(now time.Time) bool {
+	return k.RevokedAt == nil && (k.ExpiresAt == nil || now.Before(*k.ExpiresAt))
+}
+
+// newSecret - gera o segredo no formato cpfk_<prefixo>_<aleatório> e guarda o prefixo e o hash
+func (k *ApiKey) newSecret() (string, error) {
+	prefix := make([]byte, 6)
+	if _, err := rand.Read(prefix); err != nil {
+		return "", err
+	}
+	random := make([]byte, 32)
+	if _, err := rand.Read(random); err != nil {
+		return "", err
+	}
+	k.Prefix = hex.EncodeToString(prefix)
+	secret := secretPrefix + "_" + k.Prefix + "_" + base64.RawURLEncoding.EncodeToString(random)
+	k.Hash = hashSecret(secret)
+	return secret, nil
+}
+
+// ParsePrefix - extrai o prefixo usado para localizar a chave a partir do segredo informado
+func ParsePrefix(secret string) (string, error) {
+	// O trecho aleatório em base64url também pode conter "_"
+	parts := strings.SplitN(secret, "_", 3)
+	if len(parts) != 3 || parts[0] != secretPrefix || len(parts[1]) != 12 || parts[2] == "" {
+		return "", domainerr.ErrApiKeyInvalida
+	}
+	return parts[1], nil
+}
+
+// O segredo tem 256 bits aleatórios, então um SHA-256 é suficiente (não precisa de bcrypt/argon2)
+func hashSecret(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,16 @@
+package vo
+
+import "github.com/valdinei-santos/cpf-backend/internal/modules/apikey/domain/domainerr"
+
+type NomeApiKey string
+
+func NewNomeApiKey(nome string) (NomeApiKey, error) {
+	if len(nome) < 3 || len(nome) > 50 {
+		return "", domainerr.ErrApiKeyNomeInvalid
+	}
+	return NomeApiKey(nome), nil
+}
+
+func (n NomeApiKey) String() string {
+	return string(n)
+}
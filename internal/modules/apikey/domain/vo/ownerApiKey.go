@@ -0,0 +1,17 @@
+package vo
+
+import "github.com/valdinei-santos/cpf-backend/internal/modules/apikey/domain/domainerr"
+
+// OwnerApiKey é o responsável pela chave (equipe ou sistema que executa o job)
+type OwnerApiKey string
+
+func NewOwnerApiKey(owner string) (OwnerApiKey, error) {
+	if len(owner) < 3 || len(owner) > 100 {
+		return "", domainerr.ErrApiKeyOwnerInvalid
+	}
+	return OwnerApiKey(owner), nil
+}
+
+func (o OwnerApiKey) String() string {
+	return string(o)
+}
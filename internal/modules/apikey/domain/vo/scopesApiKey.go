@@ -0,0 +1,34 @@
+package vo
+
+import (
+	"regexp"
+	"slices"
+
+	"github.com/valdinei-santos/cpf-backend/internal/modules/apikey/domain/domainerr"
+)
+
+var scopeRegex = regexp.MustCompile(`^[a-z0-9_:.\-]{2,50}$`)
+
+// ScopesApiKey são os escopos concedidos à chave. Ex: cliente:read, pii:unmask
+type ScopesApiKey []string
+
+func NewScopesApiKey(scopes []string) (ScopesApiKey, error) {
+	if len(scopes) == 0 {
+		return nil, domainerr.ErrApiKeyScopeInvalid
+	}
+	result := make([]string, 0, len(scopes))
+	for _, s := range scopes {
+		if !scopeRegex.MatchString(s) {
+			return nil, domainerr.ErrApiKeyScopeInvalid
+		}
+		if !slices.Contains(result, s) {
+			result = append(result, s)
+		}
+	}
+	slices.Sort(result)
+	return ScopesApiKey(result), nil
+}
+
+func (s ScopesApiKey) Strings() []string {
+	return []string(s)
+}
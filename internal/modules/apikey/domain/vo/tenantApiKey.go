@@ -0,0 +1,24 @@
+package vo
+
+import (
+	"regexp"
+
+	"github.com/valdinei-santos/cpf-backend/internal/modules/apikey/domain/domainerr"
+)
+
+// Mesmo formato aceito no header X-Tenant-ID
+var tenantRegex = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]{1,31}$`)
+
+// TenantApiKey é o tenant ao qual a chave fica presa. Vazio quando a chave não é de um tenant específico.
+type TenantApiKey string
+
+func NewTenantApiKey(tenant string) (TenantApiKey, error) {
+	if tenant != "" && !tenantRegex.MatchString(tenant) {
+		return "", domainerr.ErrApiKeyTenantInvalid
+	}
+	return TenantApiKey(tenant), nil
+}
+
+func (t TenantApiKey) String() string {
+	return string(t)
+}
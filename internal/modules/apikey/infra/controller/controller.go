@@ -0,0 +1,115 @@
+package controller
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/valdinei-santos/cpf-backend/internal/domain/globalerr"
+	"github.com/valdinei-santos/cpf-backend/internal/infra/logger"
+	"github.com/valdinei-santos/cpf-backend/internal/infra/problem"
+	"github.com/valdinei-santos/cpf-backend/internal/infra/tracing"
+	"github.com/valdinei-santos/cpf-backend/internal/modules/apikey/infra/dto"
+	"github.com/valdinei-santos/cpf-backend/internal/modules/apikey/usecases/create"
+	"github.com/valdinei-santos/cpf-backend/internal/modules/apikey/usecases/getall"
+	"github.com/valdinei-santos/cpf-backend/internal/modules/apikey/usecases/revoke"
+	"github.com/valdinei-santos/cpf-backend/internal/modules/apikey/usecases/rotate"
+)
+
+// ApiKeyController orquestra as ações administrativas das chaves de API.
+type ApiKeyController struct {
+	log      logger.ILogger
+	createUC create.IUsecase
+	getAllUC getall.IUsecase
+	rotateUC rotate.IUsecase
+	revokeUC revoke.IUsecase
+}
+
+// NewApiKeyController é o construtor que injeta todas as dependências.
+func NewApiKeyController(
+	log logger.ILogger,
+	c create.IUsecase,
+	ga getall.IUsecase,
+	ro rotate.IUsecase,
+	re revoke.IUsecase,
+) *ApiKeyController {
+	return &ApiKeyController{
+		log:      log,
+		createUC: c,
+		getAllUC: ga,
+		rotateUC: ro,
+		revokeUC: re,
+	}
+}
+
+// Handler específico de Criação
+func (c *ApiKeyController) Create(ctx *gin.Context) {
+	c.log.DebugContext(ctx.Request.Context(), "Entrou apikey.controller.Create")
+	var input *dto.Request
+	err := json.NewDecoder(ctx.Request.Body).Decode(&input)
+	if err != nil || input == nil {
+		outputError(c.log, ctx, globalerr.ErrBadRequest, "Create/json.Decode")
+		return
+	}
+	resp, err := c.createUC.Execute(ctx.Request.Context(), input)
+	if err != nil {
+		outputError(c.log, ctx, err, "Create/usecase.Execute")
+		return
+	}
+	ctx.JSON(http.StatusCreated, resp)
+}
+
+// Handler específico de Obtenção de todos os registros (com paginação)
+func (c *ApiKeyController) GetAll(ctx *gin.Context) {
+	c.log.DebugContext(ctx.Request.Context(), "Entrou apikey.controller.GetAll")
+
+	page, err := strconv.Atoi(ctx.DefaultQuery("page", "1"))
+	if err != nil || page < 1 {
+		outputError(c.log, ctx, globalerr.ErrBadRequest, "GetAll/parse_page")
+		return
+	}
+	size, err := strconv.Atoi(ctx.DefaultQuery("size", "10"))
+	if err != nil || size < 1 {
+		outputError(c.log, ctx, globalerr.ErrBadRequest, "GetAll/parse_size")
+		return
+	}
+
+	resp, err := c.getAllUC.Execute(ctx.Request.Context(), int64(page), int64(size))
+	if err != nil {
+		outputError(c.log, ctx, err, "GetAll/usecase.Execute")
+		return
+	}
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// Handler específico de Rotação do segredo
+func (c *ApiKeyController) Rotate(ctx *gin.Context) {
+	c.log.DebugContext(ctx.Request.Context(), "Entrou apikey.controller.Rotate")
+	resp, err := c.rotateUC.Execute(ctx.Request.Context(), ctx.Param("id"))
+	if err != nil {
+		outputError(c.log, ctx, err, "Rotate/usecase.Execute")
+		return
+	}
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// Handler específico de Revogação
+func (c *ApiKeyController) Revoke(ctx *gin.Context) {
+	c.log.DebugContext(ctx.Request.Context(), "Entrou apikey.controller.Revoke")
+	resp, err := c.revokeUC.Execute(ctx.Request.Context(), ctx.Param("id"))
+	if err != nil {
+		outputError(c.log, ctx, err, "Revoke/usecase.Execute")
+		return
+	}
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// outputError registra o erro no log e no trace e responde no formato application/problem+json.
+// O status e o código vêm do registro de erros do pacote problem.
+func outputError(log logger.ILogger, ctx *gin.Context, err error, method string) {
+	reqCtx := ctx.Request.Context()
+	log.ErrorContext(reqCtx, err.Error(), "mtd", method)
+	tracing.RecordError(reqCtx, err)
+	problem.Write(ctx, err)
+}
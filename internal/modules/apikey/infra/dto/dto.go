@@ -0,0 +1,72 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/valdinei-santos/cpf-backend/internal/modules/apikey/domain/entities"
+)
+
+// Request - dados para criar uma chave de API
+type Request struct {
+	Nome      string     `json:"nome"`
+	Owner     string     `json:"owner"`
+	Tenant    string     `json:"tenant,omitempty"`
+	Scopes    []string   `json:"scopes"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+} //@name ApiKeyRequest
+
+// Response - chave de API sem o segredo
+type Response struct {
+	ID         string   `json:"id"`
+	Nome       string   `json:"nome"`
+	Owner      string   `json:"owner"`
+	Tenant     string   `json:"tenant,omitempty"`
+	Scopes     []string `json:"scopes"`
+	Prefix     string   `json:"prefix"`
+	Ativa      bool     `json:"ativa"`
+	ExpiresAt  *string  `json:"expires_at,omitempty"`
+	LastUsedAt *string  `json:"last_used_at,omitempty"`
+	RevokedAt  *string  `json:"revoked_at,omitempty"`
+	CreatedAt  string   `json:"created_at"`
+	UpdatedAt  string   `json:"updated_at"`
+} //@name ApiKeyResponse
+
+// SecretResponse - resposta da criação e da rotação. O segredo é exibido somente nesta resposta.
+type SecretResponse struct {
+	Response
+	Secret string `json:"secret"`
+} //@name ApiKeySecretResponse
+
+type ResponseManyPaginated struct {
+	ApiKeys      []Response `json:"api_keys"`
+	TotalItems   int64      `json:"totalItems"`
+	TotalPages   int64      `json:"totalPages"`
+	CurrentPage  int64      `json:"currentPage"`
+	ItemsPerPage int64      `json:"itemsPerPage"`
+} //@name ApiKeysPaginated
+
+// NewResponse - transforma a entidade ApiKey no DTO Response
+func NewResponse(k *entities.ApiKey) Response {
+	return Response{
+		ID:         k.ID.String(),
+		Nome:       k.Nome.String(),
+		Owner:      k.Owner.String(),
+		Tenant:     k.Tenant.String(),
+		Scopes:     k.Scopes.Strings(),
+		Prefix:     k.Prefix,
+		Ativa:      k.Ativa(time.Now()),
+		ExpiresAt:  timeString(k.ExpiresAt),
+		LastUsedAt: timeString(k.LastUsedAt),
+		RevokedAt:  timeString(k.RevokedAt),
+		CreatedAt:  k.CreatedAt.String(),
+		UpdatedAt:  k.UpdatedAt.String(),
+	}
+}
+
+func timeString(t *time.Time) *string {
+	if t == nil {
+		return nil
+	}
+	s := t.String()
+	return &s
+}
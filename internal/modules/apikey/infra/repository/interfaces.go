@@ -0,0 +1,18 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/valdinei-santos/cpf-backend/internal/modules/apikey/domain/entities"
+)
+
+// IApiKeyRepository define a interface para as operações de ApiKey
+type IApiKeyRepository interface {
+	AddApiKey(ctx context.Context, k *entities.ApiKey) error
+	GetApiKeyByID(ctx context.Context, id string) (*entities.ApiKey, error)
+	GetApiKeyByPrefix(ctx context.Context, prefix string) (*entities.ApiKey, error)
+	GetAllApiKeys(ctx context.Context, offset int64, limit int64) ([]*entities.ApiKey, int64, error)
+	UpdateApiKey(ctx context.Context, k *entities.ApiKey) error
+	TouchLastUsed(ctx context.Context, id string, usedAt time.Time) error
+}
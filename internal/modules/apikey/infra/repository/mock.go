@@ -0,0 +1,131 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/valdinei-santos/cpf-backend/internal/domain/vo"
+	"github.com/valdinei-santos/cpf-backend/internal/modules/apikey/domain/domainerr"
+	"github.com/valdinei-santos/cpf-backend/internal/modules/apikey/domain/entities"
+)
+
+// MockApiKeyRepository é um mock com a implementação da interface IApiKeyRepository
+type MockApiKeyRepository struct {
+	ApiKeys   []entities.ApiKey
+	Secrets   []string // Segredos em texto puro das chaves padrão, na mesma ordem de ApiKeys
+	mockError error
+}
+
+// NewMockApiKeyRepository cria uma nova instancia de MockApiKeyRepository com 2 chaves padrão,
+// a segunda revogada
+func NewMockApiKeyRepository() *MockApiKeyRepository {
+	m := &MockApiKeyRepository{}
+	for _, nome := range []string{"Job Exportacao", "Job Antigo"} {
+		k, secret, err := entities.NewApiKey(nome, "time-batch", "", []string{"cliente:read"}, nil)
+		if err != nil {
+			panic(err)
+		}
+		m.ApiKeys = append(m.ApiKeys, *k)
+		m.Secrets = append(m.Secrets, secret)
+	}
+	revokedAt := time.Now()
+	m.ApiKeys[1].RevokedAt = &revokedAt
+	return m
+}
+
+func (m *MockApiKeyRepository) SetMockError(err error) {
+	m.mockError = err
+}
+
+// AddApiKey - mock do método AddApiKey
+func (m *MockApiKeyRepository) AddApiKey(ctx context.Context, k *entities.ApiKey) error {
+	if m.mockError != nil {
+		return m.mockError
+	}
+	m.ApiKeys = append(m.ApiKeys, *k)
+	return nil
+}
+
+// GetApiKeyByID - mock do método GetApiKeyByID
+func (m *MockApiKeyRepository) GetApiKeyByID(ctx context.Context, id string) (*entities.ApiKey, error) {
+	if m.mockError != nil {
+		return nil, m.mockError
+	}
+	idUUID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, domainerr.ErrApiKeyIDInvalid
+	}
+	for _, k := range m.ApiKeys {
+		if k.ID == vo.FromUUID(idUUID) {
+			return &k, nil
+		}
+	}
+	return nil, domainerr.ErrApiKeyNotFound
+}
+
+// GetApiKeyByPrefix - mock do método GetApiKeyByPrefix
+func (m *MockApiKeyRepository) GetApiKeyByPrefix(ctx context.Context, prefix string) (*entities.ApiKey, error) {
+	if m.mockError != nil {
+		return nil, m.mockError
+	}
+	for _, k := range m.ApiKeys {
+		if k.Prefix == prefix {
+			return &k, nil
+		}
+	}
+	return nil, domainerr.ErrApiKeyNotFound
+}
+
+// GetAllApiKeys - mock do método GetAllApiKeys
+func (m *MockApiKeyRepository) GetAllApiKeys(ctx context.Context, offset int64, limit int64) ([]*entities.ApiKey, int64, error) {
+	if m.mockError != nil {
+		return nil, 0, m.mockError
+	}
+	total := int64(len(m.ApiKeys))
+	if offset >= total {
+		return []*entities.ApiKey{}, total, nil
+	}
+	end := offset + limit
+	if end > total {
+		end = total
+	}
+	apiKeys := []*entities.ApiKey{}
+	for i := offset; i < end; i++ {
+		k := m.ApiKeys[i]
+		apiKeys = append(apiKeys, &k)
+	}
+	return apiKeys, total, nil
+}
+
+// UpdateApiKey - mock do método UpdateApiKey
+func (m *MockApiKeyRepository) UpdateApiKey(ctx context.Context, k *entities.ApiKey) error {
+	if m.mockError != nil {
+		return m.mockError
+	}
+	for i := range m.ApiKeys {
+		if m.ApiKeys[i].ID == k.ID {
+			m.ApiKeys[i] = *k
+			return nil
+		}
+	}
+	return domainerr.ErrApiKeyNotFound
+}
+
+// TouchLastUsed - mock do método TouchLastUsed
+func (m *MockApiKeyRepository) TouchLastUsed(ctx context.Context, id string, usedAt time.Time) error {
+	if m.mockError != nil {
+		return m.mockError
+	}
+	idUUID, err := uuid.Parse(id)
+	if err != nil {
+		return domainerr.ErrApiKeyIDInvalid
+	}
+	for i := range m.ApiKeys {
+		if m.ApiKeys[i].ID == vo.FromUUID(idUUID) {
+			m.ApiKeys[i].LastUsedAt = &usedAt
+			return nil
+		}
+	}
+	return domainerr.ErrApiKeyNotFound
+}
@@ -0,0 +1,141 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/valdinei-santos/cpf-backend/internal/domain/globalerr"
+	"github.com/valdinei-santos/cpf-backend/internal/infra/logger"
+	"github.com/valdinei-santos/cpf-backend/internal/modules/apikey/domain/domainerr"
+	"github.com/valdinei-santos/cpf-backend/internal/modules/apikey/domain/entities"
+)
+
+// RepoApiKeyMongoDB é um repositório para gerenciar as chaves de API no MongoDB
+type RepoApiKeyMongoDB struct {
+	collection *mongo.Collection
+	log        logger.ILogger
+}
+
+// NewRepoApiKeyMongoDB - cria uma nova instância do repositório
+func NewRepoApiKeyMongoDB(db *mongo.Database, collectionName string, l logger.ILogger) *RepoApiKeyMongoDB {
+	collection := db.Collection(collectionName)
+	return &RepoApiKeyMongoDB{
+		collection: collection,
+		log:        l,
+	}
+}
+
+// EnsureIndexes - cria o índice único do prefixo, usado para localizar a chave em cada requisição
+func (r *RepoApiKeyMongoDB) EnsureIndexes(ctx context.Context) error {
+	_, err := r.collection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "prefix", Value: 1}},
+		Options: options.Index().SetName("uniq_prefix").SetUnique(true),
+	})
+	return err
+}
+
+// AddApiKey - adiciona uma nova chave ao repositório
+func (r *RepoApiKeyMongoDB) AddApiKey(ctx context.Context, k *entities.ApiKey) error {
+	_, err := r.collection.InsertOne(ctx, k)
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return globalerr.ErrDuplicatekey
+		}
+		return err
+	}
+	return nil
+}
+
+// GetApiKeyByID - retorna a chave pelo ID
+func (r *RepoApiKeyMongoDB) GetApiKeyByID(ctx context.Context, id string) (*entities.ApiKey, error) {
+	idBSON, err := apiKeyIDToBSON(id)
+	if err != nil {
+		return nil, err
+	}
+	return r.findOne(ctx, bson.M{"id": idBSON})
+}
+
+// GetApiKeyByPrefix - retorna a chave pelo prefixo do segredo
+func (r *RepoApiKeyMongoDB) GetApiKeyByPrefix(ctx context.Context, prefix string) (*entities.ApiKey, error) {
+	return r.findOne(ctx, bson.M{"prefix": prefix})
+}
+
+// GetAllApiKeys - retorna todas as chaves com paginação, das mais recentes para as mais antigas
+func (r *RepoApiKeyMongoDB) GetAllApiKeys(ctx context.Context, offset int64, limit int64) ([]*entities.ApiKey, int64, error) {
+	total, err := r.collection.CountDocuments(ctx, bson.M{})
+	if err != nil {
+		return nil, 0, err
+	}
+	if offset >= total {
+		return []*entities.ApiKey{}, total, nil
+	}
+
+	findOptions := options.Find().
+		SetSort(bson.D{{Key: "created_at", Value: -1}}).
+		SetSkip(offset).
+		SetLimit(limit)
+	cursor, err := r.collection.Find(ctx, bson.M{}, findOptions)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer cursor.Close(ctx)
+
+	apiKeys := []*entities.ApiKey{}
+	if err = cursor.All(ctx, &apiKeys); err != nil {
+		return nil, 0, err
+	}
+	return apiKeys, total, nil
+}
+
+// UpdateApiKey - grava as alterações da chave (rotação e revogação)
+func (r *RepoApiKeyMongoDB) UpdateApiKey(ctx context.Context, k *entities.ApiKey) error {
+	result, err := r.collection.ReplaceOne(ctx, bson.M{"id": k.ID}, k)
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return globalerr.ErrDuplicatekey
+		}
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return domainerr.ErrApiKeyNotFound
+	}
+	return nil
+}
+
+// TouchLastUsed - atualiza a data do último uso da chave
+func (r *RepoApiKeyMongoDB) TouchLastUsed(ctx context.Context, id string, usedAt time.Time) error {
+	idBSON, err := apiKeyIDToBSON(id)
+	if err != nil {
+		return err
+	}
+	_, err = r.collection.UpdateOne(ctx, bson.M{"id": idBSON}, bson.M{"$set": bson.M{"last_used_at": usedAt}})
+	return err
+}
+
+func (r *RepoApiKeyMongoDB) findOne(ctx context.Context, filter bson.M) (*entities.ApiKey, error) {
+	var k entities.ApiKey
+	err := r.collection.FindOne(ctx, filter).Decode(&k)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, domainerr.ErrApiKeyNotFound
+		}
+		return nil, err
+	}
+	return &k, nil
+}
+
+// apiKeyIDToBSON converte o ID da chave para o formato Binário do Mongo (UUID)
+func apiKeyIDToBSON(id string) (primitive.Binary, error) {
+	idUUID, err := uuid.Parse(id)
+	if err != nil {
+		return primitive.Binary{}, domainerr.ErrApiKeyIDInvalid
+	}
+	return primitive.Binary{Subtype: 4, Data: idUUID[:]}, nil
+}
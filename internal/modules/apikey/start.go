@@ -0,0 +1,46 @@
+package apikey
+
+import (
+	"context"
+
+	"github.com/valdinei-santos/cpf-backend/internal/infra/config"
+	"github.com/valdinei-santos/cpf-backend/internal/infra/logger"
+	"github.com/valdinei-santos/cpf-backend/internal/modules/apikey/infra/controller"
+	"github.com/valdinei-santos/cpf-backend/internal/modules/apikey/infra/repository"
+	"github.com/valdinei-santos/cpf-backend/internal/modules/apikey/usecases/authenticate"
+	"github.com/valdinei-santos/cpf-backend/internal/modules/apikey/usecases/create"
+	"github.com/valdinei-santos/cpf-backend/internal/modules/apikey/usecases/getall"
+	"github.com/valdinei-santos/cpf-backend/internal/modules/apikey/usecases/revoke"
+	"github.com/valdinei-santos/cpf-backend/internal/modules/apikey/usecases/rotate"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// ModuleApiKey contém o controller das rotas administrativas e o caso de uso de autenticação
+// usado pelo middleware do header X-API-Key.
+type ModuleApiKey struct {
+	Controller    *controller.ApiKeyController
+	Authenticator authenticate.IUsecase
+}
+
+// NewModuleApiKey - Inicializa TODAS as dependências do módulo uma única vez.
+func NewModuleApiKey(log logger.ILogger, db *mongo.Database, cfg *config.Config) *ModuleApiKey {
+	log.Info("Inicializando Módulo ApiKey...")
+
+	repo := repository.NewRepoApiKeyMongoDB(db, cfg.Mongo.Collections.ApiKey, log)
+	if err := repo.EnsureIndexes(context.Background()); err != nil {
+		log.Error("Erro ao criar os índices da collection "+cfg.Mongo.Collections.ApiKey, "err", err.Error())
+	}
+
+	apiKeyController := controller.NewApiKeyController(
+		log,
+		create.NewUseCase(repo, log),
+		getall.NewUseCase(repo, log),
+		rotate.NewUseCase(repo, log),
+		revoke.NewUseCase(repo, log),
+	)
+
+	return &ModuleApiKey{
+		Controller:    apiKeyController,
+		Authenticator: authenticate.NewUseCase(repo, cfg.Tenant.Claim, log),
+	}
+}
@@ -0,0 +1,12 @@
+package authenticate
+
+import (
+	"context"
+
+	"github.com/valdinei-santos/cpf-backend/internal/infra/identity"
+)
+
+// IUsecase - ...
+type IUsecase interface {
+	Execute(ctx context.Context, secret string) (*identity.Identity, error)
+}
@@ -0,0 +1,91 @@
+package authenticate
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/valdinei-santos/cpf-backend/internal/domain/globalerr"
+	"github.com/valdinei-santos/cpf-backend/internal/infra/identity"
+	"github.com/valdinei-santos/cpf-backend/internal/infra/logger"
+	"github.com/valdinei-santos/cpf-backend/internal/infra/tracing"
+	"github.com/valdinei-santos/cpf-backend/internal/modules/apikey/domain/domainerr"
+	"github.com/valdinei-santos/cpf-backend/internal/modules/apikey/domain/entities"
+	"github.com/valdinei-santos/cpf-backend/internal/modules/apikey/infra/repository"
+)
+
+// lastUsedInterval evita uma escrita no banco a cada requisição de um job que chama a API em sequência
+const lastUsedInterval = time.Minute
+
+// UseCase - Estrutura para o caso de uso de autenticação pela chave de API
+type UseCase struct {
+	repo        repository.IApiKeyRepository
+	tenantClaim string
+	log         logger.ILogger
+	now         func() time.Time
+}
+
+// NewUseCase - Construtor do caso de uso. O tenant da chave é exposto na claim tenantClaim,
+// a mesma lida do token JWT.
+func NewUseCase(r repository.IApiKeyRepository, tenantClaim string, l logger.ILogger) *UseCase {
+	return &UseCase{
+		repo:        r,
+		tenantClaim: tenantClaim,
+		log:         l,
+		now:         time.Now,
+	}
+}
+
+// Execute - valida o segredo informado no header X-API-Key e retorna a identidade da chave,
+// com os escopos da chave e o responsável como subject. Chave inexistente, revogada ou expirada
+// retorna globalerr.ErrUnauthorized, o motivo fica apenas no log.
+func (u *UseCase) Execute(ctx context.Context, secret string) (*identity.Identity, error) {
+	u.log.DebugContext(ctx, "Entrou apikey.authenticate.Execute")
+	ctx, span := tracing.Start(ctx, "apikey.authenticate.Execute")
+	defer span.End()
+
+	prefix, err := entities.ParsePrefix(secret)
+	if err != nil {
+		u.log.ErrorContext(ctx, err.Error(), "mtd", "entities.ParsePrefix")
+		return nil, globalerr.ErrUnauthorized
+	}
+
+	k, err := u.repo.GetApiKeyByPrefix(ctx, prefix)
+	if err != nil {
+		u.log.ErrorContext(ctx, err.Error(), "mtd", "u.repo.GetApiKeyByPrefix")
+		if errors.Is(err, domainerr.ErrApiKeyNotFound) {
+			return nil, globalerr.ErrUnauthorized
+		}
+		return nil, err
+	}
+
+	now := u.now()
+	err = k.Validate(secret, now)
+	if err != nil {
+		u.log.ErrorContext(ctx, err.Error(), "mtd", "k.Validate", "id", k.ID.String())
+		return nil, globalerr.ErrUnauthorized
+	}
+
+	// Falha ao gravar o último uso não impede a requisição
+	if k.LastUsedAt == nil || now.Sub(*k.LastUsedAt) >= lastUsedInterval {
+		if err := u.repo.TouchLastUsed(ctx, k.ID.String(), now); err != nil {
+			u.log.WarnContext(ctx, "Erro ao atualizar o último uso da chave de API", "id", k.ID.String(), "err", err.Error())
+		}
+	}
+
+	claims := map[string]any{
+		"api_key_id":   k.ID.String(),
+		"api_key_nome": k.Nome.String(),
+	}
+	if k.Tenant != "" {
+		claims[u.tenantClaim] = k.Tenant.String()
+	}
+
+	return &identity.Identity{
+		Subject: k.Owner.String(),
+		Roles:   []string{},
+		Scopes:  k.Scopes.Strings(),
+		Claims:  claims,
+		Method:  "api_key",
+	}, nil
+}
@@ -0,0 +1,127 @@
+package authenticate_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/valdinei-santos/cpf-backend/internal/domain/globalerr"
+	"github.com/valdinei-santos/cpf-backend/internal/infra/logger"
+	"github.com/valdinei-santos/cpf-backend/internal/modules/apikey/infra/repository"
+	"github.com/valdinei-santos/cpf-backend/internal/modules/apikey/usecases/authenticate"
+)
+
+func TestExecute(t *testing.T) {
+	mockRepo := repository.NewMockApiKeyRepository()
+	expirada := repository.NewMockApiKeyRepository()
+	ontem := time.Now().Add(-24 * time.Hour)
+	expirada.ApiKeys[0].ExpiresAt = &ontem
+	comTenant := repository.NewMockApiKeyRepository()
+	comTenant.ApiKeys[0].Tenant = "loja_sul"
+
+	tests := []struct {
+		name        string
+		repo        *repository.MockApiKeyRepository
+		logger      *logger.MockILogger
+		secret      string
+		tenant      string
+		expectedErr error
+		expectDebug bool
+		expectError bool
+	}{
+		{
+			name:        "Deve retornar a identidade com os escopos da chave",
+			repo:        mockRepo,
+			logger:      logger.NewMockILogger(),
+			secret:      mockRepo.Secrets[0],
+			expectDebug: true,
+			expectError: false,
+		},
+		{
+			name:        "Deve retornar o tenant da chave na claim de tenant",
+			repo:        comTenant,
+			logger:      logger.NewMockILogger(),
+			secret:      comTenant.Secrets[0],
+			tenant:      "loja_sul",
+			expectDebug: true,
+			expectError: false,
+		},
+		{
+			name:        "Deve rejeitar chave revogada",
+			repo:        mockRepo,
+			logger:      logger.NewMockILogger(),
+			secret:      mockRepo.Secrets[1],
+			expectedErr: globalerr.ErrUnauthorized,
+			expectDebug: true,
+			expectError: true,
+		},
+		{
+			name:        "Deve rejeitar chave expirada",
+			repo:        expirada,
+			logger:      logger.NewMockILogger(),
+			secret:      expirada.Secrets[0],
+			expectedErr: globalerr.ErrUnauthorized,
+			expectDebug: true,
+			expectError: true,
+		},
+		{
+			name:        "Deve rejeitar segredo com prefixo válido e parte aleatória errada",
+			repo:        mockRepo,
+			logger:      logger.NewMockILogger(),
+			secret:      mockRepo.Secrets[0][:18] + "outro-segredo",
+			expectedErr: globalerr.ErrUnauthorized,
+			expectDebug: true,
+			expectError: true,
+		},
+		{
+			name:        "Deve rejeitar segredo em formato inválido",
+			repo:        mockRepo,
+			logger:      logger.NewMockILogger(),
+			secret:      "qualquer-coisa",
+			expectedErr: globalerr.ErrUnauthorized,
+			expectDebug: true,
+			expectError: true,
+		},
+		{
+			name: "Deve repassar o erro quando o repositório falha",
+			repo: func() *repository.MockApiKeyRepository {
+				r := repository.NewMockApiKeyRepository()
+				r.SetMockError(errors.New("erro de conexão com o banco de dados"))
+				return r
+			}(),
+			logger:      logger.NewMockILogger(),
+			secret:      mockRepo.Secrets[0],
+			expectedErr: errors.New("erro de conexão com o banco de dados"),
+			expectDebug: true,
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc := authenticate.NewUseCase(tt.repo, "tenant", tt.logger)
+
+			id, err := uc.Execute(context.Background(), tt.secret)
+
+			if tt.expectedErr != nil {
+				assert.EqualError(t, err, tt.expectedErr.Error())
+				assert.Nil(t, id)
+			} else {
+				assert.Nil(t, err)
+				assert.Equal(t, "time-batch", id.Subject)
+				assert.Equal(t, []string{"cliente:read"}, id.Scopes)
+				assert.Equal(t, "api_key", id.Method)
+				assert.NotNil(t, tt.repo.ApiKeys[0].LastUsedAt)
+				if tt.tenant != "" {
+					assert.Equal(t, tt.tenant, id.Claims["tenant"])
+				} else {
+					assert.NotContains(t, id.Claims, "tenant")
+				}
+			}
+			assert.Equal(t, tt.expectDebug, tt.logger.DebugCalled)
+			assert.Equal(t, tt.expectError, tt.logger.ErrorCalled)
+		})
+	}
+}
@@ -0,0 +1,64 @@
+package create
+
+import (
+	"context"
+	"errors"
+
+	"github.com/valdinei-santos/cpf-backend/internal/domain/globalerr"
+	"github.com/valdinei-santos/cpf-backend/internal/infra/logger"
+	"github.com/valdinei-santos/cpf-backend/internal/infra/tracing"
+	"github.com/valdinei-santos/cpf-backend/internal/modules/apikey/domain/entities"
+	"github.com/valdinei-santos/cpf-backend/internal/modules/apikey/infra/dto"
+	"github.com/valdinei-santos/cpf-backend/internal/modules/apikey/infra/repository"
+)
+
+// UseCase - Estrutura para o caso de uso de criação de chave de API
+type UseCase struct {
+	repo repository.IApiKeyRepository
+	log  logger.ILogger
+}
+
+// NewUseCase - Construtor do caso de uso
+func NewUseCase(r repository.IApiKeyRepository, l logger.ILogger) *UseCase {
+	return &UseCase{
+		repo: r,
+		log:  l,
+	}
+}
+
+// @Summary      Cria uma chave de API
+// @Description  Cria uma chave de API para clientes serviço a serviço. O segredo é exibido somente nesta resposta.
+// @Tags         apikeys
+// @Accept       json
+// @Produce      json
+// @Param        apikey body dto.Request true "Dados da chave"
+// @Param        Accept-Language header string false "Idioma das mensagens de erro: pt-BR (padrão) ou en-US" Enums(pt-BR, en-US)
+// @Success      201 {object} dto.SecretResponse
+// @Failure      400 {object} problem.Problem "Erro na requisição"
+// @Failure      403 {object} problem.Problem "Permissão insuficiente"
+// @Failure      422 {object} problem.Problem "Campos inválidos, todos listados em errors"
+// @Router       /apikey [post]
+// Execute - Executa a lógica de criação de uma chave de API
+func (u *UseCase) Execute(ctx context.Context, in *dto.Request) (*dto.SecretResponse, error) {
+	u.log.DebugContext(ctx, "Entrou apikey.create.Execute")
+	ctx, span := tracing.Start(ctx, "apikey.create.Execute")
+	defer span.End()
+
+	k, secret, err := entities.NewApiKey(in.Nome, in.Owner, in.Tenant, in.Scopes, in.ExpiresAt)
+	if err != nil {
+		u.log.ErrorContext(ctx, err.Error(), "mtd", "entities.NewApiKey")
+		return nil, err
+	}
+
+	err = u.repo.AddApiKey(ctx, k)
+	if err != nil {
+		u.log.ErrorContext(ctx, err.Error(), "mtd", "u.repo.AddApiKey")
+		if errors.Is(err, globalerr.ErrDuplicatekey) {
+			return nil, globalerr.ErrDuplicatekey
+		}
+		return nil, globalerr.ErrInternal
+	}
+	u.log.InfoContext(ctx, "Chave de API criada", "id", k.ID.String(), "nome", k.Nome.String(), "owner", k.Owner.String())
+
+	return &dto.SecretResponse{Response: dto.NewResponse(k), Secret: secret}, nil
+}
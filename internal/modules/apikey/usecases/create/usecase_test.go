@@ -0,0 +1,96 @@
+package create_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/valdinei-santos/cpf-backend/internal/domain/globalerr"
+	"github.com/valdinei-santos/cpf-backend/internal/infra/logger"
+	"github.com/valdinei-santos/cpf-backend/internal/modules/apikey/domain/domainerr"
+	"github.com/valdinei-santos/cpf-backend/internal/modules/apikey/infra/dto"
+	"github.com/valdinei-santos/cpf-backend/internal/modules/apikey/infra/repository"
+	"github.com/valdinei-santos/cpf-backend/internal/modules/apikey/usecases/create"
+)
+
+func TestExecute(t *testing.T) {
+	amanha := time.Now().Add(24 * time.Hour)
+	ontem := time.Now().Add(-24 * time.Hour)
+
+	tests := []struct {
+		name        string
+		repo        *repository.MockApiKeyRepository
+		logger      *logger.MockILogger
+		input       *dto.Request
+		expectedErr error
+		expectDebug bool
+		expectError bool
+	}{
+		{
+			name:        "Deve criar a chave e retornar o segredo",
+			repo:        repository.NewMockApiKeyRepository(),
+			logger:      logger.NewMockILogger(),
+			input:       &dto.Request{Nome: "Job Campanha", Owner: "time-marketing", Scopes: []string{"cliente:read", "cliente:read"}, ExpiresAt: &amanha},
+			expectedErr: nil,
+			expectDebug: true,
+			expectError: false,
+		},
+		{
+			name:        "Deve retornar erro quando não há escopos",
+			repo:        repository.NewMockApiKeyRepository(),
+			logger:      logger.NewMockILogger(),
+			input:       &dto.Request{Nome: "Job Campanha", Owner: "time-marketing"},
+			expectedErr: domainerr.ErrApiKeyScopeInvalid,
+			expectDebug: true,
+			expectError: true,
+		},
+		{
+			name:        "Deve retornar erro quando a expiração está no passado",
+			repo:        repository.NewMockApiKeyRepository(),
+			logger:      logger.NewMockILogger(),
+			input:       &dto.Request{Nome: "Job Campanha", Owner: "time-marketing", Scopes: []string{"cliente:read"}, ExpiresAt: &ontem},
+			expectedErr: domainerr.ErrApiKeyExpiracaoInvalid,
+			expectDebug: true,
+			expectError: true,
+		},
+		{
+			name: "Deve retornar erro interno quando o repositório falha",
+			repo: func() *repository.MockApiKeyRepository {
+				r := repository.NewMockApiKeyRepository()
+				r.SetMockError(globalerr.ErrSaveInDatabase)
+				return r
+			}(),
+			logger:      logger.NewMockILogger(),
+			input:       &dto.Request{Nome: "Job Campanha", Owner: "time-marketing", Scopes: []string{"cliente:read"}},
+			expectedErr: globalerr.ErrInternal,
+			expectDebug: true,
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc := create.NewUseCase(tt.repo, tt.logger)
+
+			resp, err := uc.Execute(context.Background(), tt.input)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				assert.Nil(t, resp)
+			} else {
+				assert.Nil(t, err)
+				assert.True(t, strings.HasPrefix(resp.Secret, "cpfk_"+resp.Prefix+"_"))
+				assert.Equal(t, []string{"cliente:read"}, resp.Scopes)
+				assert.True(t, resp.Ativa)
+				// O segredo não é gravado, apenas o hash
+				gravada := tt.repo.ApiKeys[len(tt.repo.ApiKeys)-1]
+				assert.NotEqual(t, resp.Secret, gravada.Hash)
+				assert.Nil(t, gravada.Validate(resp.Secret, time.Now()))
+			}
+			assert.Equal(t, tt.expectDebug, tt.logger.DebugCalled)
+			assert.Equal(t, tt.expectError, tt.logger.ErrorCalled)
+		})
+	}
+}
@@ -0,0 +1,68 @@
+package getall
+
+import (
+	"context"
+	"math"
+
+	"github.com/valdinei-santos/cpf-backend/internal/infra/logger"
+	"github.com/valdinei-santos/cpf-backend/internal/infra/tracing"
+	"github.com/valdinei-santos/cpf-backend/internal/modules/apikey/infra/dto"
+	"github.com/valdinei-santos/cpf-backend/internal/modules/apikey/infra/repository"
+)
+
+// UseCase - Estrutura para o caso de uso de listagem das chaves de API
+type UseCase struct {
+	repo repository.IApiKeyRepository
+	log  logger.ILogger
+}
+
+// NewUseCase - Construtor do caso de uso
+func NewUseCase(r repository.IApiKeyRepository, l logger.ILogger) *UseCase {
+	return &UseCase{
+		repo: r,
+		log:  l,
+	}
+}
+
+// @Summary        Lista as chaves de API
+// @Description    Retorna as chaves de API, paginadas. Os segredos nunca são retornados.
+// @Tags           apikeys
+// @Produce        json
+// @Param          page query int64 false "Numero da página a ser retornada"
+// @Param          size query int64 false "Quantidade de itens na página a ser retornada"
+// @Param        Accept-Language header string false "Idioma das mensagens de erro: pt-BR (padrão) ou en-US" Enums(pt-BR, en-US)
+// @Success        200 {object} dto.ResponseManyPaginated
+// @Failure        403 {object} problem.Problem "Permissão insuficiente"
+// @Failure        500 {object} problem.Problem "Erro interno do servidor"
+// @Router         /apikey [get]
+// Execute - Executa a lógica para listar as chaves de API
+func (u *UseCase) Execute(ctx context.Context, page int64, size int64) (*dto.ResponseManyPaginated, error) {
+	u.log.DebugContext(ctx, "Entrou apikey.getall.Execute")
+	ctx, span := tracing.Start(ctx, "apikey.getall.Execute")
+	defer span.End()
+
+	offset := (page - 1) * size
+	apiKeys, totalItems, err := u.repo.GetAllApiKeys(ctx, offset, size)
+	if err != nil {
+		u.log.ErrorContext(ctx, err.Error(), "mtd", "u.repo.GetAllApiKeys")
+		return nil, err
+	}
+
+	list := make([]dto.Response, len(apiKeys))
+	for i, k := range apiKeys {
+		list[i] = dto.NewResponse(k)
+	}
+
+	totalPages := int(math.Ceil(float64(totalItems) / float64(size)))
+	if totalPages == 0 && totalItems > 0 {
+		totalPages = 1
+	}
+
+	return &dto.ResponseManyPaginated{
+		ApiKeys:      list,
+		TotalItems:   totalItems,
+		TotalPages:   int64(totalPages),
+		CurrentPage:  page,
+		ItemsPerPage: size,
+	}, nil
+}
@@ -0,0 +1,93 @@
+package getall_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/valdinei-santos/cpf-backend/internal/infra/logger"
+	"github.com/valdinei-santos/cpf-backend/internal/modules/apikey/infra/dto"
+	"github.com/valdinei-santos/cpf-backend/internal/modules/apikey/infra/repository"
+	"github.com/valdinei-santos/cpf-backend/internal/modules/apikey/usecases/getall"
+)
+
+func TestExecute(t *testing.T) {
+	mockRepo := repository.NewMockApiKeyRepository()
+
+	tests := []struct {
+		name         string
+		repo         *repository.MockApiKeyRepository
+		logger       *logger.MockILogger
+		page         int64
+		size         int64
+		expectedResp *dto.ResponseManyPaginated
+		expectedErr  error
+		expectDebug  bool
+		expectError  bool
+	}{
+		{
+			name:   "Deve retornar a primeira página sem os segredos",
+			repo:   mockRepo,
+			logger: logger.NewMockILogger(),
+			page:   1,
+			size:   1,
+			expectedResp: &dto.ResponseManyPaginated{
+				ApiKeys:      []dto.Response{dto.NewResponse(&mockRepo.ApiKeys[0])},
+				TotalItems:   2,
+				TotalPages:   2,
+				CurrentPage:  1,
+				ItemsPerPage: 1,
+			},
+			expectDebug: true,
+			expectError: false,
+		},
+		{
+			name:   "Deve retornar lista vazia para página além do total",
+			repo:   mockRepo,
+			logger: logger.NewMockILogger(),
+			page:   3,
+			size:   10,
+			expectedResp: &dto.ResponseManyPaginated{
+				ApiKeys:      []dto.Response{},
+				TotalItems:   2,
+				TotalPages:   1,
+				CurrentPage:  3,
+				ItemsPerPage: 10,
+			},
+			expectDebug: true,
+			expectError: false,
+		},
+		{
+			name: "Deve retornar erro quando o repositório falha",
+			repo: func() *repository.MockApiKeyRepository {
+				r := repository.NewMockApiKeyRepository()
+				r.SetMockError(errors.New("erro de conexão com o banco de dados"))
+				return r
+			}(),
+			logger:      logger.NewMockILogger(),
+			page:        1,
+			size:        10,
+			expectedErr: errors.New("erro de conexão com o banco de dados"),
+			expectDebug: true,
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc := getall.NewUseCase(tt.repo, tt.logger)
+
+			resp, err := uc.Execute(context.Background(), tt.page, tt.size)
+
+			assert.Equal(t, tt.expectedResp, resp)
+			if tt.expectedErr != nil {
+				assert.EqualError(t, err, tt.expectedErr.Error())
+			} else {
+				assert.Nil(t, err)
+			}
+			assert.Equal(t, tt.expectDebug, tt.logger.DebugCalled)
+			assert.Equal(t, tt.expectError, tt.logger.ErrorCalled)
+		})
+	}
+}
@@ -0,0 +1,64 @@
+package revoke
+
+import (
+	"context"
+
+	"github.com/valdinei-santos/cpf-backend/internal/infra/logger"
+	"github.com/valdinei-santos/cpf-backend/internal/infra/tracing"
+	"github.com/valdinei-santos/cpf-backend/internal/modules/apikey/infra/dto"
+	"github.com/valdinei-santos/cpf-backend/internal/modules/apikey/infra/repository"
+)
+
+// UseCase - Estrutura para o caso de uso de revogação da chave de API
+type UseCase struct {
+	repo repository.IApiKeyRepository
+	log  logger.ILogger
+}
+
+// NewUseCase - Construtor do caso de uso
+func NewUseCase(r repository.IApiKeyRepository, l logger.ILogger) *UseCase {
+	return &UseCase{
+		repo: r,
+		log:  l,
+	}
+}
+
+// @Summary      Revoga uma chave de API
+// @Description  Revoga a chave. O registro é mantido para auditoria e a chave não pode ser reativada.
+// @Tags         apikeys
+// @Produce      json
+// @Param        id path string true "ID da chave"
+// @Param        Accept-Language header string false "Idioma das mensagens de erro: pt-BR (padrão) ou en-US" Enums(pt-BR, en-US)
+// @Success      200 {object} dto.Response
+// @Failure      403 {object} problem.Problem "Permissão insuficiente"
+// @Failure      404 {object} problem.Problem "Chave não encontrada"
+// @Failure      409 {object} problem.Problem "Chave já revogada"
+// @Router       /apikey/{id}/revogar [post]
+// Execute - Executa a lógica de revogação da chave
+func (u *UseCase) Execute(ctx context.Context, id string) (*dto.Response, error) {
+	u.log.DebugContext(ctx, "Entrou apikey.revoke.Execute")
+	ctx, span := tracing.Start(ctx, "apikey.revoke.Execute")
+	defer span.End()
+
+	k, err := u.repo.GetApiKeyByID(ctx, id)
+	if err != nil {
+		u.log.ErrorContext(ctx, err.Error(), "mtd", "u.repo.GetApiKeyByID")
+		return nil, err
+	}
+
+	err = k.Revoke()
+	if err != nil {
+		u.log.ErrorContext(ctx, err.Error(), "mtd", "k.Revoke")
+		return nil, err
+	}
+
+	err = u.repo.UpdateApiKey(ctx, k)
+	if err != nil {
+		u.log.ErrorContext(ctx, err.Error(), "mtd", "u.repo.UpdateApiKey")
+		return nil, err
+	}
+	u.log.InfoContext(ctx, "Chave de API revogada", "id", k.ID.String(), "nome", k.Nome.String())
+
+	resp := dto.NewResponse(k)
+	return &resp, nil
+}
@@ -0,0 +1,75 @@
+package revoke_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/valdinei-santos/cpf-backend/internal/infra/logger"
+	"github.com/valdinei-santos/cpf-backend/internal/modules/apikey/domain/domainerr"
+	"github.com/valdinei-santos/cpf-backend/internal/modules/apikey/infra/repository"
+	"github.com/valdinei-santos/cpf-backend/internal/modules/apikey/usecases/revoke"
+)
+
+func TestExecute(t *testing.T) {
+	mockRepo := repository.NewMockApiKeyRepository()
+
+	tests := []struct {
+		name        string
+		repo        *repository.MockApiKeyRepository
+		logger      *logger.MockILogger
+		inputID     string
+		expectedErr error
+		expectDebug bool
+		expectError bool
+	}{
+		{
+			name:        "Deve revogar a chave",
+			repo:        mockRepo,
+			logger:      logger.NewMockILogger(),
+			inputID:     mockRepo.ApiKeys[0].ID.String(),
+			expectDebug: true,
+			expectError: false,
+		},
+		{
+			name:        "Deve retornar erro quando a chave já está revogada",
+			repo:        mockRepo,
+			logger:      logger.NewMockILogger(),
+			inputID:     mockRepo.ApiKeys[1].ID.String(),
+			expectedErr: domainerr.ErrApiKeyRevogada,
+			expectDebug: true,
+			expectError: true,
+		},
+		{
+			name:        "Deve retornar erro quando a chave não existe",
+			repo:        mockRepo,
+			logger:      logger.NewMockILogger(),
+			inputID:     uuid.New().String(),
+			expectedErr: domainerr.ErrApiKeyNotFound,
+			expectDebug: true,
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc := revoke.NewUseCase(tt.repo, tt.logger)
+
+			resp, err := uc.Execute(context.Background(), tt.inputID)
+
+			if tt.expectedErr != nil {
+				assert.EqualError(t, err, tt.expectedErr.Error())
+				assert.Nil(t, resp)
+			} else {
+				assert.Nil(t, err)
+				assert.False(t, resp.Ativa)
+				assert.NotNil(t, resp.RevokedAt)
+				assert.ErrorIs(t, tt.repo.ApiKeys[0].Validate(tt.repo.Secrets[0], time.Now()), domainerr.ErrApiKeyRevogada)
+			}
+			assert.Equal(t, tt.expectDebug, tt.logger.DebugCalled)
+			assert.Equal(t, tt.expectError, tt.logger.ErrorCalled)
+		})
+	}
+}
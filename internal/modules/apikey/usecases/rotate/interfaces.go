@@ -0,0 +1,16 @@
+package rotate
+
+import (
+	"context"
+
+	"github.com/valdinei-santos/cpf-backend/internal/infra/rbac"
+	"github.com/valdinei-santos/cpf-backend/internal/modules/apikey/infra/dto"
+)
+
+// Permission - permissão exigida na rota do caso de uso
+const Permission rbac.Permission = "apikey:manage"
+
+// IUsecase - ...
+type IUsecase interface {
+	Execute(ctx context.Context, id string) (*dto.SecretResponse, error)
+}
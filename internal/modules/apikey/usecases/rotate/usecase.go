@@ -0,0 +1,63 @@
+package rotate
+
+import (
+	"context"
+
+	"github.com/valdinei-santos/cpf-backend/internal/infra/logger"
+	"github.com/valdinei-santos/cpf-backend/internal/infra/tracing"
+	"github.com/valdinei-santos/cpf-backend/internal/modules/apikey/infra/dto"
+	"github.com/valdinei-santos/cpf-backend/internal/modules/apikey/infra/repository"
+)
+
+// UseCase - Estrutura para o caso de uso de rotação do segredo da chave de API
+type UseCase struct {
+	repo repository.IApiKeyRepository
+	log  logger.ILogger
+}
+
+// NewUseCase - Construtor do caso de uso
+func NewUseCase(r repository.IApiKeyRepository, l logger.ILogger) *UseCase {
+	return &UseCase{
+		repo: r,
+		log:  l,
+	}
+}
+
+// @Summary      Rotaciona o segredo de uma chave de API
+// @Description  Gera um novo segredo para a chave. O segredo anterior deixa de ser aceito imediatamente.
+// @Tags         apikeys
+// @Produce      json
+// @Param        id path string true "ID da chave"
+// @Param        Accept-Language header string false "Idioma das mensagens de erro: pt-BR (padrão) ou en-US" Enums(pt-BR, en-US)
+// @Success      200 {object} dto.SecretResponse
+// @Failure      403 {object} problem.Problem "Permissão insuficiente"
+// @Failure      404 {object} problem.Problem "Chave não encontrada"
+// @Failure      409 {object} problem.Problem "Chave revogada"
+// @Router       /apikey/{id}/rotacionar [post]
+// Execute - Executa a lógica de rotação do segredo
+func (u *UseCase) Execute(ctx context.Context, id string) (*dto.SecretResponse, error) {
+	u.log.DebugContext(ctx, "Entrou apikey.rotate.Execute")
+	ctx, span := tracing.Start(ctx, "apikey.rotate.Execute")
+	defer span.End()
+
+	k, err := u.repo.GetApiKeyByID(ctx, id)
+	if err != nil {
+		u.log.ErrorContext(ctx, err.Error(), "mtd", "u.repo.GetApiKeyByID")
+		return nil, err
+	}
+
+	secret, err := k.Rotate()
+	if err != nil {
+		u.log.ErrorContext(ctx, err.Error(), "mtd", "k.Rotate")
+		return nil, err
+	}
+
+	err = u.repo.UpdateApiKey(ctx, k)
+	if err != nil {
+		u.log.ErrorContext(ctx, err.Error(), "mtd", "u.repo.UpdateApiKey")
+		return nil, err
+	}
+	u.log.InfoContext(ctx, "Chave de API rotacionada", "id", k.ID.String(), "nome", k.Nome.String())
+
+	return &dto.SecretResponse{Response: dto.NewResponse(k), Secret: secret}, nil
+}
@@ -0,0 +1,86 @@
+package rotate_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/valdinei-santos/cpf-backend/internal/infra/logger"
+	"github.com/valdinei-santos/cpf-backend/internal/modules/apikey/domain/domainerr"
+	"github.com/valdinei-santos/cpf-backend/internal/modules/apikey/infra/repository"
+	"github.com/valdinei-santos/cpf-backend/internal/modules/apikey/usecases/rotate"
+)
+
+func TestExecute(t *testing.T) {
+	mockRepo := repository.NewMockApiKeyRepository()
+
+	tests := []struct {
+		name        string
+		repo        *repository.MockApiKeyRepository
+		logger      *logger.MockILogger
+		inputID     string
+		expectedErr error
+		expectDebug bool
+		expectError bool
+	}{
+		{
+			name:        "Deve gerar um novo segredo e invalidar o anterior",
+			repo:        mockRepo,
+			logger:      logger.NewMockILogger(),
+			inputID:     mockRepo.ApiKeys[0].ID.String(),
+			expectDebug: true,
+			expectError: false,
+		},
+		{
+			name:        "Deve retornar erro quando a chave está revogada",
+			repo:        mockRepo,
+			logger:      logger.NewMockILogger(),
+			inputID:     mockRepo.ApiKeys[1].ID.String(),
+			expectedErr: domainerr.ErrApiKeyRevogada,
+			expectDebug: true,
+			expectError: true,
+		},
+		{
+			name:        "Deve retornar erro quando a chave não existe",
+			repo:        mockRepo,
+			logger:      logger.NewMockILogger(),
+			inputID:     uuid.New().String(),
+			expectedErr: domainerr.ErrApiKeyNotFound,
+			expectDebug: true,
+			expectError: true,
+		},
+		{
+			name:        "Deve retornar erro quando o ID é inválido",
+			repo:        mockRepo,
+			logger:      logger.NewMockILogger(),
+			inputID:     "id-invalido",
+			expectedErr: domainerr.ErrApiKeyIDInvalid,
+			expectDebug: true,
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc := rotate.NewUseCase(tt.repo, tt.logger)
+			oldSecret := tt.repo.Secrets[0]
+
+			resp, err := uc.Execute(context.Background(), tt.inputID)
+
+			if tt.expectedErr != nil {
+				assert.EqualError(t, err, tt.expectedErr.Error())
+				assert.Nil(t, resp)
+			} else {
+				assert.Nil(t, err)
+				assert.NotEqual(t, oldSecret, resp.Secret)
+				gravada := tt.repo.ApiKeys[0]
+				assert.Nil(t, gravada.Validate(resp.Secret, time.Now()))
+				assert.ErrorIs(t, gravada.Validate(oldSecret, time.Now()), domainerr.ErrApiKeyInvalida)
+			}
+			assert.Equal(t, tt.expectDebug, tt.logger.DebugCalled)
+			assert.Equal(t, tt.expectError, tt.logger.ErrorCalled)
+		})
+	}
+}
@@ -15,3 +15,23 @@ var (
 	ErrClienteNotFoundMany     = errors.New("nenhum cliente encontrado")
 	ErrDuplicatekey            = errors.New("registro já existe")
 )
+
+// Erros do consentimento (LGPD) do cliente.
+var (
+	ErrConsentimentoFinalidadeInvalid = errors.New("a finalidade deve ter entre 3 e 50 caracteres minúsculos, números ou _")
+	ErrConsentimentoBaseLegalInvalid  = errors.New("base legal inválida")
+	ErrConsentimentoCanalInvalid      = errors.New("o canal deve ter entre 2 e 30 caracteres")
+	ErrConsentimentoVersaoInvalid     = errors.New("a versão da política deve ter entre 1 e 20 caracteres")
+	ErrConsentimentoNotFound          = errors.New("consentimento não encontrado")
+	ErrConsentimentoJaRevogado        = errors.New("consentimento já está revogado")
+)
+
+// Erros do log de acesso aos dados do cliente.
+var (
+	ErrAcessoOperadorInvalid = errors.New("informe o operador a consultar")
+)
+
+// Erros da verificação da auditoria.
+var (
+	ErrAuditoriaCadeiaInvalid = errors.New("cadeia inválida, use consentimento ou acesso")
+)
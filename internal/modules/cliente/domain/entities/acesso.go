@@ -0,0 +1,89 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/valdinei-santos/cpf-backend/internal/domain/vo"
+)
+
+// Ações registradas no log de acesso
+const (
+	AcessoConsulta     = "consulta"      // Consulta do cliente pelo ID
+	AcessoListagem     = "listagem"      // Página da listagem de clientes
+	AcessoExportacao   = "exportacao"    // Página da exportação de clientes por consentimento
+	AcessoUnmask       = "unmask"        // Pedido de dados sem máscara (?unmask=true) permitido
+	AcessoUnmaskNegado = "unmask_negado" // Pedido de dados sem máscara negado por falta de permissão
+)
+
+// Operador é quem acessou os dados pessoais e de onde
+type Operador struct {
+	Subject    string // Usuário ou serviço autenticado
+	Metodo     string // Forma de autenticação. Ex: jwt, api_key
+	Finalidade string // Finalidade informada pelo chamador
+	IP         string
+}
+
+// Acesso é o registro de uma leitura dos dados pessoais de um cliente (LGPD). Os registros são
+// apenas inseridos, nunca alterados ou removidos pela API.
+type Acesso struct {
+	ID         vo.ID     `bson:"id"`
+	ClienteID  vo.ID     `bson:"cliente_id"`
+	Acao       string    `bson:"acao"`
+	Recurso    string    `bson:"recurso,omitempty"` // Recurso do pedido de unmask. Ex: cliente:<id>, cliente:lista
+	Operador   string    `bson:"operador"`
+	Metodo     string    `bson:"metodo,omitempty"`
+	Finalidade string    `bson:"finalidade,omitempty"`
+	IP         string    `bson:"ip,omitempty"`
+	Completo   bool      `bson:"completo"` // Documento e telefone exibidos sem máscara
+	CreatedAt  time.Time `bson:"created_at"`
+}
+
+// NewAcessos - cria um registro de acesso para cada cliente exibido na resposta
+func NewAcessos(clienteIDs []vo.ID, acao string, op Operador, completo bool) ([]*Acesso, error) {
+	now := time.Now()
+	acessos := make([]*Acesso, 0, len(clienteIDs))
+	for _, clienteID := range clienteIDs {
+		uuidVO, err := uuid.NewUUID()
+		if err != nil {
+			return nil, err
+		}
+		acessos = append(acessos, &Acesso{
+			ID:         vo.FromUUID(uuidVO),
+			ClienteID:  clienteID,
+			Acao:       acao,
+			Operador:   op.Subject,
+			Metodo:     op.Metodo,
+			Finalidade: op.Finalidade,
+			IP:         op.IP,
+			Completo:   completo,
+			CreatedAt:  now,
+		})
+	}
+	return acessos, nil
+}
+
+// NewAcessoUnmask - cria o registro de um pedido de unmask. O clienteID fica vazio quando o pedido
+// não é de um cliente específico. Ex: listagem
+func NewAcessoUnmask(clienteID vo.ID, recurso string, op Operador, permitido bool) (*Acesso, error) {
+	uuidVO, err := uuid.NewUUID()
+	if err != nil {
+		return nil, err
+	}
+	acao := AcessoUnmaskNegado
+	if permitido {
+		acao = AcessoUnmask
+	}
+	return &Acesso{
+		ID:         vo.FromUUID(uuidVO),
+		ClienteID:  clienteID,
+		Acao:       acao,
+		Recurso:    recurso,
+		Operador:   op.Subject,
+		Metodo:     op.Metodo,
+		Finalidade: op.Finalidade,
+		IP:         op.IP,
+		Completo:   permitido,
+		CreatedAt:  time.Now(),
+	}, nil
+}
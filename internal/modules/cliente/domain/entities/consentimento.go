@@ -0,0 +1,102 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/valdinei-santos/cpf-backend/internal/domain/globalerr"
+	domainvo "github.com/valdinei-santos/cpf-backend/internal/domain/vo"
+	"github.com/valdinei-santos/cpf-backend/internal/modules/cliente/domain/domainerr"
+	"github.com/valdinei-santos/cpf-backend/internal/modules/cliente/domain/vo"
+)
+
+// Consentimento representa um registro do histórico de consentimento (LGPD) de um cliente.
+// Cada concessão ou revogação gera um novo registro, o status atual é o registro mais recente da finalidade.
+type Consentimento struct {
+	ID             domainvo.ID                `bson:"id"`
+	ClienteID      domainvo.ID                `bson:"cliente_id"`
+	Finalidade     vo.FinalidadeConsentimento `bson:"finalidade"`
+	BaseLegal      vo.BaseLegal               `bson:"base_legal"`
+	Canal          vo.CanalConsentimento      `bson:"canal"`
+	VersaoPolitica vo.VersaoPolitica          `bson:"versao_politica"`
+	Status         vo.StatusConsentimento     `bson:"status"`
+	ConcedidoEm    time.Time                  `bson:"concedido_em"`
+	RevogadoEm     *time.Time                 `bson:"revogado_em,omitempty"`
+	CreatedAt      time.Time                  `bson:"created_at"`
+}
+
+// NewConsentimento - cria o registro de concessão de um consentimento. Com dados inválidos retorna
+// um *globalerr.ValidationError com todos os campos inválidos
+func NewConsentimento(clienteID, finalidade, baseLegal, canal, versaoPolitica string) (*Consentimento, error) {
+	idCliente, err := uuid.Parse(clienteID)
+	if err != nil {
+		return nil, domainerr.ErrClienteIDInvalid
+	}
+	uuidVO, err := uuid.NewUUID()
+	if err != nil {
+		return nil, err
+	}
+	var v globalerr.Validation
+	finalidadeVO, err := vo.NewFinalidadeConsentimento(finalidade)
+	v.Add("finalidade", err)
+	baseLegalVO, err := vo.NewBaseLegal(baseLegal)
+	v.Add("base_legal", err)
+	canalVO, err := vo.NewCanalConsentimento(canal)
+	v.Add("canal", err)
+	versaoVO, err := vo.NewVersaoPolitica(versaoPolitica)
+	v.Add("versao_politica", err)
+	if err := v.Err(); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	return &Consentimento{
+		ID:             domainvo.FromUUID(uuidVO),
+		ClienteID:      domainvo.FromUUID(idCliente),
+		Finalidade:     finalidadeVO,
+		BaseLegal:      baseLegalVO,
+		Canal:          canalVO,
+		VersaoPolitica: versaoVO,
+		Status:         vo.ConsentimentoConcedido,
+		ConcedidoEm:    now,
+		CreatedAt:      now,
+	}, nil
+}
+
+// Revogar - cria o registro de revogação a partir do consentimento vigente.
+// O registro original não é alterado, mantendo o histórico apenas por inserção. Com o canal
+// inválido retorna um *globalerr.ValidationError.
+func (c *Consentimento) Revogar(canal string) (*Consentimento, error) {
+	if c.Status == vo.ConsentimentoRevogado {
+		return nil, domainerr.ErrConsentimentoJaRevogado
+	}
+	uuidVO, err := uuid.NewUUID()
+	if err != nil {
+		return nil, err
+	}
+	var v globalerr.Validation
+	canalVO, err := vo.NewCanalConsentimento(canal)
+	v.Add("canal", err)
+	if err := v.Err(); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	return &Consentimento{
+		ID:             domainvo.FromUUID(uuidVO),
+		ClienteID:      c.ClienteID,
+		Finalidade:     c.Finalidade,
+		BaseLegal:      c.BaseLegal,
+		Canal:          canalVO,
+		VersaoPolitica: c.VersaoPolitica,
+		Status:         vo.ConsentimentoRevogado,
+		ConcedidoEm:    c.ConcedidoEm,
+		RevogadoEm:     &now,
+		CreatedAt:      now,
+	}, nil
+}
+
+// Ativo - indica se o registro representa um consentimento vigente
+func (c *Consentimento) Ativo() bool {
+	return c.Status == vo.ConsentimentoConcedido
+}
@@ -5,6 +5,8 @@ import (
 
 	"github.com/go-playground/validator/v10"
 	"github.com/google/uuid"
+	"github.com/valdinei-santos/cpf-backend/internal/domain/globalerr"
+	domainvo "github.com/valdinei-santos/cpf-backend/internal/domain/vo"
 	"github.com/valdinei-santos/cpf-backend/internal/modules/cliente/domain/domainerr"
 	"github.com/valdinei-santos/cpf-backend/internal/modules/cliente/domain/vo"
 )
@@ -12,7 +14,7 @@ import (
 // Cliente representa a estrutura de um cliente
 type Cliente struct {
 	//ID        uuid.UUID           `bson:"_id"`
-	ID        vo.ID               `bson:"id"`
+	ID        domainvo.ID         `bson:"id"`
 	Nome      vo.NomeCliente      `bson:"nome"`
 	Documento vo.DocumentoCliente `bson:"documento"`
 	Telefone  vo.TelefoneCliente  `bson:"telefone"`
@@ -21,31 +23,28 @@ type Cliente struct {
 	UpdatedAt time.Time           `bson:"updated_at"`
 }
 
-// NewCliente - cria uma nova instância de Cliente
+// NewCliente - cria uma nova instância de Cliente. Com dados inválidos retorna um
+// *globalerr.ValidationError com todos os campos inválidos
 func NewCliente(nome, documento, telefone string, bloqueado bool) (*Cliente, error) {
 	uuidVO, err := uuid.NewUUID()
 	if err != nil {
 		return nil, err
 	}
+	var v globalerr.Validation
 	nomeVO, err := vo.NewNomeCliente(nome)
-	if err != nil {
-		return nil, err
-	}
+	v.Add("nome", err)
 	documentoVO, err := vo.NewDocumentoCliente(documento)
-	if err != nil {
-		return nil, err
-	}
+	v.Add("documento", err)
 	telefoneVO, err := vo.NewTelefoneCliente(telefone)
-	if err != nil {
-		return nil, err
-	}
+	v.Add("telefone", err)
 	bloqueadoVO, err := vo.NewBloqueadoCliente(bloqueado)
-	if err != nil {
+	v.Add("bloqueado", err)
+	if err := v.Err(); err != nil {
 		return nil, err
 	}
 
 	c := &Cliente{
-		ID:        vo.FromUUID(uuidVO),
+		ID:        domainvo.FromUUID(uuidVO),
 		Nome:      nomeVO,
 		Documento: documentoVO,
 		Telefone:  telefoneVO,
@@ -61,30 +60,27 @@ func NewCliente(nome, documento, telefone string, bloqueado bool) (*Cliente, err
 	return c, nil
 }
 
-// UpdateCliente - altera uma instância de Cliente
+// UpdateCliente - altera uma instância de Cliente. Com dados inválidos retorna um
+// *globalerr.ValidationError com todos os campos inválidos
 func UpdateCliente(id, nome, documento, telefone string, bloqueado bool, createdAt time.Time) (*Cliente, error) {
 	idUUID, err := uuid.Parse(id)
 	if err != nil {
 		return nil, domainerr.ErrClienteIDInvalid
 	}
+	var v globalerr.Validation
 	nomeVO, err := vo.NewNomeCliente(nome)
-	if err != nil {
-		return nil, err
-	}
+	v.Add("nome", err)
 	documentoVO, err := vo.NewDocumentoCliente(documento)
-	if err != nil {
-		return nil, err
-	}
+	v.Add("documento", err)
 	telefoneVO, err := vo.NewTelefoneCliente(telefone)
-	if err != nil {
-		return nil, err
-	}
+	v.Add("telefone", err)
 	bloqueadoVO, err := vo.NewBloqueadoCliente(bloqueado)
-	if err != nil {
+	v.Add("bloqueado", err)
+	if err := v.Err(); err != nil {
 		return nil, err
 	}
 	c := &Cliente{
-		ID:        vo.FromUUID(idUUID),
+		ID:        domainvo.FromUUID(idUUID),
 		Nome:      nomeVO,
 		Documento: documentoVO,
 		Telefone:  telefoneVO,
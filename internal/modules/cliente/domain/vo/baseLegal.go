@@ -0,0 +1,43 @@
+package vo
+
+import "github.com/valdinei-santos/cpf-backend/internal/modules/cliente/domain/domainerr"
+
+// BaseLegal representa a hipótese de tratamento de dados prevista no art. 7º da LGPD.
+type BaseLegal string
+
+const (
+	BaseLegalConsentimento     BaseLegal = "consentimento"
+	BaseLegalObrigacaoLegal    BaseLegal = "obrigacao_legal"
+	BaseLegalExecucaoContrato  BaseLegal = "execucao_contrato"
+	BaseLegalExercicioDireitos BaseLegal = "exercicio_direitos"
+	BaseLegalLegitimoInteresse BaseLegal = "legitimo_interesse"
+	BaseLegalProtecaoCredito   BaseLegal = "protecao_credito"
+	BaseLegalPoliticasPublicas BaseLegal = "politicas_publicas"
+	BaseLegalEstudosPesquisa   BaseLegal = "estudos_pesquisa"
+	BaseLegalProtecaoVida      BaseLegal = "protecao_vida"
+	BaseLegalTutelaSaude       BaseLegal = "tutela_saude"
+)
+
+var basesLegais = map[BaseLegal]bool{
+	BaseLegalConsentimento:     true,
+	BaseLegalObrigacaoLegal:    true,
+	BaseLegalExecucaoContrato:  true,
+	BaseLegalExercicioDireitos: true,
+	BaseLegalLegitimoInteresse: true,
+	BaseLegalProtecaoCredito:   true,
+	BaseLegalPoliticasPublicas: true,
+	BaseLegalEstudosPesquisa:   true,
+	BaseLegalProtecaoVida:      true,
+	BaseLegalTutelaSaude:       true,
+}
+
+func NewBaseLegal(b string) (BaseLegal, error) {
+	if !basesLegais[BaseLegal(b)] {
+		return "", domainerr.ErrConsentimentoBaseLegalInvalid
+	}
+	return BaseLegal(b), nil
+}
+
+func (b BaseLegal) String() string {
+	return string(b)
+}
@@ -0,0 +1,17 @@
+package vo
+
+import "github.com/valdinei-santos/cpf-backend/internal/modules/cliente/domain/domainerr"
+
+// CanalConsentimento é o canal por onde o consentimento foi coletado ou revogado. Ex: web, app, telefone
+type CanalConsentimento string
+
+func NewCanalConsentimento(canal string) (CanalConsentimento, error) {
+	if len(canal) < 2 || len(canal) > 30 {
+		return "", domainerr.ErrConsentimentoCanalInvalid
+	}
+	return CanalConsentimento(canal), nil
+}
+
+func (c CanalConsentimento) String() string {
+	return string(c)
+}
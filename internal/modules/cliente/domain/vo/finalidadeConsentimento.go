@@ -0,0 +1,23 @@
+package vo
+
+import (
+	"regexp"
+
+	"github.com/valdinei-santos/cpf-backend/internal/modules/cliente/domain/domainerr"
+)
+
+var finalidadeRegex = regexp.MustCompile(`^[a-z0-9_]{3,50}$`)
+
+// FinalidadeConsentimento identifica o propósito do consentimento. Ex: marketing, compartilhamento_parceiros
+type FinalidadeConsentimento string
+
+func NewFinalidadeConsentimento(f string) (FinalidadeConsentimento, error) {
+	if !finalidadeRegex.MatchString(f) {
+		return "", domainerr.ErrConsentimentoFinalidadeInvalid
+	}
+	return FinalidadeConsentimento(f), nil
+}
+
+func (f FinalidadeConsentimento) String() string {
+	return string(f)
+}
@@ -0,0 +1,13 @@
+package vo
+
+// StatusConsentimento indica se o registro é uma concessão ou uma revogação.
+type StatusConsentimento string
+
+const (
+	ConsentimentoConcedido StatusConsentimento = "concedido"
+	ConsentimentoRevogado  StatusConsentimento = "revogado"
+)
+
+func (s StatusConsentimento) String() string {
+	return string(s)
+}
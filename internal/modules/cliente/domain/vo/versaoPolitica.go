@@ -0,0 +1,17 @@
+package vo
+
+import "github.com/valdinei-santos/cpf-backend/internal/modules/cliente/domain/domainerr"
+
+// VersaoPolitica é a versão da política de privacidade aceita pelo cliente.
+type VersaoPolitica string
+
+func NewVersaoPolitica(v string) (VersaoPolitica, error) {
+	if len(v) < 1 || len(v) > 20 {
+		return "", domainerr.ErrConsentimentoVersaoInvalid
+	}
+	return VersaoPolitica(v), nil
+}
+
+func (v VersaoPolitica) String() string {
+	return string(v)
+}
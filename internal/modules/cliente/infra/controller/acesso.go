@@ -0,0 +1,62 @@
+package controller
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/valdinei-santos/cpf-backend/internal/domain/globalerr"
+	"github.com/valdinei-santos/cpf-backend/internal/infra/logger"
+	"github.com/valdinei-santos/cpf-backend/internal/modules/cliente/usecases/accesslist"
+)
+
+// AcessoController orquestra as consultas do log de acesso aos dados dos clientes.
+type AcessoController struct {
+	log    logger.ILogger
+	listUC accesslist.IUsecase
+}
+
+// NewAcessoController é o construtor que injeta todas as dependências.
+func NewAcessoController(log logger.ILogger, l accesslist.IUsecase) *AcessoController {
+	return &AcessoController{
+		log:    log,
+		listUC: l,
+	}
+}
+
+// Handler específico dos Acessos aos dados de um cliente (com paginação)
+func (c *AcessoController) ByCliente(ctx *gin.Context) {
+	c.log.DebugContext(ctx.Request.Context(), "Entrou controller.ByCliente")
+	id, err := getIdParam(c.log, ctx)
+	if err != nil {
+		outputError(c.log, ctx, globalerr.ErrBadRequest, "ByCliente/getIdParam")
+		return
+	}
+	c.list(ctx, id, "", "ByCliente")
+}
+
+// Handler específico dos Acessos feitos por um operador (com paginação)
+func (c *AcessoController) ByOperador(ctx *gin.Context) {
+	c.log.DebugContext(ctx.Request.Context(), "Entrou controller.ByOperador")
+	c.list(ctx, "", ctx.Query("operador"), "ByOperador")
+}
+
+func (c *AcessoController) list(ctx *gin.Context, clienteID string, operador string, method string) {
+	page, err := strconv.Atoi(ctx.DefaultQuery("page", "1"))
+	if err != nil || page < 1 {
+		outputError(c.log, ctx, globalerr.ErrBadRequest, method+"/parse_page")
+		return
+	}
+	size, err := strconv.Atoi(ctx.DefaultQuery("size", "10"))
+	if err != nil || size < 1 {
+		outputError(c.log, ctx, globalerr.ErrBadRequest, method+"/parse_size")
+		return
+	}
+
+	resp, err := c.listUC.Execute(ctx.Request.Context(), clienteID, operador, int64(page), int64(size))
+	if err != nil {
+		outputError(c.log, ctx, err, method+"/usecase.Execute")
+		return
+	}
+	ctx.JSON(http.StatusOK, resp)
+}
@@ -0,0 +1,34 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/valdinei-santos/cpf-backend/internal/infra/logger"
+	"github.com/valdinei-santos/cpf-backend/internal/modules/cliente/usecases/auditverify"
+)
+
+// AuditoriaController orquestra a verificação da integridade dos registros de auditoria.
+type AuditoriaController struct {
+	log      logger.ILogger
+	verifyUC auditverify.IUsecase
+}
+
+// NewAuditoriaController é o construtor que injeta todas as dependências.
+func NewAuditoriaController(log logger.ILogger, v auditverify.IUsecase) *AuditoriaController {
+	return &AuditoriaController{
+		log:      log,
+		verifyUC: v,
+	}
+}
+
+// Handler específico da verificação da cadeia de auditoria
+func (c *AuditoriaController) Verify(ctx *gin.Context) {
+	c.log.DebugContext(ctx.Request.Context(), "Entrou controller.Verify")
+	resp, err := c.verifyUC.Execute(ctx.Request.Context(), ctx.Query("cadeia"), ctx.Query("cliente_id"))
+	if err != nil {
+		outputError(c.log, ctx, err, "Verify/usecase.Execute")
+		return
+	}
+	ctx.JSON(http.StatusOK, resp)
+}
@@ -0,0 +1,153 @@
+package controller
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/valdinei-santos/cpf-backend/internal/domain/globalerr"
+	"github.com/valdinei-santos/cpf-backend/internal/infra/logger"
+	"github.com/valdinei-santos/cpf-backend/internal/infra/masking"
+	"github.com/valdinei-santos/cpf-backend/internal/modules/cliente/infra/dto"
+	"github.com/valdinei-santos/cpf-backend/internal/modules/cliente/usecases/consentgrant"
+	"github.com/valdinei-santos/cpf-backend/internal/modules/cliente/usecases/consenthistory"
+	"github.com/valdinei-santos/cpf-backend/internal/modules/cliente/usecases/consentlist"
+	"github.com/valdinei-santos/cpf-backend/internal/modules/cliente/usecases/consentrevoke"
+	"github.com/valdinei-santos/cpf-backend/internal/modules/cliente/usecases/consentstatus"
+)
+
+// ConsentimentoController orquestra as ações do sub-recurso de consentimentos do Cliente.
+type ConsentimentoController struct {
+	log       logger.ILogger
+	masking   *masking.Policy
+	grantUC   consentgrant.IUsecase
+	revokeUC  consentrevoke.IUsecase
+	statusUC  consentstatus.IUsecase
+	historyUC consenthistory.IUsecase
+	listUC    consentlist.IUsecase
+}
+
+// NewConsentimentoController é o construtor que injeta todas as dependências.
+func NewConsentimentoController(
+	log logger.ILogger,
+	m *masking.Policy,
+	g consentgrant.IUsecase,
+	r consentrevoke.IUsecase,
+	s consentstatus.IUsecase,
+	h consenthistory.IUsecase,
+	l consentlist.IUsecase,
+) *ConsentimentoController {
+	return &ConsentimentoController{
+		log:       log,
+		masking:   m,
+		grantUC:   g,
+		revokeUC:  r,
+		statusUC:  s,
+		historyUC: h,
+		listUC:    l,
+	}
+}
+
+// Handler específico de Concessão de consentimento
+func (c *ConsentimentoController) Grant(ctx *gin.Context) {
+	c.log.DebugContext(ctx.Request.Context(), "Entrou controller.Grant")
+	id, err := getIdParam(c.log, ctx)
+	if err != nil {
+		outputError(c.log, ctx, globalerr.ErrBadRequest, "Grant/getIdParam")
+		return
+	}
+	var input *dto.ConsentimentoRequest
+	err = json.NewDecoder(ctx.Request.Body).Decode(&input)
+	if err != nil || input == nil {
+		outputError(c.log, ctx, globalerr.ErrBadRequest, "Grant/json.Decode")
+		return
+	}
+	resp, err := c.grantUC.Execute(ctx.Request.Context(), id, input)
+	if err != nil {
+		outputError(c.log, ctx, err, "Grant/usecase.Execute")
+		return
+	}
+	ctx.JSON(http.StatusCreated, resp)
+}
+
+// Handler específico de Revogação de consentimento
+func (c *ConsentimentoController) Revoke(ctx *gin.Context) {
+	c.log.DebugContext(ctx.Request.Context(), "Entrou controller.Revoke")
+	id, err := getIdParam(c.log, ctx)
+	if err != nil {
+		outputError(c.log, ctx, globalerr.ErrBadRequest, "Revoke/getIdParam")
+		return
+	}
+	var input *dto.RevogacaoRequest
+	err = json.NewDecoder(ctx.Request.Body).Decode(&input)
+	if err != nil || input == nil {
+		outputError(c.log, ctx, globalerr.ErrBadRequest, "Revoke/json.Decode")
+		return
+	}
+	resp, err := c.revokeUC.Execute(ctx.Request.Context(), id, ctx.Param("finalidade"), input)
+	if err != nil {
+		outputError(c.log, ctx, err, "Revoke/usecase.Execute")
+		return
+	}
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// Handler específico do Status atual dos consentimentos
+func (c *ConsentimentoController) Status(ctx *gin.Context) {
+	c.log.DebugContext(ctx.Request.Context(), "Entrou controller.Status")
+	id, err := getIdParam(c.log, ctx)
+	if err != nil {
+		outputError(c.log, ctx, globalerr.ErrBadRequest, "Status/getIdParam")
+		return
+	}
+	resp, err := c.statusUC.Execute(ctx.Request.Context(), id)
+	if err != nil {
+		outputError(c.log, ctx, err, "Status/usecase.Execute")
+		return
+	}
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// Handler específico do Histórico de consentimentos
+func (c *ConsentimentoController) History(ctx *gin.Context) {
+	c.log.DebugContext(ctx.Request.Context(), "Entrou controller.History")
+	id, err := getIdParam(c.log, ctx)
+	if err != nil {
+		outputError(c.log, ctx, globalerr.ErrBadRequest, "History/getIdParam")
+		return
+	}
+	resp, err := c.historyUC.Execute(ctx.Request.Context(), id)
+	if err != nil {
+		outputError(c.log, ctx, err, "History/usecase.Execute")
+		return
+	}
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// Handler específico da Listagem de clientes por finalidade consentida (com paginação)
+func (c *ConsentimentoController) List(ctx *gin.Context) {
+	c.log.DebugContext(ctx.Request.Context(), "Entrou controller.List")
+
+	page, err := strconv.Atoi(ctx.DefaultQuery("page", "1"))
+	if err != nil || page < 1 {
+		outputError(c.log, ctx, globalerr.ErrBadRequest, "List/parse_page")
+		return
+	}
+	size, err := strconv.Atoi(ctx.DefaultQuery("size", "10"))
+	if err != nil || size < 1 {
+		outputError(c.log, ctx, globalerr.ErrBadRequest, "List/parse_size")
+		return
+	}
+
+	mode, ok := maskingMode(c.log, c.masking, ctx, "consentimento:"+ctx.Param("finalidade"), "List/maskingMode")
+	if !ok {
+		return
+	}
+	resp, err := c.listUC.Execute(ctx.Request.Context(), ctx.Param("finalidade"), int64(page), int64(size), mode)
+	if err != nil {
+		outputError(c.log, ctx, err, "List/usecase.Execute")
+		return
+	}
+	ctx.JSON(http.StatusOK, resp)
+}
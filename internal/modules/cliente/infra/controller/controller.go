@@ -8,6 +8,9 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/valdinei-santos/cpf-backend/internal/domain/globalerr"
 	"github.com/valdinei-santos/cpf-backend/internal/infra/logger"
+	"github.com/valdinei-santos/cpf-backend/internal/infra/masking"
+	"github.com/valdinei-santos/cpf-backend/internal/infra/problem"
+	"github.com/valdinei-santos/cpf-backend/internal/infra/tracing"
 	"github.com/valdinei-santos/cpf-backend/internal/modules/cliente/domain/domainerr"
 	"github.com/valdinei-santos/cpf-backend/internal/modules/cliente/infra/dto"
 	"github.com/valdinei-santos/cpf-backend/internal/modules/cliente/usecases/create"
@@ -20,6 +23,7 @@ import (
 // ClienteController orquestra todas as ações da entidade Cliente.
 type ClienteController struct {
 	log      logger.ILogger
+	masking  *masking.Policy
 	createUC create.IUsecase
 	deleteUC delete.IUsecase
 	getUC    get.IUsecase
@@ -30,6 +34,7 @@ type ClienteController struct {
 // NewClienteController é o construtor que injeta todas as dependências.
 func NewClienteController(
 	log logger.ILogger,
+	m *masking.Policy,
 	c create.IUsecase,
 	d delete.IUsecase,
 	g get.IUsecase,
@@ -38,6 +43,7 @@ func NewClienteController(
 ) *ClienteController {
 	return &ClienteController{
 		log:      log,
+		masking:  m,
 		createUC: c,
 		deleteUC: d,
 		getUC:    g,
@@ -48,32 +54,35 @@ func NewClienteController(
 
 // Handler específico de Criação
 func (c *ClienteController) Create(ctx *gin.Context) {
-	c.log.Debug("Entrou controller.Create")
+	c.log.DebugContext(ctx.Request.Context(), "Entrou controller.Create")
 	var input *dto.Request
 	err := json.NewDecoder(ctx.Request.Body).Decode(&input)
-	if err != nil {
+	if err != nil || input == nil {
 		outputError(c.log, ctx, globalerr.ErrBadRequest, "Create/json.Decode")
 		return
 	}
-	resp, err := c.createUC.Execute(input)
+	mode, ok := maskingMode(c.log, c.masking, ctx, "cliente:novo", "Create/maskingMode")
+	if !ok {
+		return
+	}
+	resp, err := c.createUC.Execute(ctx.Request.Context(), input, mode)
 	if err != nil {
 		outputError(c.log, ctx, err, "Create/usecase.Execute")
 		return
 	}
 
 	ctx.JSON(http.StatusCreated, resp)
-	c.log.Info("### Finished OK", "status_code", http.StatusCreated)
 }
 
 // Handler específico de Deleção
 func (c *ClienteController) Delete(ctx *gin.Context) {
-	c.log.Debug("Entrou controller.Delete")
+	c.log.DebugContext(ctx.Request.Context(), "Entrou controller.Delete")
 	id, err := getIdParam(c.log, ctx)
 	if err != nil {
 		return
 	}
-	c.log.Debug("ID: " + id)
-	err = c.deleteUC.Execute(id)
+	c.log.DebugContext(ctx.Request.Context(), "ID: "+id)
+	err = c.deleteUC.Execute(ctx.Request.Context(), id)
 	if err != nil {
 		outputError(c.log, ctx, err, "Delete/usecase.Execute")
 		return
@@ -83,30 +92,32 @@ func (c *ClienteController) Delete(ctx *gin.Context) {
 	result := &dto.OutputDefault{}
 
 	ctx.JSON(http.StatusNoContent, result)
-	c.log.Info("### Finished OK", "status_code", http.StatusNoContent)
 }
 
 // Handler específico de Obtenção por ID
 func (c *ClienteController) Get(ctx *gin.Context) {
-	c.log.Debug("Entrou controller.Get")
+	c.log.DebugContext(ctx.Request.Context(), "Entrou controller.Get")
 	id, err := getIdParam(c.log, ctx)
 	if err != nil {
 		outputError(c.log, ctx, globalerr.ErrBadRequest, "Get/getIdParam")
 		return
 	}
-	c.log.Debug("ID: " + id)
-	resp, err := c.getUC.Execute(id)
+	c.log.DebugContext(ctx.Request.Context(), "ID: "+id)
+	mode, ok := maskingMode(c.log, c.masking, ctx, "cliente:"+id, "Get/maskingMode")
+	if !ok {
+		return
+	}
+	resp, err := c.getUC.Execute(ctx.Request.Context(), id, mode)
 	if err != nil {
 		outputError(c.log, ctx, err, "Get/usecase.Execute")
 		return
 	}
 	ctx.JSON(http.StatusOK, resp)
-	c.log.Info("### Finished OK", "status_code", http.StatusOK)
 }
 
 // Handler específico de Obtenção de todos os registros (com paginação)
 func (c *ClienteController) GetAll(ctx *gin.Context) {
-	c.log.Debug("Entrou controller.GetAll")
+	c.log.DebugContext(ctx.Request.Context(), "Entrou controller.GetAll")
 
 	// Pega os parâmetros de paginação (page) da query string
 	page, err := strconv.Atoi(ctx.DefaultQuery("page", "1"))
@@ -122,77 +133,71 @@ func (c *ClienteController) GetAll(ctx *gin.Context) {
 		return
 	}
 
-	resp, err := c.getAllUC.Execute(int64(page), int64(size))
+	mode, ok := maskingMode(c.log, c.masking, ctx, "cliente:lista", "GetAll/maskingMode")
+	if !ok {
+		return
+	}
+	resp, err := c.getAllUC.Execute(ctx.Request.Context(), int64(page), int64(size), mode)
 	if err != nil {
 		outputError(c.log, ctx, err, "GetAll/usecase.Execute")
 		return
 	}
 	ctx.JSON(http.StatusOK, resp)
-	c.log.Info("### Finished OK", "status_code", http.StatusOK)
 }
 
 // Handler específico de Atualização
 func (c *ClienteController) Update(ctx *gin.Context) {
-	c.log.Debug("Entrou controller.Update")
+	c.log.DebugContext(ctx.Request.Context(), "Entrou controller.Update")
 	id, err := getIdParam(c.log, ctx)
 	if err != nil {
 		outputError(c.log, ctx, globalerr.ErrBadRequest, "Update/getIdParam")
 		return
 	}
-	c.log.Debug("ID: " + id)
+	c.log.DebugContext(ctx.Request.Context(), "ID: "+id)
 	var input *dto.Request
 	err = json.NewDecoder(ctx.Request.Body).Decode(&input)
-	if err != nil {
-		outputError(c.log, ctx, err, "Update/json.NewDecoder")
+	if err != nil || input == nil {
+		outputError(c.log, ctx, globalerr.ErrBadRequest, "Update/json.NewDecoder")
 		return
 	}
-	resp, err := c.updateUC.Execute(id, input)
+	mode, ok := maskingMode(c.log, c.masking, ctx, "cliente:"+id, "Update/maskingMode")
+	if !ok {
+		return
+	}
+	resp, err := c.updateUC.Execute(ctx.Request.Context(), id, input, mode)
 	if err != nil {
 		outputError(c.log, ctx, err, "Update/usecase.Execute")
 		return
 	}
 	ctx.JSON(http.StatusOK, resp)
-	c.log.Info("### Finished OK", "status_code", http.StatusOK)
 }
 
 func getIdParam(log logger.ILogger, ctx *gin.Context) (string, error) {
 	idParam := ctx.Param("id")
 	if idParam == "" {
-		log.Error(domainerr.ErrClienteIDInvalid.Error(), "mtd", "getIdParam")
+		log.ErrorContext(ctx.Request.Context(), domainerr.ErrClienteIDInvalid.Error(), "mtd", "getIdParam")
 		return "", domainerr.ErrClienteIDInvalid
 	}
 	return idParam, nil
 }
+
+// maskingMode resolve se o documento e o telefone serão retornados completos (?unmask=true) ou mascarados.
+// Se o chamador não tem permissão para o unmask, responde 401/403 e retorna false.
+func maskingMode(log logger.ILogger, policy *masking.Policy, ctx *gin.Context, resource string, method string) (masking.Mode, bool) {
+	unmask, _ := strconv.ParseBool(ctx.Query("unmask"))
+	mode, err := policy.Resolve(ctx.Request.Context(), unmask, resource)
+	if err != nil {
+		outputError(log, ctx, err, method)
+		return mode, false
+	}
+	return mode, true
+}
+
+// outputError registra o erro no log e no trace e responde no formato application/problem+json.
+// O status e o código vêm do registro de erros do pacote problem.
 func outputError(log logger.ILogger, ctx *gin.Context, err error, method string) {
-	log.Error(err.Error(), "mtd", method)
-	dataJErro := dto.OutputDefault{}
-	var errHttp int
-	switch err {
-	case globalerr.ErrDuplicatekey:
-		errHttp = http.StatusConflict
-		dataJErro.Title = globalerr.ErrHttp409.Error()
-		dataJErro.Detail = domainerr.ErrDuplicatekey.Error()
-	case globalerr.ErrNotFound, domainerr.ErrClienteNotFound:
-		errHttp = http.StatusNotFound
-		dataJErro.Title = globalerr.ErrHttp404.Error()
-		dataJErro.Detail = domainerr.ErrClienteNotFound.Error()
-	case globalerr.ErrSaveInDatabase:
-		errHttp = http.StatusInternalServerError
-		dataJErro.Title = globalerr.ErrHttp500.Error()
-		dataJErro.Detail = globalerr.ErrSaveInDatabase.Error()
-	case globalerr.ErrBadRequest:
-		errHttp = http.StatusBadRequest
-		dataJErro.Title = globalerr.ErrHttp404.Error()
-		dataJErro.Detail = globalerr.ErrBadRequest.Error()
-	case domainerr.ErrClienteIDInvalid:
-		errHttp = http.StatusBadRequest
-		dataJErro.Title = globalerr.ErrHttp400.Error()
-		dataJErro.Detail = globalerr.ErrBadRequest.Error()
-	default:
-		errHttp = http.StatusInternalServerError
-		dataJErro.Title = globalerr.ErrHttp500.Error()
-		dataJErro.Detail = globalerr.ErrSaveInDatabase.Error()
-	}
-	ctx.JSON(errHttp, dataJErro)
-	log.Info("### Finished ERROR", "status_code", errHttp)
+	reqCtx := ctx.Request.Context()
+	log.ErrorContext(reqCtx, err.Error(), "mtd", method)
+	tracing.RecordError(reqCtx, err)
+	problem.Write(ctx, err)
 }
@@ -0,0 +1,44 @@
+package dto
+
+import "github.com/valdinei-santos/cpf-backend/internal/modules/cliente/domain/entities"
+
+// AcessoResponse - registro de leitura dos dados pessoais de um cliente
+type AcessoResponse struct {
+	ID         string `json:"id"`
+	ClienteID  string `json:"cliente_id"`
+	Acao       string `json:"acao"`
+	Recurso    string `json:"recurso,omitempty"`
+	Operador   string `json:"operador"`
+	Metodo     string `json:"metodo,omitempty"`
+	Finalidade string `json:"finalidade,omitempty"`
+	IP         string `json:"ip,omitempty"`
+	Completo   bool   `json:"completo"`
+	CreatedAt  string `json:"created_at"`
+}
+
+// AcessosPaginated - registros do log de acesso, paginado
+type AcessosPaginated struct {
+	ClienteID    string           `json:"cliente_id,omitempty"`
+	Operador     string           `json:"operador,omitempty"`
+	Acessos      []AcessoResponse `json:"acessos"`
+	TotalItems   int64            `json:"totalItems"`
+	TotalPages   int64            `json:"totalPages"`
+	CurrentPage  int64            `json:"currentPage"`
+	ItemsPerPage int64            `json:"itemsPerPage"`
+}
+
+// NewAcessoResponse - transforma a entidade Acesso no DTO de saída
+func NewAcessoResponse(a *entities.Acesso) AcessoResponse {
+	return AcessoResponse{
+		ID:         a.ID.String(),
+		ClienteID:  a.ClienteID.String(),
+		Acao:       a.Acao,
+		Recurso:    a.Recurso,
+		Operador:   a.Operador,
+		Metodo:     a.Metodo,
+		Finalidade: a.Finalidade,
+		IP:         a.IP,
+		Completo:   a.Completo,
+		CreatedAt:  a.CreatedAt.String(),
+	}
+}
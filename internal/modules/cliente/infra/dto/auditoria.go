@@ -0,0 +1,43 @@
+package dto
+
+import "github.com/valdinei-santos/cpf-backend/internal/infra/hashchain"
+
+// VerificacaoResponse - resultado da verificação da cadeia de hashes dos registros de auditoria
+type VerificacaoResponse struct {
+	Cadeia                   string          `json:"cadeia"`
+	ClienteID                string          `json:"cliente_id,omitempty"`
+	Integra                  bool            `json:"integra"`
+	Verificados              int64           `json:"verificados"`
+	SemEncadeamento          int64           `json:"sem_encadeamento"` // Registros anteriores ao encadeamento
+	PrimeiroSeq              int64           `json:"primeiro_seq"`
+	UltimoSeq                int64           `json:"ultimo_seq"`
+	Checkpoints              int             `json:"checkpoints"`                // Checkpoints assinados conferidos
+	CheckpointsNaoConferidos int             `json:"checkpoints_nao_conferidos"` // Checkpoints de outra chave, ignorados
+	EloQuebrado              *EloQuebradoDTO `json:"elo_quebrado,omitempty"`
+}
+
+// EloQuebradoDTO - primeiro registro da cadeia alterado ou removido
+type EloQuebradoDTO struct {
+	Seq      int64  `json:"seq"`
+	Registro string `json:"registro,omitempty"` // ID do registro, vazio quando ele foi removido
+	Motivo   string `json:"motivo"`
+}
+
+// NewVerificacaoResponse - transforma o relatório da verificação no DTO de saída
+func NewVerificacaoResponse(cadeia string, clienteID string, r *hashchain.Report) *VerificacaoResponse {
+	resp := &VerificacaoResponse{
+		Cadeia:                   cadeia,
+		ClienteID:                clienteID,
+		Integra:                  r.OK(),
+		Verificados:              r.Checked,
+		SemEncadeamento:          r.Unchained,
+		PrimeiroSeq:              r.FirstSeq,
+		UltimoSeq:                r.LastSeq,
+		Checkpoints:              r.Checkpoints,
+		CheckpointsNaoConferidos: r.Unverified,
+	}
+	if r.Broken != nil {
+		resp.EloQuebrado = &EloQuebradoDTO{Seq: r.Broken.Seq, Registro: r.Broken.ID, Motivo: r.Broken.Reason}
+	}
+	return resp
+}
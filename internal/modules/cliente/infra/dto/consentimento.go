@@ -0,0 +1,72 @@
+package dto
+
+import "github.com/valdinei-santos/cpf-backend/internal/modules/cliente/domain/entities"
+
+// ConsentimentoRequest - dados para concessão de um consentimento
+type ConsentimentoRequest struct {
+	Finalidade     string `json:"finalidade"`
+	BaseLegal      string `json:"base_legal"`
+	Canal          string `json:"canal"`
+	VersaoPolitica string `json:"versao_politica"`
+}
+
+// RevogacaoRequest - dados para revogação de um consentimento
+type RevogacaoRequest struct {
+	Canal string `json:"canal"`
+}
+
+// ConsentimentoResponse -
+type ConsentimentoResponse struct {
+	ID             string  `json:"id"`
+	ClienteID      string  `json:"cliente_id"`
+	Finalidade     string  `json:"finalidade"`
+	BaseLegal      string  `json:"base_legal"`
+	Canal          string  `json:"canal"`
+	VersaoPolitica string  `json:"versao_politica"`
+	Status         string  `json:"status"`
+	ConcedidoEm    string  `json:"concedido_em"`
+	RevogadoEm     *string `json:"revogado_em,omitempty"`
+	CreatedAt      string  `json:"created_at"`
+}
+
+// ConsentimentosResponse - lista de consentimentos de um cliente (status atual ou histórico)
+type ConsentimentosResponse struct {
+	ClienteID      string                  `json:"cliente_id"`
+	Consentimentos []ConsentimentoResponse `json:"consentimentos"`
+}
+
+// ClienteConsentimento - cliente com o consentimento vigente para a finalidade consultada
+type ClienteConsentimento struct {
+	Cliente       Response              `json:"cliente"`
+	Consentimento ConsentimentoResponse `json:"consentimento"`
+}
+
+// ClientesConsentimentoPaginated - clientes que consentiram com uma finalidade, paginado
+type ClientesConsentimentoPaginated struct {
+	Finalidade   string                 `json:"finalidade"`
+	Clientes     []ClienteConsentimento `json:"clientes"`
+	TotalItems   int64                  `json:"totalItems"`
+	TotalPages   int64                  `json:"totalPages"`
+	CurrentPage  int64                  `json:"currentPage"`
+	ItemsPerPage int64                  `json:"itemsPerPage"`
+}
+
+// NewConsentimentoResponse - transforma a entidade Consentimento no DTO de saída
+func NewConsentimentoResponse(c *entities.Consentimento) ConsentimentoResponse {
+	resp := ConsentimentoResponse{
+		ID:             c.ID.String(),
+		ClienteID:      c.ClienteID.String(),
+		Finalidade:     c.Finalidade.String(),
+		BaseLegal:      c.BaseLegal.String(),
+		Canal:          c.Canal.String(),
+		VersaoPolitica: c.VersaoPolitica.String(),
+		Status:         c.Status.String(),
+		ConcedidoEm:    c.ConcedidoEm.String(),
+		CreatedAt:      c.CreatedAt.String(),
+	}
+	if c.RevogadoEm != nil {
+		revogadoEm := c.RevogadoEm.String()
+		resp.RevogadoEm = &revogadoEm
+	}
+	return resp
+}
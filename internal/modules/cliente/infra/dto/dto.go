@@ -1,5 +1,10 @@
 package dto
 
+import (
+	"github.com/valdinei-santos/cpf-backend/internal/infra/masking"
+	"github.com/valdinei-santos/cpf-backend/internal/modules/cliente/domain/entities"
+)
+
 // Request -
 type Request struct {
 	//ID        string `json:"id,omitempty"`
@@ -7,7 +12,7 @@ type Request struct {
 	Documento string `json:"documento"`
 	Telefone  string `json:"telefone"`
 	Bloqueado bool   `json:"bloqueado"`
-}
+} //@name ClienteRequest
 
 // Response -
 type Response struct {
@@ -18,7 +23,7 @@ type Response struct {
 	Bloqueado bool   `json:"bloqueado"`
 	CreatedAt string `json:"created_at"`
 	UpdatedAt string `json:"updated_at"`
-}
+} //@name ClienteResponse
 
 type ResponseManyPaginated struct {
 	Clientes     []Response `json:"clientes"`
@@ -26,7 +31,7 @@ type ResponseManyPaginated struct {
 	TotalPages   int64      `json:"totalPages"`
 	CurrentPage  int64      `json:"currentPage"`
 	ItemsPerPage int64      `json:"itemsPerPage"`
-}
+} //@name ClientesPaginated
 
 // ResponseMany -
 type ResponseMany struct {
@@ -38,4 +43,19 @@ type OutputDefault struct {
 	Title    string  `json:"title"`
 	Detail   string  `json:"detail"`
 	Instance *string `json:"instance,omitempty"`
+	TraceID  string  `json:"trace_id,omitempty"` // Trace da requisição, também no header X-Trace-ID
+} //@name OutputDefault
+
+// NewResponse - transforma a entidade Cliente no DTO Response, aplicando o mascaramento
+// do documento e do telefone conforme o modo definido para o chamador
+func NewResponse(p *entities.Cliente, mode masking.Mode) Response {
+	return Response{
+		ID:        p.ID.String(),
+		Nome:      p.Nome.String(),
+		Documento: masking.Documento(p.Documento.String(), mode),
+		Telefone:  masking.Telefone(p.Telefone.String(), mode),
+		Bloqueado: p.Bloqueado.Bool(),
+		CreatedAt: p.CreatedAt.String(),
+		UpdatedAt: p.UpdatedAt.String(),
+	}
 }
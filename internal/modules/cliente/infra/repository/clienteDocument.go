@@ -0,0 +1,117 @@
+package repository
+
+import (
+	"strings"
+	"time"
+	"unicode"
+
+	domainvo "github.com/valdinei-santos/cpf-backend/internal/domain/vo"
+	"github.com/valdinei-santos/cpf-backend/internal/infra/keyring"
+	"github.com/valdinei-santos/cpf-backend/internal/modules/cliente/domain/entities"
+	"github.com/valdinei-santos/cpf-backend/internal/modules/cliente/domain/vo"
+)
+
+// Nomes dos campos usados no blind index e no dado adicional autenticado (AAD) da criptografia
+const (
+	fieldDocumento = "cliente.documento"
+	fieldTelefone  = "cliente.telefone"
+)
+
+// clienteDocument é o formato gravado no MongoDB. O documento (e opcionalmente o telefone) é gravado
+// cifrado e o documento_bidx guarda o blind index usado na busca por documento e na unicidade.
+type clienteDocument struct {
+	ID            domainvo.ID `bson:"id"`
+	Tenant        string      `bson:"tenant,omitempty"`
+	Nome          string      `bson:"nome"`
+	Documento     string      `bson:"documento"`
+	DocumentoBidx string      `bson:"documento_bidx"`
+	Telefone      string      `bson:"telefone"`
+	EncKey        string      `bson:"enc_key,omitempty"`
+	Bloqueado     bool        `bson:"bloqueado"`
+	CreatedAt     time.Time   `bson:"created_at"`
+	UpdatedAt     time.Time   `bson:"updated_at"`
+}
+
+// fieldEncryption cifra e decifra os campos sensíveis do cliente
+type fieldEncryption struct {
+	cipher          keyring.IFieldCipher
+	encryptTelefone bool
+}
+
+// toDocument - converte a entidade para o formato gravado, cifrando os campos sensíveis
+func (f fieldEncryption) toDocument(p *entities.Cliente) (*clienteDocument, error) {
+	id := p.ID.String()
+	documento, err := f.cipher.Encrypt(p.Documento.String(), fieldDocumento+":"+id)
+	if err != nil {
+		return nil, err
+	}
+	telefone := p.Telefone.String()
+	if f.encryptTelefone {
+		telefone, err = f.cipher.Encrypt(telefone, fieldTelefone+":"+id)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return &clienteDocument{
+		ID:            p.ID,
+		Nome:          p.Nome.String(),
+		Documento:     documento,
+		DocumentoBidx: f.documentoBidx(p.Documento.String()),
+		Telefone:      telefone,
+		EncKey:        f.cipher.ActiveKeyID(),
+		Bloqueado:     p.Bloqueado.Bool(),
+		CreatedAt:     p.CreatedAt,
+		UpdatedAt:     p.UpdatedAt,
+	}, nil
+}
+
+// toEntity - converte o formato gravado para a entidade, decifrando os campos sensíveis
+func (f fieldEncryption) toEntity(d *clienteDocument) (*entities.Cliente, error) {
+	id := d.ID.String()
+	documento, err := f.cipher.Decrypt(d.Documento, fieldDocumento+":"+id)
+	if err != nil {
+		return nil, err
+	}
+	// O telefone pode estar cifrado mesmo com a opção desligada (registros antigos), Decrypt trata os dois casos
+	telefone, err := f.cipher.Decrypt(d.Telefone, fieldTelefone+":"+id)
+	if err != nil {
+		return nil, err
+	}
+	return &entities.Cliente{
+		ID:        d.ID,
+		Nome:      vo.NomeCliente(d.Nome),
+		Documento: vo.DocumentoCliente(documento),
+		Telefone:  vo.TelefoneCliente(telefone),
+		Bloqueado: vo.BloqueadoCliente(d.Bloqueado),
+		CreatedAt: d.CreatedAt,
+		UpdatedAt: d.UpdatedAt,
+	}, nil
+}
+
+// toEntities - converte uma lista de documentos gravados para entidades
+func (f fieldEncryption) toEntities(docs []*clienteDocument) ([]*entities.Cliente, error) {
+	clientes := make([]*entities.Cliente, 0, len(docs))
+	for _, d := range docs {
+		c, err := f.toEntity(d)
+		if err != nil {
+			return nil, err
+		}
+		clientes = append(clientes, c)
+	}
+	return clientes, nil
+}
+
+// documentoBidx - blind index do documento normalizado (sem pontuação), para que
+// "123.456.789-00" e "12345678900" sejam considerados o mesmo documento
+func (f fieldEncryption) documentoBidx(documento string) string {
+	return f.cipher.BlindIndex(fieldDocumento, normalizeDocumento(documento))
+}
+
+func normalizeDocumento(documento string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return unicode.ToUpper(r)
+		}
+		return -1
+	}, documento)
+}
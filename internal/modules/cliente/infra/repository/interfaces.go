@@ -1,13 +1,44 @@
 package repository
 
-import "github.com/valdinei-santos/cpf-backend/internal/modules/cliente/domain/entities"
+import (
+	"context"
 
-// IClienteRepository define a interface para as operações de Cliente
+	"github.com/valdinei-santos/cpf-backend/internal/infra/hashchain"
+	"github.com/valdinei-santos/cpf-backend/internal/modules/cliente/domain/entities"
+)
+
+// IClienteRepository define a interface para as operações de Cliente.
+// O ctx carrega o tenant da requisição, que delimita os dados acessados.
 type IClienteRepository interface {
-	AddCliente(p *entities.Cliente) error
-	GetClienteByID(id string) (*entities.Cliente, error)
-	GetAllClientes(offset int64, limit int64) ([]*entities.Cliente, int64, error)
-	UpdateCliente(id string, p *entities.Cliente) error
-	DeleteCliente(id string) error
-	Count() (int64, error)
+	AddCliente(ctx context.Context, p *entities.Cliente) error
+	GetClienteByID(ctx context.Context, id string) (*entities.Cliente, error)
+	GetManyClienteByIDs(ctx context.Context, ids []string) ([]*entities.Cliente, error)
+	GetClienteByDocumento(ctx context.Context, documento string) (*entities.Cliente, error)
+	GetAllClientes(ctx context.Context, offset int64, limit int64) ([]*entities.Cliente, int64, error)
+	UpdateCliente(ctx context.Context, id string, p *entities.Cliente) error
+	DeleteCliente(ctx context.Context, id string) error
+	Count(ctx context.Context) (int64, error)
+}
+
+// IConsentimentoRepository define a interface para as operações do histórico de consentimentos.
+// Os registros são apenas inseridos, nunca alterados ou removidos.
+type IConsentimentoRepository interface {
+	AddConsentimento(ctx context.Context, c *entities.Consentimento) error
+	GetConsentimentoAtual(ctx context.Context, clienteID string, finalidade string) (*entities.Consentimento, error)
+	GetConsentimentosAtuais(ctx context.Context, clienteID string) ([]*entities.Consentimento, error)
+	GetHistoricoConsentimentos(ctx context.Context, clienteID string) ([]*entities.Consentimento, error)
+	GetConsentimentosAtivosPorFinalidade(ctx context.Context, finalidade string, offset int64, limit int64) ([]*entities.Consentimento, int64, error)
+}
+
+// IAcessoRepository define a interface do log de acesso aos dados pessoais dos clientes.
+// Os registros são apenas inseridos, a remoção fica a cargo da retenção configurada.
+type IAcessoRepository interface {
+	AddAcessos(ctx context.Context, acessos []*entities.Acesso) error
+	GetAcessos(ctx context.Context, clienteID string, operador string, offset int64, limit int64) ([]*entities.Acesso, int64, error)
+}
+
+// IChainRepository define a verificação da cadeia de hashes dos registros de auditoria (histórico de
+// consentimentos e log de acesso). Com clienteID vazio verifica a cadeia completa do tenant.
+type IChainRepository interface {
+	VerifyChain(ctx context.Context, clienteID string) (*hashchain.Report, error)
 }
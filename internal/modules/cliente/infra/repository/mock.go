@@ -1,13 +1,13 @@
 package repository
 
 import (
-	"errors"
+	"context"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/valdinei-santos/cpf-backend/internal/domain/vo"
 	"github.com/valdinei-santos/cpf-backend/internal/modules/cliente/domain/domainerr"
 	"github.com/valdinei-santos/cpf-backend/internal/modules/cliente/domain/entities"
-	"github.com/valdinei-santos/cpf-backend/internal/modules/cliente/domain/vo"
 )
 
 // MockClienteRepository é um mock com a implementação da interface IClienteRepository
@@ -33,7 +33,7 @@ func (m *MockClienteRepository) SetMockError(err error) {
 }
 
 // AddCliente - mock do método AddCliente
-func (m *MockClienteRepository) AddCliente(p *entities.Cliente) error {
+func (m *MockClienteRepository) AddCliente(ctx context.Context, p *entities.Cliente) error {
 	if m.mockError != nil {
 		return m.mockError
 	}
@@ -48,7 +48,7 @@ func (m *MockClienteRepository) AddCliente(p *entities.Cliente) error {
 }
 
 // GetClienteByID - mock do método GetClienteByID
-func (m *MockClienteRepository) GetClienteByID(id string) (*entities.Cliente, error) {
+func (m *MockClienteRepository) GetClienteByID(ctx context.Context, id string) (*entities.Cliente, error) {
 	if m.mockError != nil {
 		return nil, m.mockError
 	}
@@ -62,11 +62,24 @@ func (m *MockClienteRepository) GetClienteByID(id string) (*entities.Cliente, er
 			return &cliente, nil
 		}
 	}
-	return nil, errors.New("cliente não encontrado")
+	return nil, domainerr.ErrClienteNotFound
+}
+
+// GetClienteByDocumento - mock do método GetClienteByDocumento
+func (m *MockClienteRepository) GetClienteByDocumento(ctx context.Context, documento string) (*entities.Cliente, error) {
+	if m.mockError != nil {
+		return nil, m.mockError
+	}
+	for _, cliente := range m.Clientes {
+		if cliente.Documento.String() == documento {
+			return &cliente, nil
+		}
+	}
+	return nil, domainerr.ErrClienteNotFound
 }
 
 // GetManyClienteByIDs - busca vários clientes por ID
-func (m *MockClienteRepository) GetManyClienteByIDs(ids []string) ([]*entities.Cliente, error) {
+func (m *MockClienteRepository) GetManyClienteByIDs(ctx context.Context, ids []string) ([]*entities.Cliente, error) {
 	if m.mockError != nil {
 		return nil, m.mockError
 	}
@@ -87,7 +100,7 @@ func (m *MockClienteRepository) GetManyClienteByIDs(ids []string) ([]*entities.C
 }
 
 // GetAllClientes - mock do método GetAllClientes
-func (m *MockClienteRepository) GetAllClientes(offset int64, limit int64) ([]*entities.Cliente, int64, error) {
+func (m *MockClienteRepository) GetAllClientes(ctx context.Context, offset int64, limit int64) ([]*entities.Cliente, int64, error) {
 	if m.mockError != nil {
 		return nil, 0, m.mockError
 	}
@@ -114,7 +127,7 @@ func (m *MockClienteRepository) GetAllClientes(offset int64, limit int64) ([]*en
 }
 
 // UpdateCliente - mock do método UpdateCliente
-func (m *MockClienteRepository) UpdateCliente(id string, p *entities.Cliente) error {
+func (m *MockClienteRepository) UpdateCliente(ctx context.Context, id string, p *entities.Cliente) error {
 	if m.mockError != nil {
 		return m.mockError
 	}
@@ -135,7 +148,7 @@ func (m *MockClienteRepository) UpdateCliente(id string, p *entities.Cliente) er
 }
 
 // DeleteCliente - mock do método DeleteCliente
-func (m *MockClienteRepository) DeleteCliente(id string) error {
+func (m *MockClienteRepository) DeleteCliente(ctx context.Context, id string) error {
 	if m.mockError != nil {
 		return m.mockError
 	}
@@ -154,6 +167,6 @@ func (m *MockClienteRepository) DeleteCliente(id string) error {
 }
 
 // Count - mock do método Count
-func (r *MockClienteRepository) Count() (int64, error) {
+func (r *MockClienteRepository) Count(ctx context.Context) (int64, error) {
 	return int64(len(r.Clientes)), nil
 }
@@ -0,0 +1,97 @@
+package repository
+
+import (
+	"context"
+	"sort"
+
+	"github.com/google/uuid"
+	"github.com/valdinei-santos/cpf-backend/internal/domain/vo"
+	"github.com/valdinei-santos/cpf-backend/internal/infra/hashchain"
+	"github.com/valdinei-santos/cpf-backend/internal/modules/cliente/domain/domainerr"
+	"github.com/valdinei-santos/cpf-backend/internal/modules/cliente/domain/entities"
+)
+
+// MockAcessoRepository é um mock com a implementação da interface IAcessoRepository
+type MockAcessoRepository struct {
+	Acessos   []entities.Acesso
+	Broken    *hashchain.Broken // Elo quebrado retornado pelo VerifyChain
+	mockError error
+}
+
+// NewMockAcessoRepository cria uma nova instancia de MockAcessoRepository sem registros
+func NewMockAcessoRepository() *MockAcessoRepository {
+	return &MockAcessoRepository{
+		Acessos: []entities.Acesso{},
+	}
+}
+
+func (m *MockAcessoRepository) SetMockError(err error) {
+	m.mockError = err
+}
+
+// AddAcessos - mock do método AddAcessos
+func (m *MockAcessoRepository) AddAcessos(ctx context.Context, acessos []*entities.Acesso) error {
+	if m.mockError != nil {
+		return m.mockError
+	}
+	for _, a := range acessos {
+		m.Acessos = append(m.Acessos, *a)
+	}
+	return nil
+}
+
+// GetAcessos - mock do método GetAcessos
+func (m *MockAcessoRepository) GetAcessos(ctx context.Context, clienteID string, operador string, offset int64, limit int64) ([]*entities.Acesso, int64, error) {
+	if m.mockError != nil {
+		return nil, 0, m.mockError
+	}
+	var idVO vo.ID
+	if clienteID != "" {
+		idUUID, err := uuid.Parse(clienteID)
+		if err != nil {
+			return nil, 0, domainerr.ErrClienteIDInvalid
+		}
+		idVO = vo.FromUUID(idUUID)
+	}
+
+	filtrados := []*entities.Acesso{}
+	for i := range m.Acessos {
+		a := m.Acessos[i]
+		if (clienteID == "" || a.ClienteID == idVO) && (operador == "" || a.Operador == operador) {
+			filtrados = append(filtrados, &a)
+		}
+	}
+	sort.SliceStable(filtrados, func(i, j int) bool {
+		return filtrados[i].CreatedAt.After(filtrados[j].CreatedAt)
+	})
+
+	total := int64(len(filtrados))
+	if offset >= total {
+		return []*entities.Acesso{}, total, nil
+	}
+	end := min(offset+limit, total)
+	return filtrados[offset:end], total, nil
+}
+
+// VerifyChain - mock do método VerifyChain. A cadeia é íntegra, a menos que Broken seja informado
+func (m *MockAcessoRepository) VerifyChain(ctx context.Context, clienteID string) (*hashchain.Report, error) {
+	if m.mockError != nil {
+		return nil, m.mockError
+	}
+	var idVO vo.ID
+	if clienteID != "" {
+		idUUID, err := uuid.Parse(clienteID)
+		if err != nil {
+			return nil, domainerr.ErrClienteIDInvalid
+		}
+		idVO = vo.FromUUID(idUUID)
+	}
+
+	report := &hashchain.Report{Broken: m.Broken}
+	for _, r := range m.Acessos {
+		if clienteID == "" || r.ClienteID == idVO {
+			report.Checked++
+		}
+	}
+	return report, nil
+}
@@ -0,0 +1,163 @@
+package repository
+
+import (
+	"context"
+	"sort"
+
+	"github.com/google/uuid"
+	"github.com/valdinei-santos/cpf-backend/internal/domain/vo"
+	"github.com/valdinei-santos/cpf-backend/internal/infra/hashchain"
+	"github.com/valdinei-santos/cpf-backend/internal/modules/cliente/domain/domainerr"
+	"github.com/valdinei-santos/cpf-backend/internal/modules/cliente/domain/entities"
+)
+
+// MockConsentimentoRepository é um mock com a implementação da interface IConsentimentoRepository
+type MockConsentimentoRepository struct {
+	Consentimentos []entities.Consentimento
+	Broken         *hashchain.Broken // Elo quebrado retornado pelo VerifyChain
+	mockError      error
+}
+
+// NewMockConsentimentoRepository cria uma nova instancia de MockConsentimentoRepository sem registros
+func NewMockConsentimentoRepository() *MockConsentimentoRepository {
+	return &MockConsentimentoRepository{
+		Consentimentos: []entities.Consentimento{},
+	}
+}
+
+func (m *MockConsentimentoRepository) SetMockError(err error) {
+	m.mockError = err
+}
+
+// AddConsentimento - mock do método AddConsentimento
+func (m *MockConsentimentoRepository) AddConsentimento(ctx context.Context, c *entities.Consentimento) error {
+	if m.mockError != nil {
+		return m.mockError
+	}
+	m.Consentimentos = append(m.Consentimentos, *c)
+	return nil
+}
+
+// GetConsentimentoAtual - mock do método GetConsentimentoAtual
+func (m *MockConsentimentoRepository) GetConsentimentoAtual(ctx context.Context, clienteID string, finalidade string) (*entities.Consentimento, error) {
+	if m.mockError != nil {
+		return nil, m.mockError
+	}
+	idUUID, err := uuid.Parse(clienteID)
+	if err != nil {
+		return nil, domainerr.ErrClienteIDInvalid
+	}
+	// Os registros são inseridos em ordem cronológica, o último é o vigente
+	for i := len(m.Consentimentos) - 1; i >= 0; i-- {
+		c := m.Consentimentos[i]
+		if c.ClienteID == vo.FromUUID(idUUID) && c.Finalidade.String() == finalidade {
+			return &c, nil
+		}
+	}
+	return nil, domainerr.ErrConsentimentoNotFound
+}
+
+// GetConsentimentosAtuais - mock do método GetConsentimentosAtuais
+func (m *MockConsentimentoRepository) GetConsentimentosAtuais(ctx context.Context, clienteID string) ([]*entities.Consentimento, error) {
+	if m.mockError != nil {
+		return nil, m.mockError
+	}
+	idUUID, err := uuid.Parse(clienteID)
+	if err != nil {
+		return nil, domainerr.ErrClienteIDInvalid
+	}
+	atuais := map[string]*entities.Consentimento{}
+	for i := range m.Consentimentos {
+		c := m.Consentimentos[i]
+		if c.ClienteID == vo.FromUUID(idUUID) {
+			atuais[c.Finalidade.String()] = &c
+		}
+	}
+	consentimentos := make([]*entities.Consentimento, 0, len(atuais))
+	for _, c := range atuais {
+		consentimentos = append(consentimentos, c)
+	}
+	sort.Slice(consentimentos, func(i, j int) bool {
+		return consentimentos[i].Finalidade < consentimentos[j].Finalidade
+	})
+	return consentimentos, nil
+}
+
+// GetHistoricoConsentimentos - mock do método GetHistoricoConsentimentos
+func (m *MockConsentimentoRepository) GetHistoricoConsentimentos(ctx context.Context, clienteID string) ([]*entities.Consentimento, error) {
+	if m.mockError != nil {
+		return nil, m.mockError
+	}
+	idUUID, err := uuid.Parse(clienteID)
+	if err != nil {
+		return nil, domainerr.ErrClienteIDInvalid
+	}
+	consentimentos := []*entities.Consentimento{}
+	for i := range m.Consentimentos {
+		c := m.Consentimentos[i]
+		if c.ClienteID == vo.FromUUID(idUUID) {
+			consentimentos = append(consentimentos, &c)
+		}
+	}
+	return consentimentos, nil
+}
+
+// GetConsentimentosAtivosPorFinalidade - mock do método GetConsentimentosAtivosPorFinalidade
+func (m *MockConsentimentoRepository) GetConsentimentosAtivosPorFinalidade(ctx context.Context, finalidade string, offset int64, limit int64) ([]*entities.Consentimento, int64, error) {
+	if m.mockError != nil {
+		return nil, 0, m.mockError
+	}
+
+	// Mantém apenas o registro mais recente de cada cliente, preservando a ordem de inserção
+	atuais := map[vo.ID]*entities.Consentimento{}
+	ordem := []vo.ID{}
+	for i := range m.Consentimentos {
+		c := m.Consentimentos[i]
+		if c.Finalidade.String() != finalidade {
+			continue
+		}
+		if _, ok := atuais[c.ClienteID]; !ok {
+			ordem = append(ordem, c.ClienteID)
+		}
+		atuais[c.ClienteID] = &c
+	}
+	ativos := []*entities.Consentimento{}
+	for _, id := range ordem {
+		if atuais[id].Ativo() {
+			ativos = append(ativos, atuais[id])
+		}
+	}
+
+	total := int64(len(ativos))
+	if offset > total {
+		return []*entities.Consentimento{}, total, nil
+	}
+	end := offset + limit
+	if end > total {
+		end = total
+	}
+	return ativos[offset:end], total, nil
+}
+
+// VerifyChain - mock do método VerifyChain. A cadeia é íntegra, a menos que Broken seja informado
+func (m *MockConsentimentoRepository) VerifyChain(ctx context.Context, clienteID string) (*hashchain.Report, error) {
+	if m.mockError != nil {
+		return nil, m.mockError
+	}
+	var idVO vo.ID
+	if clienteID != "" {
+		idUUID, err := uuid.Parse(clienteID)
+		if err != nil {
+			return nil, domainerr.ErrClienteIDInvalid
+		}
+		idVO = vo.FromUUID(idUUID)
+	}
+
+	report := &hashchain.Report{Broken: m.Broken}
+	for _, r := range m.Consentimentos {
+		if clienteID == "" || r.ClienteID == idVO {
+			report.Checked++
+		}
+	}
+	return report, nil
+}
@@ -0,0 +1,171 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/valdinei-santos/cpf-backend/internal/infra/database"
+	"github.com/valdinei-santos/cpf-backend/internal/infra/hashchain"
+	"github.com/valdinei-santos/cpf-backend/internal/infra/logger"
+	"github.com/valdinei-santos/cpf-backend/internal/modules/cliente/domain/entities"
+)
+
+// RepoAcessoMongoDB é um repositório para o log de acesso aos dados dos clientes no MongoDB
+type RepoAcessoMongoDB struct {
+	tenants        *database.Tenants
+	collectionName string
+	retention      time.Duration
+	chain          *hashchain.Chain
+	log            logger.ILogger
+}
+
+// acessoDocument é o registro gravado, com o tenant (apenas no tenant.ModeField), a data de
+// expiração usada pelo índice TTL e o encadeamento da auditoria
+type acessoDocument struct {
+	Tenant          string `bson:"tenant,omitempty"`
+	entities.Acesso `bson:",inline"`
+	ExpiresAt       *time.Time `bson:"expires_at,omitempty"`
+	hashchain.Link  `bson:",inline"`
+}
+
+// NewRepoAcessoMongoDB - cria uma nova instância do repositório. Os registros são removidos pelo
+// MongoDB depois de retention. Com retention zero são mantidos indefinidamente. Os registros são
+// encadeados por hash, com checkpoints assinados pelo signer.
+func NewRepoAcessoMongoDB(tenants *database.Tenants, collectionName string, retention time.Duration, signer *hashchain.Signer, l logger.ILogger) *RepoAcessoMongoDB {
+	return &RepoAcessoMongoDB{
+		tenants:        tenants,
+		collectionName: collectionName,
+		retention:      retention,
+		chain:          hashchain.NewChain(tenants, collectionName, "cliente_id", retention, signer, l),
+		log:            l,
+	}
+}
+
+// Chain retorna a cadeia de hashes do log de acesso
+func (r *RepoAcessoMongoDB) Chain() *hashchain.Chain {
+	return r.chain
+}
+
+// EnsureIndexes - cria os índices das consultas por cliente e por operador e o índice TTL da retenção.
+// A expiração é gravada em cada registro, então alterar a retenção vale apenas para os novos registros.
+func (r *RepoAcessoMongoDB) EnsureIndexes(ctx context.Context) error {
+	return r.tenants.EnsureIndexes(ctx, r.collectionName, append(r.chain.Indexes(), []mongo.IndexModel{
+		{
+			Keys:    r.tenants.IndexKeys(bson.D{{Key: "cliente_id", Value: 1}, {Key: "created_at", Value: -1}}),
+			Options: options.Index().SetName("idx_cliente_created_at"),
+		},
+		{
+			Keys:    r.tenants.IndexKeys(bson.D{{Key: "operador", Value: 1}, {Key: "created_at", Value: -1}}),
+			Options: options.Index().SetName("idx_operador_created_at"),
+		},
+		{
+			Keys:    bson.D{{Key: "expires_at", Value: 1}},
+			Options: options.Index().SetName("ttl_expires_at").SetExpireAfterSeconds(0),
+		},
+	}...))
+}
+
+// AddAcessos - encadeia e insere os registros de acesso
+func (r *RepoAcessoMongoDB) AddAcessos(ctx context.Context, acessos []*entities.Acesso) error {
+	if len(acessos) == 0 {
+		return nil
+	}
+	coll, err := r.tenants.Collection(ctx, r.collectionName)
+	if err != nil {
+		return err
+	}
+
+	records := make([]hashchain.Record, len(acessos))
+	for i, a := range acessos {
+		content, err := hashchain.Content(a)
+		if err != nil {
+			return err
+		}
+		records[i] = hashchain.Record{Key: a.ClienteID, Content: content}
+	}
+	return r.chain.Append(ctx, coll, records, func(i int, link hashchain.Link) any {
+		doc := acessoDocument{Tenant: coll.Tenant(), Acesso: *acessos[i], Link: link}
+		if r.retention > 0 {
+			expiresAt := acessos[i].CreatedAt.Add(r.retention)
+			doc.ExpiresAt = &expiresAt
+		}
+		return doc
+	})
+}
+
+// VerifyChain - verifica a cadeia de hashes do log de acesso do tenant, ou apenas a do cliente
+func (r *RepoAcessoMongoDB) VerifyChain(ctx context.Context, clienteID string) (*hashchain.Report, error) {
+	if clienteID == "" {
+		return r.chain.Verify(ctx, nil, decodeAcesso)
+	}
+	idBSON, err := clienteIDToBSON(clienteID)
+	if err != nil {
+		return nil, err
+	}
+	return r.chain.Verify(ctx, idBSON, decodeAcesso)
+}
+
+// VerifyAllChains - verifica a cadeia de hashes do log de acesso de todos os tenants
+func (r *RepoAcessoMongoDB) VerifyAllChains(ctx context.Context) ([]*hashchain.Report, error) {
+	return r.chain.VerifyAll(ctx, decodeAcesso)
+}
+
+// decodeAcesso converte o registro gravado no elo da cadeia
+func decodeAcesso(raw bson.Raw) (hashchain.Entry, error) {
+	var doc acessoDocument
+	if err := bson.Unmarshal(raw, &doc); err != nil {
+		return hashchain.Entry{}, err
+	}
+	content, err := hashchain.Content(&doc.Acesso)
+	if err != nil {
+		return hashchain.Entry{}, err
+	}
+	return hashchain.Entry{ID: doc.ID.String(), Key: doc.ClienteID.String(), Content: content, Link: doc.Link}, nil
+}
+
+// GetAcessos - retorna, com paginação e do mais recente para o mais antigo, os acessos ao cliente
+// e/ou do operador informados
+func (r *RepoAcessoMongoDB) GetAcessos(ctx context.Context, clienteID string, operador string, offset int64, limit int64) ([]*entities.Acesso, int64, error) {
+	coll, err := r.tenants.Collection(ctx, r.collectionName)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	filter := bson.M{}
+	if clienteID != "" {
+		idBSON, err := clienteIDToBSON(clienteID)
+		if err != nil {
+			return nil, 0, err
+		}
+		filter["cliente_id"] = idBSON
+	}
+	if operador != "" {
+		filter["operador"] = operador
+	}
+	filter = coll.Filter(filter)
+
+	total, err := coll.CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	findOptions := options.Find().
+		SetSkip(offset).
+		SetLimit(limit).
+		SetSort(bson.D{{Key: "created_at", Value: -1}})
+	cursor, err := coll.Find(ctx, filter, findOptions)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer cursor.Close(ctx)
+
+	acessos := []*entities.Acesso{}
+	if err = cursor.All(ctx, &acessos); err != nil {
+		return nil, 0, err
+	}
+	return acessos, total, nil
+}
@@ -3,6 +3,7 @@ package repository
 import (
 	"context"
 	"errors"
+	"fmt"
 
 	"github.com/google/uuid"
 	"go.mongodb.org/mongo-driver/bson"
@@ -10,41 +11,91 @@ import (
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 
+	"github.com/valdinei-santos/cpf-backend/internal/domain/globalerr"
+	"github.com/valdinei-santos/cpf-backend/internal/infra/database"
+	"github.com/valdinei-santos/cpf-backend/internal/infra/keyring"
 	"github.com/valdinei-santos/cpf-backend/internal/infra/logger"
+	"github.com/valdinei-santos/cpf-backend/internal/infra/tenant"
 	"github.com/valdinei-santos/cpf-backend/internal/modules/cliente/domain/domainerr"
 	"github.com/valdinei-santos/cpf-backend/internal/modules/cliente/domain/entities"
 )
 
 // RepoClienteMongoDB é um repositório para gerenciar clientes no MongoDB
 type RepoClienteMongoDB struct {
-	collection *mongo.Collection
-	log        logger.ILogger
+	tenants        *database.Tenants
+	collectionName string
+	log            logger.ILogger
+	enc            fieldEncryption
 }
 
-// NewRepoClienteMongoDB - cria uma nova instância do repositório
-func NewRepoClienteMongoDB(db *mongo.Database, collectionName string, l logger.ILogger) *RepoClienteMongoDB {
-	collection := db.Collection(collectionName)
+// NewRepoClienteMongoDB - cria uma nova instância do repositório. Todas as operações usam a collection
+// do tenant da requisição. O documento é sempre gravado cifrado pelo cipher, o telefone apenas se
+// encryptTelefone for true.
+func NewRepoClienteMongoDB(tenants *database.Tenants, collectionName string, l logger.ILogger, cipher keyring.IFieldCipher, encryptTelefone bool) *RepoClienteMongoDB {
 	return &RepoClienteMongoDB{
-		collection: collection,
-		log:        l,
+		tenants:        tenants,
+		collectionName: collectionName,
+		log:            l,
+		enc:            fieldEncryption{cipher: cipher, encryptTelefone: encryptTelefone},
 	}
 }
 
+// EnsureIndexes - cria o índice único do blind index do documento (por tenant no tenant.ModeField).
+// Registros antigos sem documento_bidx ficam fora do índice até serem recifrados pela rotação de chaves.
+func (r *RepoClienteMongoDB) EnsureIndexes(ctx context.Context) error {
+	name := "uniq_documento_bidx"
+	if r.tenants.Mode() == tenant.ModeField {
+		name = "uniq_tenant_documento_bidx"
+		// O índice sem o tenant impediria o mesmo documento em tenants diferentes
+		colls, err := r.tenants.All(ctx, r.collectionName)
+		if err != nil {
+			return err
+		}
+		for _, coll := range colls {
+			if _, err := coll.Indexes().DropOne(ctx, "uniq_documento_bidx"); err != nil {
+				r.log.DebugContext(ctx, "Índice uniq_documento_bidx não removido", "err", err.Error())
+			}
+		}
+	}
+
+	return r.tenants.EnsureIndexes(ctx, r.collectionName, []mongo.IndexModel{{
+		Keys: r.tenants.IndexKeys(bson.D{{Key: "documento_bidx", Value: 1}}),
+		Options: options.Index().
+			SetName(name).
+			SetUnique(true).
+			SetPartialFilterExpression(bson.M{"documento_bidx": bson.M{"$exists": true}}),
+	}})
+}
+
 // AddCliente - adiciona um novo cliente ao repositório
-func (r *RepoClienteMongoDB) AddCliente(p *entities.Cliente) error {
-	ctx := context.Background()
+func (r *RepoClienteMongoDB) AddCliente(ctx context.Context, p *entities.Cliente) error {
+	coll, err := r.tenants.Collection(ctx, r.collectionName)
+	if err != nil {
+		return err
+	}
 
-	_, err := r.collection.InsertOne(ctx, p)
+	doc, err := r.enc.toDocument(p)
 	if err != nil {
 		return err
 	}
+	doc.Tenant = coll.Tenant()
+	_, err = coll.InsertOne(ctx, doc)
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return globalerr.ErrDuplicatekey
+		}
+		return err
+	}
 	return nil
 }
 
 // GetClienteByID - busca um cliente por ID
-func (r *RepoClienteMongoDB) GetClienteByID(id string) (*entities.Cliente, error) {
-	ctx := context.Background()
-	var cliente entities.Cliente
+func (r *RepoClienteMongoDB) GetClienteByID(ctx context.Context, id string) (*entities.Cliente, error) {
+	coll, err := r.tenants.Collection(ctx, r.collectionName)
+	if err != nil {
+		return nil, err
+	}
+	var doc clienteDocument
 
 	idUUID, err := uuid.Parse(id)
 	if err != nil {
@@ -57,10 +108,30 @@ func (r *RepoClienteMongoDB) GetClienteByID(id string) (*entities.Cliente, error
 		Data:    idUUID[:],
 	}
 
-	// Consulta o MongoDB pelo campo _id (que deve ser o ID UUID)
-	filter := bson.M{"id": idBSON}
-	err = r.collection.FindOne(ctx, filter).Decode(&cliente)
+	// Consulta o MongoDB pelo campo id (UUID), restrito ao tenant
+	filter := coll.Filter(bson.M{"id": idBSON})
+	err = coll.FindOne(ctx, filter).Decode(&doc)
+
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, domainerr.ErrClienteNotFound
+		}
+		return nil, err
+	}
+
+	return r.enc.toEntity(&doc)
+}
 
+// GetClienteByDocumento - busca um cliente pelo documento, usando o blind index
+func (r *RepoClienteMongoDB) GetClienteByDocumento(ctx context.Context, documento string) (*entities.Cliente, error) {
+	coll, err := r.tenants.Collection(ctx, r.collectionName)
+	if err != nil {
+		return nil, err
+	}
+	var doc clienteDocument
+
+	filter := coll.Filter(bson.M{"documento_bidx": r.enc.documentoBidx(documento)})
+	err = coll.FindOne(ctx, filter).Decode(&doc)
 	if err != nil {
 		if errors.Is(err, mongo.ErrNoDocuments) {
 			return nil, domainerr.ErrClienteNotFound
@@ -68,15 +139,47 @@ func (r *RepoClienteMongoDB) GetClienteByID(id string) (*entities.Cliente, error
 		return nil, err
 	}
 
-	return &cliente, nil
+	return r.enc.toEntity(&doc)
+}
+
+// GetManyClienteByIDs - busca vários clientes por ID
+func (r *RepoClienteMongoDB) GetManyClienteByIDs(ctx context.Context, ids []string) ([]*entities.Cliente, error) {
+	coll, err := r.tenants.Collection(ctx, r.collectionName)
+	if err != nil {
+		return nil, err
+	}
+
+	idsBSON := make([]primitive.Binary, 0, len(ids))
+	for _, id := range ids {
+		idUUID, err := uuid.Parse(id)
+		if err != nil {
+			return nil, domainerr.ErrClienteIDInvalid
+		}
+		idsBSON = append(idsBSON, primitive.Binary{Subtype: 4, Data: idUUID[:]})
+	}
+
+	cursor, err := coll.Find(ctx, coll.Filter(bson.M{"id": bson.M{"$in": idsBSON}}))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var docs []*clienteDocument
+	if err = cursor.All(ctx, &docs); err != nil {
+		return nil, err
+	}
+	return r.enc.toEntities(docs)
 }
 
 // GetAllClientes - retorna todos os clientes com paginação
-func (r *RepoClienteMongoDB) GetAllClientes(offset int64, limit int64) ([]*entities.Cliente, int64, error) {
-	ctx := context.Background()
+func (r *RepoClienteMongoDB) GetAllClientes(ctx context.Context, offset int64, limit int64) ([]*entities.Cliente, int64, error) {
+	coll, err := r.tenants.Collection(ctx, r.collectionName)
+	if err != nil {
+		return nil, 0, err
+	}
 
-	// Contagem total dos documentos
-	total, err := r.collection.CountDocuments(ctx, bson.M{})
+	// Contagem total dos documentos do tenant
+	total, err := coll.CountDocuments(ctx, coll.Filter(bson.M{}))
 	if err != nil {
 		return nil, 0, err
 	}
@@ -92,23 +195,30 @@ func (r *RepoClienteMongoDB) GetAllClientes(offset int64, limit int64) ([]*entit
 	findOptions.SetLimit(limit)
 
 	// Busca os documentos paginados
-	cursor, err := r.collection.Find(ctx, bson.M{}, findOptions)
+	cursor, err := coll.Find(ctx, coll.Filter(bson.M{}), findOptions)
 	if err != nil {
 		return nil, 0, err
 	}
 	defer cursor.Close(ctx)
 
-	var clientes []*entities.Cliente
-	if err = cursor.All(ctx, &clientes); err != nil {
+	var docs []*clienteDocument
+	if err = cursor.All(ctx, &docs); err != nil {
 		return nil, 0, err
 	}
 
+	clientes, err := r.enc.toEntities(docs)
+	if err != nil {
+		return nil, 0, err
+	}
 	return clientes, total, nil
 }
 
 // UpdateCliente - atualiza os dados de um cliente existente
-func (r *RepoClienteMongoDB) UpdateCliente(id string, p *entities.Cliente) error {
-	ctx := context.Background()
+func (r *RepoClienteMongoDB) UpdateCliente(ctx context.Context, id string, p *entities.Cliente) error {
+	coll, err := r.tenants.Collection(ctx, r.collectionName)
+	if err != nil {
+		return err
+	}
 
 	idUUID, err := uuid.Parse(id)
 	if err != nil {
@@ -120,11 +230,20 @@ func (r *RepoClienteMongoDB) UpdateCliente(id string, p *entities.Cliente) error
 		Data:    idUUID[:],
 	}
 
-	filter := bson.M{"id": idBSON}
-	updateDoc := bson.M{"$set": p}
+	doc, err := r.enc.toDocument(p)
+	if err != nil {
+		return err
+	}
+	doc.Tenant = coll.Tenant()
+
+	filter := coll.Filter(bson.M{"id": idBSON})
+	updateDoc := bson.M{"$set": doc}
 
-	result, err := r.collection.UpdateOne(ctx, filter, updateDoc)
+	result, err := coll.UpdateOne(ctx, filter, updateDoc)
 	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return globalerr.ErrDuplicatekey
+		}
 		return err
 	}
 
@@ -142,8 +261,11 @@ func (r *RepoClienteMongoDB) UpdateCliente(id string, p *entities.Cliente) error
 }
 
 // DeleteCliente - remove um cliente do repositório
-func (r *RepoClienteMongoDB) DeleteCliente(id string) error {
-	ctx := context.Background()
+func (r *RepoClienteMongoDB) DeleteCliente(ctx context.Context, id string) error {
+	coll, err := r.tenants.Collection(ctx, r.collectionName)
+	if err != nil {
+		return err
+	}
 
 	idUUID, err := uuid.Parse(id)
 	if err != nil {
@@ -155,9 +277,9 @@ func (r *RepoClienteMongoDB) DeleteCliente(id string) error {
 		Data:    idUUID[:],
 	}
 
-	filter := bson.M{"id": idBSON}
+	filter := coll.Filter(bson.M{"id": idBSON})
 
-	result, err := r.collection.DeleteOne(ctx, filter)
+	result, err := coll.DeleteOne(ctx, filter)
 	if err != nil {
 		return err
 	}
@@ -170,11 +292,84 @@ func (r *RepoClienteMongoDB) DeleteCliente(id string) error {
 }
 
 // Count - retorna a contagem total de clientes
-func (r *RepoClienteMongoDB) Count() (int64, error) {
-	ctx := context.Background()
-	total, err := r.collection.CountDocuments(ctx, bson.M{})
+func (r *RepoClienteMongoDB) Count(ctx context.Context) (int64, error) {
+	coll, err := r.tenants.Collection(ctx, r.collectionName)
+	if err != nil {
+		return 0, err
+	}
+	total, err := coll.CountDocuments(ctx, coll.Filter(bson.M{}))
 	if err != nil {
 		return 0, err
 	}
 	return total, nil
 }
+
+// RotateEncryption - recifra com a chave ativa, em lotes de batchSize, os registros gravados com
+// outra chave ou ainda em texto puro, em todos os tenants. Retorna a quantidade de registros recifrados.
+func (r *RepoClienteMongoDB) RotateEncryption(ctx context.Context, batchSize int64) (int64, error) {
+	activeKey := r.enc.cipher.ActiveKeyID()
+	if activeKey == "" {
+		return 0, keyring.ErrKeyNotFound
+	}
+
+	colls, err := r.tenants.All(ctx, r.collectionName)
+	if err != nil {
+		return 0, err
+	}
+	var total int64
+	for _, coll := range colls {
+		n, err := r.rotateCollection(ctx, coll, activeKey, batchSize)
+		total += n
+		if err != nil {
+			return total, err
+		}
+	}
+	return total, nil
+}
+
+func (r *RepoClienteMongoDB) rotateCollection(ctx context.Context, coll *mongo.Collection, activeKey string, batchSize int64) (int64, error) {
+	// Registros recifrados deixam de atender o filtro, então sempre se busca o primeiro lote
+	filter := bson.M{"enc_key": bson.M{"$ne": activeKey}}
+	findOptions := options.Find().SetLimit(batchSize).SetSort(bson.D{{Key: "id", Value: 1}})
+
+	var total int64
+	for {
+		cursor, err := coll.Find(ctx, filter, findOptions)
+		if err != nil {
+			return total, err
+		}
+		var docs []*clienteDocument
+		if err = cursor.All(ctx, &docs); err != nil {
+			return total, err
+		}
+		if len(docs) == 0 {
+			return total, nil
+		}
+
+		for _, d := range docs {
+			p, err := r.enc.toEntity(d)
+			if err != nil {
+				return total, fmt.Errorf("erro ao decifrar o cliente %s: %w", d.ID.String(), err)
+			}
+			newDoc, err := r.enc.toDocument(p)
+			if err != nil {
+				return total, err
+			}
+			// A condição no enc_key evita sobrescrever um registro já regravado pela API durante a rotação
+			_, err = coll.UpdateOne(ctx,
+				bson.M{"id": d.ID, "enc_key": bson.M{"$ne": activeKey}},
+				bson.M{"$set": bson.M{
+					"documento":      newDoc.Documento,
+					"documento_bidx": newDoc.DocumentoBidx,
+					"telefone":       newDoc.Telefone,
+					"enc_key":        newDoc.EncKey,
+				}},
+			)
+			if err != nil {
+				return total, err
+			}
+			total++
+		}
+		r.log.InfoContext(ctx, "Lote de clientes recifrado", "database", coll.Database().Name(), "lote", len(docs), "total", total, "enc_key", activeKey)
+	}
+}
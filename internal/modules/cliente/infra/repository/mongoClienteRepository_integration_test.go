@@ -0,0 +1,155 @@
+//go:build integration
+// +build integration
+
+package repository_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/wait"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	domainvo "github.com/valdinei-santos/cpf-backend/internal/domain/vo"
+	"github.com/valdinei-santos/cpf-backend/internal/infra/database"
+	"github.com/valdinei-santos/cpf-backend/internal/infra/keyring"
+	"github.com/valdinei-santos/cpf-backend/internal/infra/logger"
+	"github.com/valdinei-santos/cpf-backend/internal/infra/tenant"
+	"github.com/valdinei-santos/cpf-backend/internal/modules/cliente/domain/entities"
+	"github.com/valdinei-santos/cpf-backend/internal/modules/cliente/domain/vo"
+	"github.com/valdinei-santos/cpf-backend/internal/modules/cliente/infra/repository"
+)
+
+func setupMongo(t *testing.T) *mongo.Database {
+	ctx := context.Background()
+	mongoC, err := testcontainers.GenericContainer(ctx, testcontainers.GenericContainerRequest{
+		ContainerRequest: testcontainers.ContainerRequest{
+			Image:        "mongo:6.0",
+			ExposedPorts: []string{"27017/tcp"},
+			WaitingFor:   wait.ForListeningPort("27017/tcp"),
+		},
+		Started: true,
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() { mongoC.Terminate(ctx) })
+
+	host, _ := mongoC.Host(ctx)
+	port, _ := mongoC.MappedPort(ctx, "27017")
+	client, err := mongo.Connect(ctx, options.Client().ApplyURI("mongodb://"+host+":"+port.Port()))
+	require.NoError(t, err)
+	t.Cleanup(func() { client.Disconnect(ctx) })
+	return client.Database("cpf_test")
+}
+
+// writeKeyring grava o arquivo de chaves com a chave ativa e a mesma chave de blind index
+func writeKeyring(t *testing.T, path string, active string, keys ...string) {
+	t.Helper()
+	encoded := map[string]string{}
+	for i, id := range keys {
+		encoded[id] = base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{byte(i + 1)}, 32))
+	}
+	data, err := json.Marshal(map[string]any{
+		"active_key":      active,
+		"keys":            encoded,
+		"blind_index_key": base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{9}, 32)),
+	})
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(path, data, 0o600))
+}
+
+// addClientes grava n clientes com o cipher do repositório e retorna os IDs
+func addClientes(t *testing.T, repo *repository.RepoClienteMongoDB, n int, prefix int) []string {
+	t.Helper()
+	ids := make([]string, 0, n)
+	for i := range n {
+		c := &entities.Cliente{
+			ID:        domainvo.FromUUID(uuid.New()),
+			Nome:      vo.NomeCliente(fmt.Sprintf("Cliente %d-%d", prefix, i)),
+			Documento: vo.DocumentoCliente(fmt.Sprintf("%03d%08d", prefix, i)),
+			Telefone:  vo.TelefoneCliente("11999990000"),
+			CreatedAt: time.Now().UTC().Truncate(time.Millisecond),
+			UpdatedAt: time.Now().UTC().Truncate(time.Millisecond),
+		}
+		require.NoError(t, repo.AddCliente(context.Background(), c))
+		ids = append(ids, c.ID.String())
+	}
+	return ids
+}
+
+// rawDocumentos retorna os registros gravados, sem decifrar, pelo nome do cliente
+func rawDocumentos(t *testing.T, coll *mongo.Collection) map[string]bson.M {
+	t.Helper()
+	cursor, err := coll.Find(context.Background(), bson.M{})
+	require.NoError(t, err)
+	var docs []bson.M
+	require.NoError(t, cursor.All(context.Background(), &docs))
+	byNome := map[string]bson.M{}
+	for _, d := range docs {
+		byNome[d["nome"].(string)] = d
+	}
+	return byNome
+}
+
+func TestRotateEncryption_Integration(t *testing.T) {
+	ctx := context.Background()
+	db := setupMongo(t)
+	log := logger.NewMockILogger()
+	tenants := database.NewTenants(db, tenant.ModeNone, nil, log)
+
+	path := filepath.Join(t.TempDir(), "keyring.json")
+	writeKeyring(t, path, "2025-01", "2025-01")
+	cipher, err := keyring.NewReloadable(path)
+	require.NoError(t, err)
+
+	// Registros anteriores à criptografia, na chave antiga e já na chave nova
+	plainIDs := addClientes(t, repository.NewRepoClienteMongoDB(tenants, "cliente", log, keyring.NewPlaintext(), false), 2, 1)
+	repo := repository.NewRepoClienteMongoDB(tenants, "cliente", log, cipher, true)
+	oldIDs := addClientes(t, repo, 3, 2)
+	writeKeyring(t, path, "2025-10", "2025-01", "2025-10")
+	require.NoError(t, cipher.Reload())
+	activeIDs := addClientes(t, repo, 2, 3)
+
+	coll := db.Collection("cliente")
+	before := rawDocumentos(t, coll)
+
+	total, err := repo.RotateEncryption(ctx, 2)
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(len(plainIDs)+len(oldIDs)), total, "apenas os registros fora da chave ativa são recifrados")
+	after := rawDocumentos(t, coll)
+	for nome, doc := range after {
+		assert.Equal(t, "2025-10", doc["enc_key"], nome)
+		if before[nome]["enc_key"] == "2025-10" {
+			assert.Equal(t, before[nome]["documento"], doc["documento"], "registro já na chave ativa não deve ser regravado: %s", nome)
+		} else {
+			assert.NotEqual(t, before[nome]["documento"], doc["documento"], nome)
+		}
+	}
+
+	for _, id := range append(append(plainIDs, oldIDs...), activeIDs...) {
+		c, err := repo.GetClienteByID(ctx, id)
+		require.NoError(t, err)
+		assert.Equal(t, "11999990000", c.Telefone.String())
+		found, err := repo.GetClienteByDocumento(ctx, c.Documento.String())
+		require.NoError(t, err, "o blind index deve ser recalculado na rotação")
+		assert.Equal(t, id, found.ID.String())
+	}
+
+	// Uma segunda execução não encontra registros para recifrar
+	total, err = repo.RotateEncryption(ctx, 2)
+	require.NoError(t, err)
+	assert.Zero(t, total)
+}
@@ -0,0 +1,239 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/valdinei-santos/cpf-backend/internal/infra/database"
+	"github.com/valdinei-santos/cpf-backend/internal/infra/hashchain"
+	"github.com/valdinei-santos/cpf-backend/internal/infra/logger"
+	"github.com/valdinei-santos/cpf-backend/internal/modules/cliente/domain/domainerr"
+	"github.com/valdinei-santos/cpf-backend/internal/modules/cliente/domain/entities"
+)
+
+// RepoConsentimentoMongoDB é um repositório para o histórico de consentimentos no MongoDB
+type RepoConsentimentoMongoDB struct {
+	tenants        *database.Tenants
+	collectionName string
+	chain          *hashchain.Chain
+	log            logger.ILogger
+}
+
+// consentimentoDocument é o registro gravado, com o tenant (apenas no tenant.ModeField) e o
+// encadeamento da auditoria
+type consentimentoDocument struct {
+	Tenant                 string `bson:"tenant,omitempty"`
+	entities.Consentimento `bson:",inline"`
+	hashchain.Link         `bson:",inline"`
+}
+
+// NewRepoConsentimentoMongoDB - cria uma nova instância do repositório. Todas as operações usam a
+// collection do tenant da requisição. Os registros são encadeados por hash, com checkpoints
+// assinados pelo signer.
+func NewRepoConsentimentoMongoDB(tenants *database.Tenants, collectionName string, signer *hashchain.Signer, l logger.ILogger) *RepoConsentimentoMongoDB {
+	return &RepoConsentimentoMongoDB{
+		tenants:        tenants,
+		collectionName: collectionName,
+		chain:          hashchain.NewChain(tenants, collectionName, "cliente_id", 0, signer, l),
+		log:            l,
+	}
+}
+
+// Chain retorna a cadeia de hashes do histórico de consentimentos
+func (r *RepoConsentimentoMongoDB) Chain() *hashchain.Chain {
+	return r.chain
+}
+
+// EnsureIndexes - cria os índices do encadeamento do histórico
+func (r *RepoConsentimentoMongoDB) EnsureIndexes(ctx context.Context) error {
+	return r.tenants.EnsureIndexes(ctx, r.collectionName, r.chain.Indexes())
+}
+
+// AddConsentimento - encadeia e insere um novo registro no histórico
+func (r *RepoConsentimentoMongoDB) AddConsentimento(ctx context.Context, c *entities.Consentimento) error {
+	coll, err := r.tenants.Collection(ctx, r.collectionName)
+	if err != nil {
+		return err
+	}
+
+	content, err := hashchain.Content(c)
+	if err != nil {
+		return err
+	}
+	records := []hashchain.Record{{Key: c.ClienteID, Content: content}}
+	return r.chain.Append(ctx, coll, records, func(_ int, link hashchain.Link) any {
+		return consentimentoDocument{Tenant: coll.Tenant(), Consentimento: *c, Link: link}
+	})
+}
+
+// VerifyChain - verifica a cadeia de hashes do histórico do tenant, ou apenas a do cliente
+func (r *RepoConsentimentoMongoDB) VerifyChain(ctx context.Context, clienteID string) (*hashchain.Report, error) {
+	if clienteID == "" {
+		return r.chain.Verify(ctx, nil, decodeConsentimento)
+	}
+	idBSON, err := clienteIDToBSON(clienteID)
+	if err != nil {
+		return nil, err
+	}
+	return r.chain.Verify(ctx, idBSON, decodeConsentimento)
+}
+
+// VerifyAllChains - verifica a cadeia de hashes do histórico de todos os tenants
+func (r *RepoConsentimentoMongoDB) VerifyAllChains(ctx context.Context) ([]*hashchain.Report, error) {
+	return r.chain.VerifyAll(ctx, decodeConsentimento)
+}
+
+// decodeConsentimento converte o registro gravado no elo da cadeia
+func decodeConsentimento(raw bson.Raw) (hashchain.Entry, error) {
+	var doc consentimentoDocument
+	if err := bson.Unmarshal(raw, &doc); err != nil {
+		return hashchain.Entry{}, err
+	}
+	content, err := hashchain.Content(&doc.Consentimento)
+	if err != nil {
+		return hashchain.Entry{}, err
+	}
+	return hashchain.Entry{ID: doc.ID.String(), Key: doc.ClienteID.String(), Content: content, Link: doc.Link}, nil
+}
+
+// GetConsentimentoAtual - retorna o registro mais recente do cliente para a finalidade
+func (r *RepoConsentimentoMongoDB) GetConsentimentoAtual(ctx context.Context, clienteID string, finalidade string) (*entities.Consentimento, error) {
+	coll, err := r.tenants.Collection(ctx, r.collectionName)
+	if err != nil {
+		return nil, err
+	}
+
+	idBSON, err := clienteIDToBSON(clienteID)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := coll.Filter(bson.M{"cliente_id": idBSON, "finalidade": finalidade})
+	findOptions := options.FindOne().SetSort(bson.D{{Key: "created_at", Value: -1}})
+
+	var consentimento entities.Consentimento
+	err = coll.FindOne(ctx, filter, findOptions).Decode(&consentimento)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, domainerr.ErrConsentimentoNotFound
+		}
+		return nil, err
+	}
+	return &consentimento, nil
+}
+
+// GetConsentimentosAtuais - retorna o registro mais recente de cada finalidade do cliente
+func (r *RepoConsentimentoMongoDB) GetConsentimentosAtuais(ctx context.Context, clienteID string) ([]*entities.Consentimento, error) {
+	coll, err := r.tenants.Collection(ctx, r.collectionName)
+	if err != nil {
+		return nil, err
+	}
+
+	idBSON, err := clienteIDToBSON(clienteID)
+	if err != nil {
+		return nil, err
+	}
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: coll.Filter(bson.M{"cliente_id": idBSON})}},
+		{{Key: "$sort", Value: bson.D{{Key: "created_at", Value: -1}}}},
+		{{Key: "$group", Value: bson.M{"_id": "$finalidade", "atual": bson.M{"$first": "$$ROOT"}}}},
+		{{Key: "$replaceRoot", Value: bson.M{"newRoot": "$atual"}}},
+		{{Key: "$sort", Value: bson.D{{Key: "finalidade", Value: 1}}}},
+	}
+
+	cursor, err := coll.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	consentimentos := []*entities.Consentimento{}
+	if err = cursor.All(ctx, &consentimentos); err != nil {
+		return nil, err
+	}
+	return consentimentos, nil
+}
+
+// GetHistoricoConsentimentos - retorna todos os registros do cliente, do mais antigo para o mais recente
+func (r *RepoConsentimentoMongoDB) GetHistoricoConsentimentos(ctx context.Context, clienteID string) ([]*entities.Consentimento, error) {
+	coll, err := r.tenants.Collection(ctx, r.collectionName)
+	if err != nil {
+		return nil, err
+	}
+
+	idBSON, err := clienteIDToBSON(clienteID)
+	if err != nil {
+		return nil, err
+	}
+
+	findOptions := options.Find().SetSort(bson.D{{Key: "created_at", Value: 1}})
+	cursor, err := coll.Find(ctx, coll.Filter(bson.M{"cliente_id": idBSON}), findOptions)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	consentimentos := []*entities.Consentimento{}
+	if err = cursor.All(ctx, &consentimentos); err != nil {
+		return nil, err
+	}
+	return consentimentos, nil
+}
+
+// GetConsentimentosAtivosPorFinalidade - retorna, com paginação, o consentimento vigente de cada cliente
+// que concedeu a finalidade informada. Usado nas exportações para campanhas.
+func (r *RepoConsentimentoMongoDB) GetConsentimentosAtivosPorFinalidade(ctx context.Context, finalidade string, offset int64, limit int64) ([]*entities.Consentimento, int64, error) {
+	coll, err := r.tenants.Collection(ctx, r.collectionName)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: coll.Filter(bson.M{"finalidade": finalidade})}},
+		{{Key: "$sort", Value: bson.D{{Key: "created_at", Value: -1}}}},
+		{{Key: "$group", Value: bson.M{"_id": "$cliente_id", "atual": bson.M{"$first": "$$ROOT"}}}},
+		{{Key: "$replaceRoot", Value: bson.M{"newRoot": "$atual"}}},
+		{{Key: "$match", Value: bson.M{"status": "concedido"}}},
+		{{Key: "$sort", Value: bson.D{{Key: "concedido_em", Value: 1}, {Key: "cliente_id", Value: 1}}}},
+		{{Key: "$facet", Value: bson.M{
+			"total": bson.A{bson.M{"$count": "count"}},
+			"items": bson.A{bson.M{"$skip": offset}, bson.M{"$limit": limit}},
+		}}},
+	}
+
+	cursor, err := coll.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer cursor.Close(ctx)
+
+	var result []struct {
+		Total []struct {
+			Count int64 `bson:"count"`
+		} `bson:"total"`
+		Items []*entities.Consentimento `bson:"items"`
+	}
+	if err = cursor.All(ctx, &result); err != nil {
+		return nil, 0, err
+	}
+	if len(result) == 0 || len(result[0].Total) == 0 {
+		return []*entities.Consentimento{}, 0, nil
+	}
+	return result[0].Items, result[0].Total[0].Count, nil
+}
+
+// clienteIDToBSON converte o ID do cliente para o formato Binário do Mongo (UUID)
+func clienteIDToBSON(clienteID string) (primitive.Binary, error) {
+	idUUID, err := uuid.Parse(clienteID)
+	if err != nil {
+		return primitive.Binary{}, domainerr.ErrClienteIDInvalid
+	}
+	return primitive.Binary{Subtype: 4, Data: idUUID[:]}, nil
+}
@@ -1,9 +1,26 @@
 package cliente
 
 import (
+	"context"
+
+	"github.com/valdinei-santos/cpf-backend/internal/infra/config"
+	"github.com/valdinei-santos/cpf-backend/internal/infra/database"
+	"github.com/valdinei-santos/cpf-backend/internal/infra/hashchain"
+	"github.com/valdinei-santos/cpf-backend/internal/infra/keyring"
 	"github.com/valdinei-santos/cpf-backend/internal/infra/logger"
+	"github.com/valdinei-santos/cpf-backend/internal/infra/masking"
+	"github.com/valdinei-santos/cpf-backend/internal/infra/rbac"
+	"github.com/valdinei-santos/cpf-backend/internal/infra/worker"
 	"github.com/valdinei-santos/cpf-backend/internal/modules/cliente/infra/controller"
 	"github.com/valdinei-santos/cpf-backend/internal/modules/cliente/infra/repository"
+	"github.com/valdinei-santos/cpf-backend/internal/modules/cliente/usecases/accesslist"
+	"github.com/valdinei-santos/cpf-backend/internal/modules/cliente/usecases/accessrecord"
+	"github.com/valdinei-santos/cpf-backend/internal/modules/cliente/usecases/auditverify"
+	"github.com/valdinei-santos/cpf-backend/internal/modules/cliente/usecases/consentgrant"
+	"github.com/valdinei-santos/cpf-backend/internal/modules/cliente/usecases/consenthistory"
+	"github.com/valdinei-santos/cpf-backend/internal/modules/cliente/usecases/consentlist"
+	"github.com/valdinei-santos/cpf-backend/internal/modules/cliente/usecases/consentrevoke"
+	"github.com/valdinei-santos/cpf-backend/internal/modules/cliente/usecases/consentstatus"
 	"github.com/valdinei-santos/cpf-backend/internal/modules/cliente/usecases/create"
 	"github.com/valdinei-santos/cpf-backend/internal/modules/cliente/usecases/delete"
 	"github.com/valdinei-santos/cpf-backend/internal/modules/cliente/usecases/get"
@@ -15,22 +32,44 @@ import (
 // ModuleCliente é uma estrutura que contém os controladores (handlers). Mudei para usar só um controller por módulo
 // para as rotas. Ela será criada uma única vez.
 type ModuleCliente struct {
-	Controller *controller.ClienteController
+	Controller              *controller.ClienteController
+	ConsentimentoController *controller.ConsentimentoController
+	AcessoController        *controller.AcessoController
+	AuditoriaController     *controller.AuditoriaController
 }
 
-// NewModuleCliente - Inicializa TODAS as dependências do módulo uma única vez.
-func NewModuleCliente(log logger.ILogger, db *mongo.Database) *ModuleCliente {
+// NewModuleCliente - Inicializa TODAS as dependências do módulo uma única vez. O signer assina os
+// checkpoints da cadeia de auditoria, nil os desabilita. Os checkpoints rodam no grupo workers.
+func NewModuleCliente(log logger.ILogger, db *mongo.Database, cfg *config.Config, cipher keyring.IFieldCipher, signer *hashchain.Signer, authz *rbac.Authorizer, workers *worker.Group) *ModuleCliente {
 	log.Info("Inicializando Módulo Cliente...")
 
-	repo := repository.NewRepoClienteMongoDB(db, "cliente", log)
-	createUC := create.NewUseCase(repo, log)
+	// Collections do tenant da requisição, conforme o TENANT_MODE
+	tenants := database.NewTenants(db, cfg.Tenant.Mode, cfg.Tenant.Allowed, log)
+
+	repo := repository.NewRepoClienteMongoDB(tenants, cfg.Mongo.Collections.Cliente, log, cipher, cfg.Crypto.EncryptTelefone)
+	if err := repo.EnsureIndexes(context.Background()); err != nil {
+		log.Error("Erro ao criar os índices da collection "+cfg.Mongo.Collections.Cliente, "err", err.Error())
+	}
+
+	// Log de acesso (LGPD): toda leitura de dados pessoais é registrada
+	repoAcesso := repository.NewRepoAcessoMongoDB(tenants, cfg.Mongo.Collections.Acesso, cfg.Audit.AccessLogRetention(), signer, log)
+	if err := repoAcesso.EnsureIndexes(context.Background()); err != nil {
+		log.Error("Erro ao criar os índices da collection "+cfg.Mongo.Collections.Acesso, "err", err.Error())
+	}
+	accessRecordUC := accessrecord.NewUseCase(repoAcesso, log)
+
+	createUC := create.NewUseCase(repo, authz, log)
 	deleteUC := delete.NewUseCase(repo, log)
-	getUC := get.NewUseCase(repo, log)
-	getAllUC := getall.NewUseCase(repo, log)
-	updateUC := update.NewUseCase(repo, log)
+	getUC := get.NewUseCase(repo, accessRecordUC, log)
+	getAllUC := getall.NewUseCase(repo, accessRecordUC, log)
+	updateUC := update.NewUseCase(repo, authz, log)
+
+	// Mascaramento de documento/telefone nas respostas. Os pedidos de unmask vão para o log de acesso
+	maskingPolicy := masking.NewPolicy(authz, accessRecordUC, log)
 
 	clienteController := controller.NewClienteController(
 		log,
+		maskingPolicy,
 		createUC,
 		deleteUC,
 		getUC,
@@ -38,7 +77,33 @@ func NewModuleCliente(log logger.ILogger, db *mongo.Database) *ModuleCliente {
 		updateUC,
 	)
 
+	// Sub-recurso de consentimentos (LGPD) do cliente
+	repoConsentimento := repository.NewRepoConsentimentoMongoDB(tenants, cfg.Mongo.Collections.Consentimento, signer, log)
+	if err := repoConsentimento.EnsureIndexes(context.Background()); err != nil {
+		log.Error("Erro ao criar os índices da collection "+cfg.Mongo.Collections.Consentimento, "err", err.Error())
+	}
+	consentimentoController := controller.NewConsentimentoController(
+		log,
+		maskingPolicy,
+		consentgrant.NewUseCase(repo, repoConsentimento, log),
+		consentrevoke.NewUseCase(repoConsentimento, log),
+		consentstatus.NewUseCase(repoConsentimento, log),
+		consenthistory.NewUseCase(repoConsentimento, log),
+		consentlist.NewUseCase(repo, repoConsentimento, accessRecordUC, log),
+	)
+
+	// Histórico de consentimentos e log de acesso são encadeados por hash, com o topo das cadeias
+	// assinado periodicamente
+	if signer != nil && cfg.Audit.CheckpointInterval > 0 {
+		workers.Go("audit_checkpoint", func(ctx context.Context) {
+			hashchain.RunCheckpoints(ctx, cfg.Audit.CheckpointInterval, log, repoConsentimento.Chain(), repoAcesso.Chain())
+		})
+	}
+
 	return &ModuleCliente{
-		Controller: clienteController,
+		Controller:              clienteController,
+		ConsentimentoController: consentimentoController,
+		AcessoController:        controller.NewAcessoController(log, accesslist.NewUseCase(repoAcesso, log)),
+		AuditoriaController:     controller.NewAuditoriaController(log, auditverify.NewUseCase(repoConsentimento, repoAcesso, log)),
 	}
 }
@@ -0,0 +1,16 @@
+package accesslist
+
+import (
+	"context"
+
+	"github.com/valdinei-santos/cpf-backend/internal/infra/rbac"
+	"github.com/valdinei-santos/cpf-backend/internal/modules/cliente/infra/dto"
+)
+
+// Permission - permissão exigida na rota do caso de uso
+const Permission rbac.Permission = "acesso:read"
+
+// IUsecase - Consulta do log de acesso por cliente ou por operador
+type IUsecase interface {
+	Execute(ctx context.Context, clienteID string, operador string, page int64, size int64) (*dto.AcessosPaginated, error)
+}
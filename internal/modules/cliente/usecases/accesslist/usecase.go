@@ -0,0 +1,80 @@
+package accesslist
+
+import (
+	"context"
+	"math"
+
+	"github.com/valdinei-santos/cpf-backend/internal/infra/logger"
+	"github.com/valdinei-santos/cpf-backend/internal/infra/tracing"
+	"github.com/valdinei-santos/cpf-backend/internal/modules/cliente/domain/domainerr"
+	"github.com/valdinei-santos/cpf-backend/internal/modules/cliente/infra/dto"
+	"github.com/valdinei-santos/cpf-backend/internal/modules/cliente/infra/repository"
+)
+
+// UseCase - Estrutura para o caso de uso de consulta do log de acesso
+type UseCase struct {
+	repo repository.IAcessoRepository
+	log  logger.ILogger
+}
+
+// NewUseCase - Construtor do caso de uso
+func NewUseCase(r repository.IAcessoRepository, l logger.ILogger) *UseCase {
+	return &UseCase{
+		repo: r,
+		log:  l,
+	}
+}
+
+// @Summary        Consulta o log de acesso aos dados dos clientes
+// @Description    Retorna, paginadas, as leituras dos dados pessoais de um cliente ou as leituras feitas por um operador (LGPD)
+// @Tags           acessos
+// @Produce        json
+// @Param          id path string false "Cliente ID (rota por cliente)"
+// @Param          operador query string false "Usuário ou serviço que acessou os dados (rota por operador)"
+// @Param          page query int64 false "Numero da página a ser retornada"
+// @Param          size query int64 false "Quantidade de itens na página a ser retornada"
+// @Param        Accept-Language header string false "Idioma das mensagens de erro: pt-BR (padrão) ou en-US" Enums(pt-BR, en-US)
+// @Success        200 {object} dto.AcessosPaginated
+// @Failure        400 {object} problem.Problem
+// @Failure        403 {object} problem.Problem
+// @Router         /cliente/{id}/acessos [get]
+// @Router         /cliente/acessos [get]
+// Execute - Retorna, paginados e do mais recente para o mais antigo, os acessos aos dados de um
+// cliente ou os acessos feitos por um operador. Ao menos um dos filtros é obrigatório.
+func (u *UseCase) Execute(ctx context.Context, clienteID string, operador string, page int64, size int64) (*dto.AcessosPaginated, error) {
+	u.log.DebugContext(ctx, "Entrou accesslist.Execute")
+	ctx, span := tracing.Start(ctx, "accesslist.Execute")
+	defer span.End()
+
+	if clienteID == "" && operador == "" {
+		u.log.ErrorContext(ctx, domainerr.ErrAcessoOperadorInvalid.Error(), "mtd", "accesslist.Execute")
+		return nil, domainerr.ErrAcessoOperadorInvalid
+	}
+
+	// Calcula o offset para o repositório
+	offset := (page - 1) * size
+
+	acessos, totalItems, err := u.repo.GetAcessos(ctx, clienteID, operador, offset, size)
+	if err != nil {
+		u.log.ErrorContext(ctx, err.Error(), "mtd", "u.repo.GetAcessos")
+		return nil, err
+	}
+
+	lista := make([]dto.AcessoResponse, len(acessos))
+	for i, a := range acessos {
+		lista[i] = dto.NewAcessoResponse(a)
+	}
+
+	// Calcula o total de páginas
+	totalPages := int(math.Ceil(float64(totalItems) / float64(size)))
+
+	return &dto.AcessosPaginated{
+		ClienteID:    clienteID,
+		Operador:     operador,
+		Acessos:      lista,
+		TotalItems:   totalItems,
+		TotalPages:   int64(totalPages),
+		CurrentPage:  page,
+		ItemsPerPage: size,
+	}, nil
+}
@@ -0,0 +1,144 @@
+package accesslist_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/valdinei-santos/cpf-backend/internal/domain/vo"
+	"github.com/valdinei-santos/cpf-backend/internal/infra/logger"
+	"github.com/valdinei-santos/cpf-backend/internal/modules/cliente/domain/domainerr"
+	"github.com/valdinei-santos/cpf-backend/internal/modules/cliente/domain/entities"
+	"github.com/valdinei-santos/cpf-backend/internal/modules/cliente/infra/repository"
+	"github.com/valdinei-santos/cpf-backend/internal/modules/cliente/usecases/accesslist"
+)
+
+func TestExecute(t *testing.T) {
+	mockRepoCliente := repository.NewMockClienteRepository()
+	cliente0 := mockRepoCliente.Clientes[0].ID
+	cliente1 := mockRepoCliente.Clientes[1].ID
+
+	// atendente-1 consultou os clientes 0 e 1, auditor-1 consultou o cliente 0
+	mockRepo := repository.NewMockAcessoRepository()
+	for _, op := range []struct {
+		subject string
+		ids     []vo.ID
+	}{
+		{"atendente-1", []vo.ID{cliente0, cliente1}},
+		{"auditor-1", []vo.ID{cliente0}},
+	} {
+		acessos, _ := entities.NewAcessos(op.ids, entities.AcessoConsulta, entities.Operador{Subject: op.subject}, false)
+		_ = mockRepo.AddAcessos(context.Background(), acessos)
+	}
+
+	tests := []struct {
+		name          string
+		repo          *repository.MockAcessoRepository
+		logger        *logger.MockILogger
+		clienteID     string
+		operador      string
+		page          int64
+		size          int64
+		expectedTotal int64
+		expectedPages int64
+		expectedLen   int
+		expectedErr   error
+		expectDebug   bool
+		expectError   bool
+	}{
+		{
+			name:          "Deve listar os acessos aos dados do cliente",
+			repo:          mockRepo,
+			logger:        logger.NewMockILogger(),
+			clienteID:     cliente0.String(),
+			page:          1,
+			size:          10,
+			expectedTotal: 2,
+			expectedPages: 1,
+			expectedLen:   2,
+			expectDebug:   true,
+			expectError:   false,
+		},
+		{
+			name:          "Deve listar e paginar os acessos feitos pelo operador",
+			repo:          mockRepo,
+			logger:        logger.NewMockILogger(),
+			operador:      "atendente-1",
+			page:          2,
+			size:          1,
+			expectedTotal: 2,
+			expectedPages: 2,
+			expectedLen:   1,
+			expectDebug:   true,
+			expectError:   false,
+		},
+		{
+			name:          "Deve retornar lista vazia para operador sem acessos",
+			repo:          mockRepo,
+			logger:        logger.NewMockILogger(),
+			operador:      "desconhecido",
+			page:          1,
+			size:          10,
+			expectedTotal: 0,
+			expectedPages: 0,
+			expectedLen:   0,
+			expectDebug:   true,
+			expectError:   false,
+		},
+		{
+			name:        "Deve retornar erro quando nenhum filtro é informado",
+			repo:        mockRepo,
+			logger:      logger.NewMockILogger(),
+			page:        1,
+			size:        10,
+			expectedErr: domainerr.ErrAcessoOperadorInvalid,
+			expectDebug: true,
+			expectError: true,
+		},
+		{
+			name: "Deve retornar erro quando o repositório falha",
+			repo: func() *repository.MockAcessoRepository {
+				r := repository.NewMockAcessoRepository()
+				r.SetMockError(errors.New("erro de conexão com o banco de dados"))
+				return r
+			}(),
+			logger:      logger.NewMockILogger(),
+			operador:    "atendente-1",
+			page:        1,
+			size:        10,
+			expectedErr: errors.New("erro de conexão com o banco de dados"),
+			expectDebug: true,
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc := accesslist.NewUseCase(tt.repo, tt.logger)
+
+			resp, err := uc.Execute(context.Background(), tt.clienteID, tt.operador, tt.page, tt.size)
+
+			if tt.expectedErr != nil {
+				assert.EqualError(t, err, tt.expectedErr.Error())
+				assert.Nil(t, resp)
+			} else {
+				assert.Nil(t, err)
+				assert.Len(t, resp.Acessos, tt.expectedLen)
+				for _, a := range resp.Acessos {
+					if tt.clienteID != "" {
+						assert.Equal(t, tt.clienteID, a.ClienteID)
+					}
+					if tt.operador != "" {
+						assert.Equal(t, tt.operador, a.Operador)
+					}
+				}
+				assert.Equal(t, tt.expectedTotal, resp.TotalItems)
+				assert.Equal(t, tt.expectedPages, resp.TotalPages)
+			}
+
+			assert.Equal(t, tt.expectDebug, tt.logger.DebugCalled)
+			assert.Equal(t, tt.expectError, tt.logger.ErrorCalled)
+		})
+	}
+}
@@ -0,0 +1,13 @@
+package accessrecord
+
+import (
+	"context"
+
+	"github.com/valdinei-santos/cpf-backend/internal/domain/vo"
+	"github.com/valdinei-santos/cpf-backend/internal/infra/masking"
+)
+
+// IUsecase - Registro das leituras de dados pessoais, usado pelos casos de uso de consulta
+type IUsecase interface {
+	Execute(ctx context.Context, acao string, clienteIDs []vo.ID, mode masking.Mode) error
+}
@@ -0,0 +1,96 @@
+package accessrecord
+
+import (
+	"context"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/valdinei-santos/cpf-backend/internal/domain/globalerr"
+	"github.com/valdinei-santos/cpf-backend/internal/domain/vo"
+	"github.com/valdinei-santos/cpf-backend/internal/infra/identity"
+	"github.com/valdinei-santos/cpf-backend/internal/infra/logger"
+	"github.com/valdinei-santos/cpf-backend/internal/infra/masking"
+	"github.com/valdinei-santos/cpf-backend/internal/infra/requestinfo"
+	"github.com/valdinei-santos/cpf-backend/internal/infra/tracing"
+	"github.com/valdinei-santos/cpf-backend/internal/modules/cliente/domain/entities"
+	"github.com/valdinei-santos/cpf-backend/internal/modules/cliente/infra/repository"
+)
+
+// UseCase - Estrutura para o caso de uso de registro de acesso aos dados do cliente
+type UseCase struct {
+	repo repository.IAcessoRepository
+	log  logger.ILogger
+}
+
+// NewUseCase - Construtor do caso de uso
+func NewUseCase(r repository.IAcessoRepository, l logger.ILogger) *UseCase {
+	return &UseCase{
+		repo: r,
+		log:  l,
+	}
+}
+
+// Execute - registra quem leu os dados dos clientes, com a finalidade e o IP da requisição.
+// Se o registro falhar retorna globalerr.ErrInternal e os dados não devem ser retornados.
+func (u *UseCase) Execute(ctx context.Context, acao string, clienteIDs []vo.ID, mode masking.Mode) error {
+	u.log.DebugContext(ctx, "Entrou accessrecord.Execute")
+	ctx, span := tracing.Start(ctx, "accessrecord.Execute")
+	defer span.End()
+	if len(clienteIDs) == 0 {
+		return nil
+	}
+
+	op := operador(ctx)
+	acessos, err := entities.NewAcessos(clienteIDs, acao, op, mode == masking.Unmasked)
+	if err != nil {
+		u.log.ErrorContext(ctx, err.Error(), "mtd", "entities.NewAcessos")
+		return globalerr.ErrInternal
+	}
+
+	err = u.repo.AddAcessos(ctx, acessos)
+	if err != nil {
+		u.log.ErrorContext(ctx, err.Error(), "mtd", "u.repo.AddAcessos", "acao", acao, "operador", op.Subject)
+		return globalerr.ErrInternal
+	}
+	return nil
+}
+
+// RecordUnmask - registra o pedido de dados sem máscara, permitido ou negado, com o operador da
+// requisição. O resource cliente:<id> é gravado também como o cliente do registro.
+// Se o registro falhar retorna globalerr.ErrInternal.
+func (u *UseCase) RecordUnmask(ctx context.Context, resource string, granted bool) error {
+	u.log.DebugContext(ctx, "Entrou accessrecord.RecordUnmask")
+	ctx, span := tracing.Start(ctx, "accessrecord.RecordUnmask")
+	defer span.End()
+
+	var clienteID vo.ID
+	if id, ok := strings.CutPrefix(resource, "cliente:"); ok {
+		if idUUID, err := uuid.Parse(id); err == nil {
+			clienteID = vo.FromUUID(idUUID)
+		}
+	}
+	op := operador(ctx)
+	acesso, err := entities.NewAcessoUnmask(clienteID, resource, op, granted)
+	if err != nil {
+		u.log.ErrorContext(ctx, err.Error(), "mtd", "entities.NewAcessoUnmask")
+		return globalerr.ErrInternal
+	}
+
+	err = u.repo.AddAcessos(ctx, []*entities.Acesso{acesso})
+	if err != nil {
+		u.log.ErrorContext(ctx, err.Error(), "mtd", "u.repo.AddAcessos", "acao", acesso.Acao, "operador", op.Subject)
+		return globalerr.ErrInternal
+	}
+	return nil
+}
+
+// operador retorna quem fez a requisição, com a finalidade e o IP informados
+func operador(ctx context.Context) entities.Operador {
+	id, info := identity.FromContext(ctx), requestinfo.FromContext(ctx)
+	return entities.Operador{
+		Subject:    id.Subject,
+		Metodo:     id.Method,
+		Finalidade: info.Finalidade,
+		IP:         info.IP,
+	}
+}
@@ -0,0 +1,160 @@
+package accessrecord_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/valdinei-santos/cpf-backend/internal/domain/globalerr"
+	"github.com/valdinei-santos/cpf-backend/internal/domain/vo"
+	"github.com/valdinei-santos/cpf-backend/internal/infra/identity"
+	"github.com/valdinei-santos/cpf-backend/internal/infra/logger"
+	"github.com/valdinei-santos/cpf-backend/internal/infra/masking"
+	"github.com/valdinei-santos/cpf-backend/internal/infra/requestinfo"
+	"github.com/valdinei-santos/cpf-backend/internal/modules/cliente/domain/entities"
+	"github.com/valdinei-santos/cpf-backend/internal/modules/cliente/infra/repository"
+	"github.com/valdinei-santos/cpf-backend/internal/modules/cliente/usecases/accessrecord"
+)
+
+func TestExecute(t *testing.T) {
+	mockRepoCliente := repository.NewMockClienteRepository()
+	ids := []vo.ID{mockRepoCliente.Clientes[0].ID, mockRepoCliente.Clientes[1].ID}
+
+	// Requisição autenticada com finalidade informada
+	ctx := identity.NewContext(context.Background(), &identity.Identity{Subject: "atendente-1", Method: "jwt"})
+	ctx = requestinfo.NewContext(ctx, requestinfo.Info{IP: "10.0.0.1", Finalidade: "atendimento"})
+
+	tests := []struct {
+		name         string
+		ctx          context.Context
+		ids          []vo.ID
+		mode         masking.Mode
+		repoErr      error
+		expectedErr  error
+		expectAcesso int
+		expectError  bool
+	}{
+		{
+			name:         "Deve registrar um acesso para cada cliente com o operador da requisição",
+			ctx:          ctx,
+			ids:          ids,
+			mode:         masking.Unmasked,
+			expectAcesso: 2,
+			expectError:  false,
+		},
+		{
+			name:         "Deve registrar o acesso mascarado de chamador anônimo",
+			ctx:          context.Background(),
+			ids:          ids[:1],
+			mode:         masking.Masked,
+			expectAcesso: 1,
+			expectError:  false,
+		},
+		{
+			name:         "Não deve registrar nada quando nenhum cliente foi lido",
+			ctx:          ctx,
+			ids:          []vo.ID{},
+			expectAcesso: 0,
+			expectError:  false,
+		},
+		{
+			name:        "Deve retornar erro interno quando o repositório falha",
+			ctx:         ctx,
+			ids:         ids,
+			repoErr:     errors.New("erro de conexão com o banco de dados"),
+			expectedErr: globalerr.ErrInternal,
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := repository.NewMockAcessoRepository()
+			repo.SetMockError(tt.repoErr)
+			log := logger.NewMockILogger()
+			uc := accessrecord.NewUseCase(repo, log)
+
+			err := uc.Execute(tt.ctx, entities.AcessoConsulta, tt.ids, tt.mode)
+
+			assert.Equal(t, tt.expectedErr, err)
+			assert.Len(t, repo.Acessos, tt.expectAcesso)
+			id, info := identity.FromContext(tt.ctx), requestinfo.FromContext(tt.ctx)
+			for i, a := range repo.Acessos {
+				assert.Equal(t, tt.ids[i], a.ClienteID)
+				assert.Equal(t, entities.AcessoConsulta, a.Acao)
+				assert.Equal(t, id.Subject, a.Operador)
+				assert.Equal(t, id.Method, a.Metodo)
+				assert.Equal(t, info.Finalidade, a.Finalidade)
+				assert.Equal(t, info.IP, a.IP)
+				assert.Equal(t, tt.mode == masking.Unmasked, a.Completo)
+			}
+			assert.True(t, log.DebugCalled)
+			assert.Equal(t, tt.expectError, log.ErrorCalled)
+		})
+	}
+}
+
+func TestRecordUnmask(t *testing.T) {
+	clienteID := repository.NewMockClienteRepository().Clientes[0].ID
+
+	ctx := identity.NewContext(context.Background(), &identity.Identity{Subject: "supervisor-1", Method: "jwt"})
+	ctx = requestinfo.NewContext(ctx, requestinfo.Info{IP: "10.0.0.1"})
+
+	tests := []struct {
+		name            string
+		resource        string
+		granted         bool
+		repoErr         error
+		expectedErr     error
+		expectAcao      string
+		expectClienteID vo.ID
+		expectAcesso    int
+	}{
+		{
+			name:            "Deve registrar o unmask permitido com o cliente do recurso",
+			resource:        "cliente:" + clienteID.String(),
+			granted:         true,
+			expectAcao:      entities.AcessoUnmask,
+			expectClienteID: clienteID,
+			expectAcesso:    1,
+		},
+		{
+			name:         "Deve registrar o unmask negado sem cliente quando o recurso é a listagem",
+			resource:     "cliente:lista",
+			expectAcao:   entities.AcessoUnmaskNegado,
+			expectAcesso: 1,
+		},
+		{
+			name:        "Deve retornar erro interno quando o repositório falha",
+			resource:    "cliente:lista",
+			granted:     true,
+			repoErr:     errors.New("erro de conexão com o banco de dados"),
+			expectedErr: globalerr.ErrInternal,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := repository.NewMockAcessoRepository()
+			repo.SetMockError(tt.repoErr)
+			log := logger.NewMockILogger()
+			uc := accessrecord.NewUseCase(repo, log)
+
+			err := uc.RecordUnmask(ctx, tt.resource, tt.granted)
+
+			assert.Equal(t, tt.expectedErr, err)
+			assert.Len(t, repo.Acessos, tt.expectAcesso)
+			for _, a := range repo.Acessos {
+				assert.Equal(t, tt.expectAcao, a.Acao)
+				assert.Equal(t, tt.expectClienteID, a.ClienteID)
+				assert.Equal(t, tt.resource, a.Recurso)
+				assert.Equal(t, "supervisor-1", a.Operador)
+				assert.Equal(t, "10.0.0.1", a.IP)
+				assert.Equal(t, tt.granted, a.Completo)
+			}
+			assert.True(t, log.DebugCalled)
+			assert.Equal(t, tt.expectedErr != nil, log.ErrorCalled)
+		})
+	}
+}
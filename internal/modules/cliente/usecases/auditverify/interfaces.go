@@ -0,0 +1,22 @@
+package auditverify
+
+import (
+	"context"
+
+	"github.com/valdinei-santos/cpf-backend/internal/infra/rbac"
+	"github.com/valdinei-santos/cpf-backend/internal/modules/cliente/infra/dto"
+)
+
+// Permission - permissão exigida na rota do caso de uso
+const Permission rbac.Permission = "auditoria:verify"
+
+// Cadeias de registros de auditoria verificáveis
+const (
+	CadeiaConsentimento = "consentimento" // Histórico de consentimentos
+	CadeiaAcesso        = "acesso"        // Log de acesso aos dados dos clientes
+)
+
+// IUsecase - Verificação da cadeia de hashes dos registros de auditoria
+type IUsecase interface {
+	Execute(ctx context.Context, cadeia string, clienteID string) (*dto.VerificacaoResponse, error)
+}
@@ -0,0 +1,64 @@
+package auditverify
+
+import (
+	"context"
+
+	"github.com/valdinei-santos/cpf-backend/internal/infra/logger"
+	"github.com/valdinei-santos/cpf-backend/internal/infra/tracing"
+	"github.com/valdinei-santos/cpf-backend/internal/modules/cliente/domain/domainerr"
+	"github.com/valdinei-santos/cpf-backend/internal/modules/cliente/infra/dto"
+	"github.com/valdinei-santos/cpf-backend/internal/modules/cliente/infra/repository"
+)
+
+// UseCase - Estrutura para o caso de uso de verificação da cadeia de auditoria
+type UseCase struct {
+	chains map[string]repository.IChainRepository
+	log    logger.ILogger
+}
+
+// NewUseCase - Construtor do caso de uso
+func NewUseCase(rc repository.IChainRepository, ra repository.IChainRepository, l logger.ILogger) *UseCase {
+	return &UseCase{
+		chains: map[string]repository.IChainRepository{
+			CadeiaConsentimento: rc,
+			CadeiaAcesso:        ra,
+		},
+		log: l,
+	}
+}
+
+// @Summary        Verifica a integridade dos registros de auditoria
+// @Description    Percorre a cadeia de hashes do histórico de consentimentos ou do log de acesso e retorna o primeiro elo quebrado (registro alterado ou removido diretamente no banco)
+// @Tags           auditoria
+// @Produce        json
+// @Param          cadeia query string true "Cadeia a verificar" Enums(consentimento, acesso)
+// @Param          cliente_id query string false "Verifica apenas os registros do cliente"
+// @Param        Accept-Language header string false "Idioma das mensagens de erro: pt-BR (padrão) ou en-US" Enums(pt-BR, en-US)
+// @Success        200 {object} dto.VerificacaoResponse
+// @Failure        400 {object} problem.Problem
+// @Failure        403 {object} problem.Problem
+// @Router         /cliente/auditoria/verificar [get]
+// Execute - Verifica a cadeia completa do tenant ou, com clienteID, apenas a cadeia do cliente.
+// Uma cadeia quebrada não é erro do caso de uso: é retornada com integra=false e registrada no log.
+func (u *UseCase) Execute(ctx context.Context, cadeia string, clienteID string) (*dto.VerificacaoResponse, error) {
+	u.log.DebugContext(ctx, "Entrou auditverify.Execute")
+	ctx, span := tracing.Start(ctx, "auditverify.Execute")
+	defer span.End()
+
+	repo, ok := u.chains[cadeia]
+	if !ok {
+		u.log.ErrorContext(ctx, domainerr.ErrAuditoriaCadeiaInvalid.Error(), "mtd", "auditverify.Execute", "cadeia", cadeia)
+		return nil, domainerr.ErrAuditoriaCadeiaInvalid
+	}
+
+	report, err := repo.VerifyChain(ctx, clienteID)
+	if err != nil {
+		u.log.ErrorContext(ctx, err.Error(), "mtd", "repo.VerifyChain", "cadeia", cadeia)
+		return nil, err
+	}
+	if !report.OK() {
+		u.log.ErrorContext(ctx, "audit", "event", "audit_chain_broken", "cadeia", cadeia, "cliente_id", clienteID,
+			"seq", report.Broken.Seq, "registro", report.Broken.ID, "motivo", report.Broken.Reason)
+	}
+	return dto.NewVerificacaoResponse(cadeia, clienteID, report), nil
+}
@@ -0,0 +1,131 @@
+package auditverify_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/valdinei-santos/cpf-backend/internal/infra/hashchain"
+	"github.com/valdinei-santos/cpf-backend/internal/infra/logger"
+	"github.com/valdinei-santos/cpf-backend/internal/modules/cliente/domain/domainerr"
+	"github.com/valdinei-santos/cpf-backend/internal/modules/cliente/domain/entities"
+	"github.com/valdinei-santos/cpf-backend/internal/modules/cliente/infra/dto"
+	"github.com/valdinei-santos/cpf-backend/internal/modules/cliente/infra/repository"
+	"github.com/valdinei-santos/cpf-backend/internal/modules/cliente/usecases/auditverify"
+)
+
+func TestExecute(t *testing.T) {
+	mockRepoCliente := repository.NewMockClienteRepository()
+	cliente0 := mockRepoCliente.Clientes[0].ID.String()
+	cliente1 := mockRepoCliente.Clientes[1].ID.String()
+
+	// Histórico com dois registros do cliente 0 e um do cliente 1
+	mockRepoConsentimento := repository.NewMockConsentimentoRepository()
+	for _, id := range []string{cliente0, cliente1} {
+		c, _ := entities.NewConsentimento(id, "marketing", "consentimento", "web", "2025.1")
+		_ = mockRepoConsentimento.AddConsentimento(context.Background(), c)
+	}
+	atual, _ := mockRepoConsentimento.GetConsentimentoAtual(context.Background(), cliente0, "marketing")
+	revogacao, _ := atual.Revogar("app")
+	_ = mockRepoConsentimento.AddConsentimento(context.Background(), revogacao)
+
+	// Log de acesso com um registro alterado no banco
+	broken := &hashchain.Broken{Seq: 2, ID: "registro-2", Reason: "hash não confere: registro alterado"}
+	mockRepoAcesso := repository.NewMockAcessoRepository()
+	mockRepoAcesso.Broken = broken
+
+	tests := []struct {
+		name         string
+		repoAcesso   *repository.MockAcessoRepository
+		logger       *logger.MockILogger
+		cadeia       string
+		clienteID    string
+		expectedResp *dto.VerificacaoResponse
+		expectedErr  error
+		expectDebug  bool
+		expectError  bool
+	}{
+		{
+			name:         "Deve retornar a cadeia completa íntegra",
+			repoAcesso:   mockRepoAcesso,
+			logger:       logger.NewMockILogger(),
+			cadeia:       auditverify.CadeiaConsentimento,
+			expectedResp: &dto.VerificacaoResponse{Cadeia: "consentimento", Integra: true, Verificados: 3},
+			expectDebug:  true,
+			expectError:  false,
+		},
+		{
+			name:         "Deve verificar apenas a cadeia do cliente",
+			repoAcesso:   mockRepoAcesso,
+			logger:       logger.NewMockILogger(),
+			cadeia:       auditverify.CadeiaConsentimento,
+			clienteID:    cliente0,
+			expectedResp: &dto.VerificacaoResponse{Cadeia: "consentimento", ClienteID: cliente0, Integra: true, Verificados: 2},
+			expectDebug:  true,
+			expectError:  false,
+		},
+		{
+			name:       "Deve retornar o primeiro elo quebrado e registrar no log",
+			repoAcesso: mockRepoAcesso,
+			logger:     logger.NewMockILogger(),
+			cadeia:     auditverify.CadeiaAcesso,
+			expectedResp: &dto.VerificacaoResponse{
+				Cadeia:      "acesso",
+				Integra:     false,
+				EloQuebrado: &dto.EloQuebradoDTO{Seq: 2, Registro: "registro-2", Motivo: "hash não confere: registro alterado"},
+			},
+			expectDebug: true,
+			expectError: true,
+		},
+		{
+			name:        "Deve retornar erro quando a cadeia é inválida",
+			repoAcesso:  mockRepoAcesso,
+			logger:      logger.NewMockILogger(),
+			cadeia:      "cliente",
+			expectedErr: domainerr.ErrAuditoriaCadeiaInvalid,
+			expectDebug: true,
+			expectError: true,
+		},
+		{
+			name:        "Deve retornar erro quando o ID do cliente é inválido",
+			repoAcesso:  mockRepoAcesso,
+			logger:      logger.NewMockILogger(),
+			cadeia:      auditverify.CadeiaConsentimento,
+			clienteID:   "id-invalido",
+			expectedErr: domainerr.ErrClienteIDInvalid,
+			expectDebug: true,
+			expectError: true,
+		},
+		{
+			name: "Deve retornar erro quando o repositório falha",
+			repoAcesso: func() *repository.MockAcessoRepository {
+				r := repository.NewMockAcessoRepository()
+				r.SetMockError(errors.New("erro de conexão com o banco de dados"))
+				return r
+			}(),
+			logger:      logger.NewMockILogger(),
+			cadeia:      auditverify.CadeiaAcesso,
+			expectedErr: errors.New("erro de conexão com o banco de dados"),
+			expectDebug: true,
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc := auditverify.NewUseCase(mockRepoConsentimento, tt.repoAcesso, tt.logger)
+
+			resp, err := uc.Execute(context.Background(), tt.cadeia, tt.clienteID)
+
+			assert.Equal(t, tt.expectedResp, resp)
+			if tt.expectedErr != nil {
+				assert.EqualError(t, err, tt.expectedErr.Error())
+			} else {
+				assert.Nil(t, err)
+			}
+			assert.Equal(t, tt.expectDebug, tt.logger.DebugCalled)
+			assert.Equal(t, tt.expectError, tt.logger.ErrorCalled)
+		})
+	}
+}
@@ -0,0 +1,71 @@
+package consentgrant
+
+import (
+	"context"
+
+	"github.com/valdinei-santos/cpf-backend/internal/domain/globalerr"
+	"github.com/valdinei-santos/cpf-backend/internal/infra/logger"
+	"github.com/valdinei-santos/cpf-backend/internal/infra/tracing"
+	"github.com/valdinei-santos/cpf-backend/internal/modules/cliente/domain/entities"
+	"github.com/valdinei-santos/cpf-backend/internal/modules/cliente/infra/dto"
+	"github.com/valdinei-santos/cpf-backend/internal/modules/cliente/infra/repository"
+)
+
+// UseCase - Estrutura para o caso de uso de concessão de consentimento
+type UseCase struct {
+	repoCliente       repository.IClienteRepository
+	repoConsentimento repository.IConsentimentoRepository
+	log               logger.ILogger
+}
+
+// NewUseCase - Construtor do caso de uso
+func NewUseCase(rc repository.IClienteRepository, r repository.IConsentimentoRepository, l logger.ILogger) *UseCase {
+	return &UseCase{
+		repoCliente:       rc,
+		repoConsentimento: r,
+		log:               l,
+	}
+}
+
+// @Summary      Concede um consentimento
+// @Description  Registra a concessão de um consentimento (LGPD) do cliente para uma finalidade
+// @Tags         consentimentos
+// @Accept       json
+// @Produce      json
+// @Param        id path string true "Cliente ID"
+// @Param        consentimento body dto.ConsentimentoRequest true "Dados do consentimento"
+// @Param        Accept-Language header string false "Idioma das mensagens de erro: pt-BR (padrão) ou en-US" Enums(pt-BR, en-US)
+// @Success      201 {object} dto.ConsentimentoResponse
+// @Failure      400 {object} problem.Problem
+// @Failure      404 {object} problem.Problem
+// @Failure      422 {object} problem.Problem "Campos inválidos, todos listados em errors"
+// @Router       /cliente/{id}/consentimento [post]
+// Execute - Executa a lógica de concessão de um consentimento
+func (u *UseCase) Execute(ctx context.Context, clienteID string, in *dto.ConsentimentoRequest) (*dto.ConsentimentoResponse, error) {
+	u.log.DebugContext(ctx, "Entrou consentgrant.Execute")
+	ctx, span := tracing.Start(ctx, "consentgrant.Execute")
+	defer span.End()
+
+	// Garante que o cliente existe
+	_, err := u.repoCliente.GetClienteByID(ctx, clienteID)
+	if err != nil {
+		u.log.ErrorContext(ctx, err.Error(), "mtd", "u.repoCliente.GetClienteByID")
+		return nil, err
+	}
+
+	c, err := entities.NewConsentimento(clienteID, in.Finalidade, in.BaseLegal, in.Canal, in.VersaoPolitica)
+	if err != nil {
+		u.log.ErrorContext(ctx, err.Error(), "mtd", "entities.NewConsentimento")
+		return nil, err
+	}
+
+	err = u.repoConsentimento.AddConsentimento(ctx, c)
+	if err != nil {
+		u.log.ErrorContext(ctx, err.Error(), "mtd", "u.repoConsentimento.AddConsentimento")
+		return nil, globalerr.ErrInternal
+	}
+	u.log.DebugContext(ctx, "Consentimento concedido com sucesso", "id", c.ID, "finalidade", c.Finalidade)
+
+	resp := dto.NewConsentimentoResponse(c)
+	return &resp, nil
+}
@@ -0,0 +1,127 @@
+package consentgrant_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/valdinei-santos/cpf-backend/internal/domain/globalerr"
+	"github.com/valdinei-santos/cpf-backend/internal/infra/logger"
+	"github.com/valdinei-santos/cpf-backend/internal/modules/cliente/domain/domainerr"
+	"github.com/valdinei-santos/cpf-backend/internal/modules/cliente/infra/dto"
+	"github.com/valdinei-santos/cpf-backend/internal/modules/cliente/infra/repository"
+	"github.com/valdinei-santos/cpf-backend/internal/modules/cliente/usecases/consentgrant"
+)
+
+func TestExecute(t *testing.T) {
+	// Pega um ID válido do mock de repositório
+	mockRepoCliente := repository.NewMockClienteRepository()
+	validID := mockRepoCliente.Clientes[0].ID.String()
+
+	validInput := &dto.ConsentimentoRequest{
+		Finalidade:     "marketing",
+		BaseLegal:      "consentimento",
+		Canal:          "web",
+		VersaoPolitica: "2025.1",
+	}
+
+	tests := []struct {
+		name              string
+		repoConsentimento *repository.MockConsentimentoRepository
+		logger            *logger.MockILogger
+		clienteID         string
+		input             *dto.ConsentimentoRequest
+		expectedErr       error
+		expectDebug       bool
+		expectError       bool
+	}{
+		{
+			name:              "Deve retornar sucesso com dados válidos",
+			repoConsentimento: repository.NewMockConsentimentoRepository(),
+			logger:            logger.NewMockILogger(),
+			clienteID:         validID,
+			input:             validInput,
+			expectedErr:       nil,
+			expectDebug:       true,
+			expectError:       false,
+		},
+		{
+			name:              "Deve retornar erro quando o cliente não existe",
+			repoConsentimento: repository.NewMockConsentimentoRepository(),
+			logger:            logger.NewMockILogger(),
+			clienteID:         uuid.New().String(),
+			input:             validInput,
+			expectedErr:       domainerr.ErrClienteNotFound,
+			expectDebug:       true,
+			expectError:       true,
+		},
+		{
+			name:              "Deve retornar erro quando a base legal é inválida",
+			repoConsentimento: repository.NewMockConsentimentoRepository(),
+			logger:            logger.NewMockILogger(),
+			clienteID:         validID,
+			input: &dto.ConsentimentoRequest{
+				Finalidade:     "marketing",
+				BaseLegal:      "porque_sim",
+				Canal:          "web",
+				VersaoPolitica: "2025.1",
+			},
+			expectedErr: domainerr.ErrConsentimentoBaseLegalInvalid,
+			expectDebug: true,
+			expectError: true,
+		},
+		{
+			name:              "Deve retornar erro quando a finalidade é inválida",
+			repoConsentimento: repository.NewMockConsentimentoRepository(),
+			logger:            logger.NewMockILogger(),
+			clienteID:         validID,
+			input: &dto.ConsentimentoRequest{
+				Finalidade:     "Marketing Digital",
+				BaseLegal:      "consentimento",
+				Canal:          "web",
+				VersaoPolitica: "2025.1",
+			},
+			expectedErr: domainerr.ErrConsentimentoFinalidadeInvalid,
+			expectDebug: true,
+			expectError: true,
+		},
+		{
+			name: "Deve retornar erro quando repositório falha",
+			repoConsentimento: func() *repository.MockConsentimentoRepository {
+				r := repository.NewMockConsentimentoRepository()
+				r.SetMockError(globalerr.ErrSaveInDatabase)
+				return r
+			}(),
+			logger:      logger.NewMockILogger(),
+			clienteID:   validID,
+			input:       validInput,
+			expectedErr: globalerr.ErrInternal,
+			expectDebug: true,
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc := consentgrant.NewUseCase(mockRepoCliente, tt.repoConsentimento, tt.logger)
+
+			resp, err := uc.Execute(context.Background(), tt.clienteID, tt.input)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+			} else {
+				assert.Nil(t, err)
+				assert.NotEmpty(t, resp.ID)
+				assert.Equal(t, tt.clienteID, resp.ClienteID)
+				assert.Equal(t, tt.input.Finalidade, resp.Finalidade)
+				assert.Equal(t, "concedido", resp.Status)
+				assert.Nil(t, resp.RevogadoEm)
+				assert.Len(t, tt.repoConsentimento.Consentimentos, 1)
+			}
+
+			assert.Equal(t, tt.expectDebug, tt.logger.DebugCalled)
+			assert.Equal(t, tt.expectError, tt.logger.ErrorCalled)
+		})
+	}
+}
@@ -0,0 +1,16 @@
+package consenthistory
+
+import (
+	"context"
+
+	"github.com/valdinei-santos/cpf-backend/internal/infra/rbac"
+	"github.com/valdinei-santos/cpf-backend/internal/modules/cliente/infra/dto"
+)
+
+// Permission - permissão exigida na rota do caso de uso
+const Permission rbac.Permission = "consentimento:read"
+
+// IUsecase - ...
+type IUsecase interface {
+	Execute(ctx context.Context, clienteID string) (*dto.ConsentimentosResponse, error)
+}
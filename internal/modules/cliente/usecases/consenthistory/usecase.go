@@ -0,0 +1,55 @@
+package consenthistory
+
+import (
+	"context"
+
+	"github.com/valdinei-santos/cpf-backend/internal/infra/logger"
+	"github.com/valdinei-santos/cpf-backend/internal/infra/tracing"
+	"github.com/valdinei-santos/cpf-backend/internal/modules/cliente/infra/dto"
+	"github.com/valdinei-santos/cpf-backend/internal/modules/cliente/infra/repository"
+)
+
+// UseCase - Estrutura para o caso de uso de histórico de consentimentos
+type UseCase struct {
+	repo repository.IConsentimentoRepository
+	log  logger.ILogger
+}
+
+// NewUseCase - Construtor do caso de uso
+func NewUseCase(r repository.IConsentimentoRepository, l logger.ILogger) *UseCase {
+	return &UseCase{
+		repo: r,
+		log:  l,
+	}
+}
+
+// @Summary      Retorna o histórico de consentimentos
+// @Description  Retorna todas as concessões e revogações do cliente em ordem cronológica
+// @Tags         consentimentos
+// @Produce      json
+// @Param        id path string true "Cliente ID"
+// @Param        Accept-Language header string false "Idioma das mensagens de erro: pt-BR (padrão) ou en-US" Enums(pt-BR, en-US)
+// @Success      200 {object} dto.ConsentimentosResponse
+// @Failure      400 {object} problem.Problem
+// @Router       /cliente/{id}/consentimento/historico [get]
+// Execute - Executa a lógica de busca do histórico de consentimentos
+func (u *UseCase) Execute(ctx context.Context, clienteID string) (*dto.ConsentimentosResponse, error) {
+	u.log.DebugContext(ctx, "Entrou consenthistory.Execute")
+	ctx, span := tracing.Start(ctx, "consenthistory.Execute")
+	defer span.End()
+
+	historico, err := u.repo.GetHistoricoConsentimentos(ctx, clienteID)
+	if err != nil {
+		u.log.ErrorContext(ctx, err.Error(), "mtd", "u.repo.GetHistoricoConsentimentos")
+		return nil, err
+	}
+
+	result := &dto.ConsentimentosResponse{
+		ClienteID:      clienteID,
+		Consentimentos: make([]dto.ConsentimentoResponse, len(historico)),
+	}
+	for i, c := range historico {
+		result.Consentimentos[i] = dto.NewConsentimentoResponse(c)
+	}
+	return result, nil
+}
@@ -0,0 +1,91 @@
+package consenthistory_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/valdinei-santos/cpf-backend/internal/infra/logger"
+	"github.com/valdinei-santos/cpf-backend/internal/modules/cliente/domain/entities"
+	"github.com/valdinei-santos/cpf-backend/internal/modules/cliente/infra/repository"
+	"github.com/valdinei-santos/cpf-backend/internal/modules/cliente/usecases/consenthistory"
+)
+
+func TestExecute(t *testing.T) {
+	clienteID := repository.NewMockClienteRepository().Clientes[0].ID.String()
+
+	mockRepo := repository.NewMockConsentimentoRepository()
+	marketing, _ := entities.NewConsentimento(clienteID, "marketing", "consentimento", "web", "2025.1")
+	_ = mockRepo.AddConsentimento(context.Background(), marketing)
+	revogacao, _ := marketing.Revogar("telefone")
+	_ = mockRepo.AddConsentimento(context.Background(), revogacao)
+
+	tests := []struct {
+		name           string
+		repo           *repository.MockConsentimentoRepository
+		logger         *logger.MockILogger
+		clienteID      string
+		expectedStatus []string
+		expectedErr    error
+		expectDebug    bool
+		expectError    bool
+	}{
+		{
+			name:           "Deve retornar todos os registros em ordem cronológica",
+			repo:           mockRepo,
+			logger:         logger.NewMockILogger(),
+			clienteID:      clienteID,
+			expectedStatus: []string{"concedido", "revogado"},
+			expectedErr:    nil,
+			expectDebug:    true,
+			expectError:    false,
+		},
+		{
+			name:           "Deve retornar lista vazia quando o cliente não tem consentimentos",
+			repo:           mockRepo,
+			logger:         logger.NewMockILogger(),
+			clienteID:      uuid.New().String(),
+			expectedStatus: []string{},
+			expectedErr:    nil,
+			expectDebug:    true,
+			expectError:    false,
+		},
+		{
+			name: "Deve retornar erro quando o repositório falha",
+			repo: func() *repository.MockConsentimentoRepository {
+				r := repository.NewMockConsentimentoRepository()
+				r.SetMockError(errors.New("erro no banco de dados"))
+				return r
+			}(),
+			logger:      logger.NewMockILogger(),
+			clienteID:   clienteID,
+			expectedErr: errors.New("erro no banco de dados"),
+			expectDebug: true,
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc := consenthistory.NewUseCase(tt.repo, tt.logger)
+
+			resp, err := uc.Execute(context.Background(), tt.clienteID)
+
+			if tt.expectedErr != nil {
+				assert.EqualError(t, err, tt.expectedErr.Error())
+			} else {
+				assert.Nil(t, err)
+				status := []string{}
+				for _, c := range resp.Consentimentos {
+					status = append(status, c.Status)
+				}
+				assert.Equal(t, tt.expectedStatus, status)
+			}
+
+			assert.Equal(t, tt.expectDebug, tt.logger.DebugCalled)
+			assert.Equal(t, tt.expectError, tt.logger.ErrorCalled)
+		})
+	}
+}
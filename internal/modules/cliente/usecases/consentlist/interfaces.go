@@ -0,0 +1,17 @@
+package consentlist
+
+import (
+	"context"
+
+	"github.com/valdinei-santos/cpf-backend/internal/infra/masking"
+	"github.com/valdinei-santos/cpf-backend/internal/infra/rbac"
+	"github.com/valdinei-santos/cpf-backend/internal/modules/cliente/infra/dto"
+)
+
+// Permission - permissão exigida na rota do caso de uso
+const Permission rbac.Permission = "consentimento:read"
+
+// IUsecase - ...
+type IUsecase interface {
+	Execute(ctx context.Context, finalidade string, page int64, size int64, mode masking.Mode) (*dto.ClientesConsentimentoPaginated, error)
+}
@@ -0,0 +1,125 @@
+package consentlist
+
+import (
+	"context"
+	"math"
+
+	"github.com/valdinei-santos/cpf-backend/internal/domain/globalerr"
+	domainvo "github.com/valdinei-santos/cpf-backend/internal/domain/vo"
+	"github.com/valdinei-santos/cpf-backend/internal/infra/logger"
+	"github.com/valdinei-santos/cpf-backend/internal/infra/masking"
+	"github.com/valdinei-santos/cpf-backend/internal/infra/tracing"
+	"github.com/valdinei-santos/cpf-backend/internal/modules/cliente/domain/entities"
+	"github.com/valdinei-santos/cpf-backend/internal/modules/cliente/domain/vo"
+	"github.com/valdinei-santos/cpf-backend/internal/modules/cliente/infra/dto"
+	"github.com/valdinei-santos/cpf-backend/internal/modules/cliente/infra/repository"
+	"github.com/valdinei-santos/cpf-backend/internal/modules/cliente/usecases/accessrecord"
+)
+
+// UseCase - Estrutura para o caso de uso de listagem de clientes por consentimento
+type UseCase struct {
+	repoCliente       repository.IClienteRepository
+	repoConsentimento repository.IConsentimentoRepository
+	access            accessrecord.IUsecase
+	log               logger.ILogger
+}
+
+// NewUseCase - Construtor do caso de uso
+func NewUseCase(rc repository.IClienteRepository, r repository.IConsentimentoRepository, a accessrecord.IUsecase, l logger.ILogger) *UseCase {
+	return &UseCase{
+		repoCliente:       rc,
+		repoConsentimento: r,
+		access:            a,
+		log:               l,
+	}
+}
+
+// @Summary        Lista os clientes que consentiram com uma finalidade
+// @Description    Retorna, paginado, os clientes com consentimento vigente para a finalidade. Usado nas exportações de campanhas
+// @Tags           consentimentos
+// @Produce        json
+// @Param          finalidade path string true "Finalidade do consentimento"
+// @Param          page query int64 false "Numero da página a ser retornada"
+// @Param          size query int64 false "Quantidade de itens na página a ser retornada"
+// @Param          unmask query bool false "Retorna documento e telefone completos (exige permissão e é auditado)"
+// @Param          X-Access-Purpose header string false "Finalidade do acesso, registrada no log de acesso"
+// @Param        Accept-Language header string false "Idioma das mensagens de erro: pt-BR (padrão) ou en-US" Enums(pt-BR, en-US)
+// @Success        200 {object} dto.ClientesConsentimentoPaginated
+// @Failure        400 {object} problem.Problem
+// @Failure        422 {object} problem.Problem "Finalidade inválida"
+// @Router         /cliente/consentimento/{finalidade} [get]
+// Execute - Executa a lógica de listagem dos clientes por consentimento
+func (u *UseCase) Execute(ctx context.Context, finalidade string, page int64, size int64, mode masking.Mode) (*dto.ClientesConsentimentoPaginated, error) {
+	u.log.DebugContext(ctx, "Entrou consentlist.Execute")
+	ctx, span := tracing.Start(ctx, "consentlist.Execute")
+	defer span.End()
+
+	var v globalerr.Validation
+	finalidadeVO, err := vo.NewFinalidadeConsentimento(finalidade)
+	v.Add("finalidade", err)
+	if err := v.Err(); err != nil {
+		u.log.ErrorContext(ctx, err.Error(), "mtd", "vo.NewFinalidadeConsentimento")
+		return nil, err
+	}
+
+	// Calcula o offset para o repositório
+	offset := (page - 1) * size
+
+	consentimentos, totalItems, err := u.repoConsentimento.GetConsentimentosAtivosPorFinalidade(ctx, finalidadeVO.String(), offset, size)
+	if err != nil {
+		u.log.ErrorContext(ctx, err.Error(), "mtd", "u.repoConsentimento.GetConsentimentosAtivosPorFinalidade")
+		return nil, err
+	}
+
+	// Busca os clientes da página de uma só vez
+	ids := make([]string, len(consentimentos))
+	for i, c := range consentimentos {
+		ids[i] = c.ClienteID.String()
+	}
+	clientesPorID := map[domainvo.ID]*entities.Cliente{}
+	if len(ids) > 0 {
+		clientes, err := u.repoCliente.GetManyClienteByIDs(ctx, ids)
+		if err != nil {
+			u.log.ErrorContext(ctx, err.Error(), "mtd", "u.repoCliente.GetManyClienteByIDs")
+			return nil, err
+		}
+		for _, p := range clientes {
+			clientesPorID[p.ID] = p
+		}
+	}
+
+	// Clientes removidos depois do consentimento não entram na exportação
+	lista := make([]dto.ClienteConsentimento, 0, len(consentimentos))
+	exportados := make([]domainvo.ID, 0, len(consentimentos))
+	for _, c := range consentimentos {
+		p, ok := clientesPorID[c.ClienteID]
+		if !ok {
+			u.log.WarnContext(ctx, "Cliente do consentimento não encontrado", "cliente_id", c.ClienteID.String())
+			continue
+		}
+		lista = append(lista, dto.ClienteConsentimento{
+			Cliente:       dto.NewResponse(p, mode),
+			Consentimento: dto.NewConsentimentoResponse(c),
+		})
+		exportados = append(exportados, p.ID)
+	}
+
+	// Registra a leitura dos dados pessoais de cada cliente exportado (LGPD)
+	err = u.access.Execute(ctx, entities.AcessoExportacao, exportados, mode)
+	if err != nil {
+		u.log.ErrorContext(ctx, err.Error(), "mtd", "u.access.Execute")
+		return nil, err
+	}
+
+	// Calcula o total de páginas
+	totalPages := int(math.Ceil(float64(totalItems) / float64(size)))
+
+	return &dto.ClientesConsentimentoPaginated{
+		Finalidade:   finalidadeVO.String(),
+		Clientes:     lista,
+		TotalItems:   totalItems,
+		TotalPages:   int64(totalPages),
+		CurrentPage:  page,
+		ItemsPerPage: size,
+	}, nil
+}
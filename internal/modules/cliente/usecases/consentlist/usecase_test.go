@@ -0,0 +1,137 @@
+package consentlist_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/valdinei-santos/cpf-backend/internal/domain/globalerr"
+	"github.com/valdinei-santos/cpf-backend/internal/infra/logger"
+	"github.com/valdinei-santos/cpf-backend/internal/infra/masking"
+	"github.com/valdinei-santos/cpf-backend/internal/modules/cliente/domain/domainerr"
+	"github.com/valdinei-santos/cpf-backend/internal/modules/cliente/domain/entities"
+	"github.com/valdinei-santos/cpf-backend/internal/modules/cliente/infra/repository"
+	"github.com/valdinei-santos/cpf-backend/internal/modules/cliente/usecases/accessrecord"
+	"github.com/valdinei-santos/cpf-backend/internal/modules/cliente/usecases/consentlist"
+)
+
+func TestExecute(t *testing.T) {
+	mockRepoCliente := repository.NewMockClienteRepository()
+	cliente0 := mockRepoCliente.Clientes[0].ID.String()
+	cliente1 := mockRepoCliente.Clientes[1].ID.String()
+	cliente2 := mockRepoCliente.Clientes[2].ID.String()
+
+	// Clientes 0 e 2 consentiram marketing, cliente 1 consentiu e revogou
+	mockRepo := repository.NewMockConsentimentoRepository()
+	for _, id := range []string{cliente0, cliente1, cliente2} {
+		c, _ := entities.NewConsentimento(id, "marketing", "consentimento", "web", "2025.1")
+		_ = mockRepo.AddConsentimento(context.Background(), c)
+	}
+	atual, _ := mockRepo.GetConsentimentoAtual(context.Background(), cliente1, "marketing")
+	revogacao, _ := atual.Revogar("app")
+	_ = mockRepo.AddConsentimento(context.Background(), revogacao)
+
+	tests := []struct {
+		name          string
+		logger        *logger.MockILogger
+		finalidade    string
+		page          int64
+		size          int64
+		expectedIDs   []string
+		expectedTotal int64
+		expectedPages int64
+		expectedErr   error
+		invalidField  string
+		expectDebug   bool
+		expectError   bool
+	}{
+		{
+			name:          "Deve listar apenas os clientes com consentimento vigente",
+			logger:        logger.NewMockILogger(),
+			finalidade:    "marketing",
+			page:          1,
+			size:          10,
+			expectedIDs:   []string{cliente0, cliente2},
+			expectedTotal: 2,
+			expectedPages: 1,
+			expectDebug:   true,
+			expectError:   false,
+		},
+		{
+			name:          "Deve paginar o resultado",
+			logger:        logger.NewMockILogger(),
+			finalidade:    "marketing",
+			page:          2,
+			size:          1,
+			expectedIDs:   []string{cliente2},
+			expectedTotal: 2,
+			expectedPages: 2,
+			expectDebug:   true,
+			expectError:   false,
+		},
+		{
+			name:          "Deve retornar lista vazia para finalidade sem consentimentos",
+			logger:        logger.NewMockILogger(),
+			finalidade:    "pesquisa_satisfacao",
+			page:          1,
+			size:          10,
+			expectedIDs:   []string{},
+			expectedTotal: 0,
+			expectedPages: 0,
+			expectDebug:   true,
+			expectError:   false,
+		},
+		{
+			name:         "Deve retornar erro quando a finalidade é inválida",
+			logger:       logger.NewMockILogger(),
+			finalidade:   "x",
+			page:         1,
+			size:         10,
+			expectedErr:  domainerr.ErrConsentimentoFinalidadeInvalid,
+			invalidField: "finalidade",
+			expectDebug:  true,
+			expectError:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repoAcesso := repository.NewMockAcessoRepository()
+			uc := consentlist.NewUseCase(mockRepoCliente, mockRepo, accessrecord.NewUseCase(repoAcesso, logger.NewMockILogger()), tt.logger)
+
+			resp, err := uc.Execute(context.Background(), tt.finalidade, tt.page, tt.size, masking.Masked)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+			} else {
+				assert.Nil(t, err)
+				ids := []string{}
+				for _, c := range resp.Clientes {
+					ids = append(ids, c.Cliente.ID)
+					assert.Equal(t, "concedido", c.Consentimento.Status)
+					assert.Contains(t, c.Cliente.Documento, "*")
+				}
+				assert.Equal(t, tt.expectedIDs, ids)
+				// Cada cliente exportado gera um registro no log de acesso
+				acessados := []string{}
+				for _, a := range repoAcesso.Acessos {
+					acessados = append(acessados, a.ClienteID.String())
+					assert.Equal(t, entities.AcessoExportacao, a.Acao)
+				}
+				assert.Equal(t, tt.expectedIDs, acessados)
+				assert.Equal(t, tt.expectedTotal, resp.TotalItems)
+				assert.Equal(t, tt.expectedPages, resp.TotalPages)
+			}
+
+			if tt.invalidField != "" {
+				var validationErr *globalerr.ValidationError
+				if assert.ErrorAs(t, err, &validationErr) && assert.Len(t, validationErr.Fields, 1) {
+					assert.Equal(t, tt.invalidField, validationErr.Fields[0].Field)
+				}
+			}
+
+			assert.Equal(t, tt.expectDebug, tt.logger.DebugCalled)
+			assert.Equal(t, tt.expectError, tt.logger.ErrorCalled)
+		})
+	}
+}
@@ -0,0 +1,68 @@
+package consentrevoke
+
+import (
+	"context"
+
+	"github.com/valdinei-santos/cpf-backend/internal/domain/globalerr"
+	"github.com/valdinei-santos/cpf-backend/internal/infra/logger"
+	"github.com/valdinei-santos/cpf-backend/internal/infra/tracing"
+	"github.com/valdinei-santos/cpf-backend/internal/modules/cliente/infra/dto"
+	"github.com/valdinei-santos/cpf-backend/internal/modules/cliente/infra/repository"
+)
+
+// UseCase - Estrutura para o caso de uso de revogação de consentimento
+type UseCase struct {
+	repo repository.IConsentimentoRepository
+	log  logger.ILogger
+}
+
+// NewUseCase - Construtor do caso de uso
+func NewUseCase(r repository.IConsentimentoRepository, l logger.ILogger) *UseCase {
+	return &UseCase{
+		repo: r,
+		log:  l,
+	}
+}
+
+// @Summary      Revoga um consentimento
+// @Description  Registra a revogação do consentimento vigente do cliente para a finalidade
+// @Tags         consentimentos
+// @Accept       json
+// @Produce      json
+// @Param        id path string true "Cliente ID"
+// @Param        finalidade path string true "Finalidade do consentimento"
+// @Param        revogacao body dto.RevogacaoRequest true "Canal da revogação"
+// @Param        Accept-Language header string false "Idioma das mensagens de erro: pt-BR (padrão) ou en-US" Enums(pt-BR, en-US)
+// @Success      200 {object} dto.ConsentimentoResponse
+// @Failure      404 {object} problem.Problem
+// @Failure      409 {object} problem.Problem
+// @Failure      422 {object} problem.Problem "Canal inválido"
+// @Router       /cliente/{id}/consentimento/{finalidade}/revogar [post]
+// Execute - Executa a lógica de revogação de um consentimento
+func (u *UseCase) Execute(ctx context.Context, clienteID string, finalidade string, in *dto.RevogacaoRequest) (*dto.ConsentimentoResponse, error) {
+	u.log.DebugContext(ctx, "Entrou consentrevoke.Execute")
+	ctx, span := tracing.Start(ctx, "consentrevoke.Execute")
+	defer span.End()
+
+	atual, err := u.repo.GetConsentimentoAtual(ctx, clienteID, finalidade)
+	if err != nil {
+		u.log.ErrorContext(ctx, err.Error(), "mtd", "u.repo.GetConsentimentoAtual")
+		return nil, err
+	}
+
+	revogacao, err := atual.Revogar(in.Canal)
+	if err != nil {
+		u.log.ErrorContext(ctx, err.Error(), "mtd", "atual.Revogar")
+		return nil, err
+	}
+
+	err = u.repo.AddConsentimento(ctx, revogacao)
+	if err != nil {
+		u.log.ErrorContext(ctx, err.Error(), "mtd", "u.repo.AddConsentimento")
+		return nil, globalerr.ErrInternal
+	}
+	u.log.DebugContext(ctx, "Consentimento revogado com sucesso", "id", revogacao.ID, "finalidade", revogacao.Finalidade)
+
+	resp := dto.NewConsentimentoResponse(revogacao)
+	return &resp, nil
+}
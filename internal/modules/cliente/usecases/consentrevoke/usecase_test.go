@@ -0,0 +1,117 @@
+package consentrevoke_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/valdinei-santos/cpf-backend/internal/domain/globalerr"
+	"github.com/valdinei-santos/cpf-backend/internal/infra/logger"
+	"github.com/valdinei-santos/cpf-backend/internal/modules/cliente/domain/domainerr"
+	"github.com/valdinei-santos/cpf-backend/internal/modules/cliente/domain/entities"
+	"github.com/valdinei-santos/cpf-backend/internal/modules/cliente/infra/dto"
+	"github.com/valdinei-santos/cpf-backend/internal/modules/cliente/infra/repository"
+	"github.com/valdinei-santos/cpf-backend/internal/modules/cliente/usecases/consentrevoke"
+)
+
+func TestExecute(t *testing.T) {
+	clienteID := repository.NewMockClienteRepository().Clientes[0].ID.String()
+
+	// Repositório com um consentimento vigente de marketing
+	repoComConsentimento := func() *repository.MockConsentimentoRepository {
+		r := repository.NewMockConsentimentoRepository()
+		c, _ := entities.NewConsentimento(clienteID, "marketing", "consentimento", "web", "2025.1")
+		_ = r.AddConsentimento(context.Background(), c)
+		return r
+	}
+
+	tests := []struct {
+		name         string
+		repo         *repository.MockConsentimentoRepository
+		logger       *logger.MockILogger
+		finalidade   string
+		input        *dto.RevogacaoRequest
+		expectedErr  error
+		invalidField string
+		expectDebug  bool
+		expectError  bool
+	}{
+		{
+			name:        "Deve revogar o consentimento vigente",
+			repo:        repoComConsentimento(),
+			logger:      logger.NewMockILogger(),
+			finalidade:  "marketing",
+			input:       &dto.RevogacaoRequest{Canal: "app"},
+			expectedErr: nil,
+			expectDebug: true,
+			expectError: false,
+		},
+		{
+			name:        "Deve retornar erro quando não há consentimento para a finalidade",
+			repo:        repoComConsentimento(),
+			logger:      logger.NewMockILogger(),
+			finalidade:  "compartilhamento_parceiros",
+			input:       &dto.RevogacaoRequest{Canal: "app"},
+			expectedErr: domainerr.ErrConsentimentoNotFound,
+			expectDebug: true,
+			expectError: true,
+		},
+		{
+			name: "Deve retornar erro quando o consentimento já foi revogado",
+			repo: func() *repository.MockConsentimentoRepository {
+				r := repoComConsentimento()
+				atual, _ := r.GetConsentimentoAtual(context.Background(), clienteID, "marketing")
+				revogacao, _ := atual.Revogar("app")
+				_ = r.AddConsentimento(context.Background(), revogacao)
+				return r
+			}(),
+			logger:      logger.NewMockILogger(),
+			finalidade:  "marketing",
+			input:       &dto.RevogacaoRequest{Canal: "app"},
+			expectedErr: domainerr.ErrConsentimentoJaRevogado,
+			expectDebug: true,
+			expectError: true,
+		},
+		{
+			name:         "Deve retornar erro quando o canal é inválido",
+			repo:         repoComConsentimento(),
+			logger:       logger.NewMockILogger(),
+			finalidade:   "marketing",
+			input:        &dto.RevogacaoRequest{Canal: ""},
+			expectedErr:  domainerr.ErrConsentimentoCanalInvalid,
+			invalidField: "canal",
+			expectDebug:  true,
+			expectError:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc := consentrevoke.NewUseCase(tt.repo, tt.logger)
+
+			resp, err := uc.Execute(context.Background(), clienteID, tt.finalidade, tt.input)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+			} else {
+				assert.Nil(t, err)
+				assert.Equal(t, "revogado", resp.Status)
+				assert.NotNil(t, resp.RevogadoEm)
+				assert.Equal(t, tt.input.Canal, resp.Canal)
+				// O registro de concessão é mantido no histórico
+				assert.Len(t, tt.repo.Consentimentos, 2)
+				assert.Equal(t, "concedido", tt.repo.Consentimentos[0].Status.String())
+			}
+
+			if tt.invalidField != "" {
+				var validationErr *globalerr.ValidationError
+				if assert.ErrorAs(t, err, &validationErr) && assert.Len(t, validationErr.Fields, 1) {
+					assert.Equal(t, tt.invalidField, validationErr.Fields[0].Field)
+				}
+			}
+
+			assert.Equal(t, tt.expectDebug, tt.logger.DebugCalled)
+			assert.Equal(t, tt.expectError, tt.logger.ErrorCalled)
+		})
+	}
+}
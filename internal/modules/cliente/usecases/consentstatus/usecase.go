@@ -0,0 +1,55 @@
+package consentstatus
+
+import (
+	"context"
+
+	"github.com/valdinei-santos/cpf-backend/internal/infra/logger"
+	"github.com/valdinei-santos/cpf-backend/internal/infra/tracing"
+	"github.com/valdinei-santos/cpf-backend/internal/modules/cliente/infra/dto"
+	"github.com/valdinei-santos/cpf-backend/internal/modules/cliente/infra/repository"
+)
+
+// UseCase - Estrutura para o caso de uso de status atual dos consentimentos
+type UseCase struct {
+	repo repository.IConsentimentoRepository
+	log  logger.ILogger
+}
+
+// NewUseCase - Construtor do caso de uso
+func NewUseCase(r repository.IConsentimentoRepository, l logger.ILogger) *UseCase {
+	return &UseCase{
+		repo: r,
+		log:  l,
+	}
+}
+
+// @Summary      Retorna o status atual dos consentimentos
+// @Description  Retorna o registro vigente de cada finalidade consentida ou revogada pelo cliente
+// @Tags         consentimentos
+// @Produce      json
+// @Param        id path string true "Cliente ID"
+// @Param        Accept-Language header string false "Idioma das mensagens de erro: pt-BR (padrão) ou en-US" Enums(pt-BR, en-US)
+// @Success      200 {object} dto.ConsentimentosResponse
+// @Failure      400 {object} problem.Problem
+// @Router       /cliente/{id}/consentimento [get]
+// Execute - Executa a lógica de busca do status atual dos consentimentos
+func (u *UseCase) Execute(ctx context.Context, clienteID string) (*dto.ConsentimentosResponse, error) {
+	u.log.DebugContext(ctx, "Entrou consentstatus.Execute")
+	ctx, span := tracing.Start(ctx, "consentstatus.Execute")
+	defer span.End()
+
+	atuais, err := u.repo.GetConsentimentosAtuais(ctx, clienteID)
+	if err != nil {
+		u.log.ErrorContext(ctx, err.Error(), "mtd", "u.repo.GetConsentimentosAtuais")
+		return nil, err
+	}
+
+	result := &dto.ConsentimentosResponse{
+		ClienteID:      clienteID,
+		Consentimentos: make([]dto.ConsentimentoResponse, len(atuais)),
+	}
+	for i, c := range atuais {
+		result.Consentimentos[i] = dto.NewConsentimentoResponse(c)
+	}
+	return result, nil
+}
@@ -0,0 +1,96 @@
+package consentstatus_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/valdinei-santos/cpf-backend/internal/infra/logger"
+	"github.com/valdinei-santos/cpf-backend/internal/modules/cliente/domain/domainerr"
+	"github.com/valdinei-santos/cpf-backend/internal/modules/cliente/domain/entities"
+	"github.com/valdinei-santos/cpf-backend/internal/modules/cliente/infra/repository"
+	"github.com/valdinei-santos/cpf-backend/internal/modules/cliente/usecases/consentstatus"
+)
+
+func TestExecute(t *testing.T) {
+	clienteID := repository.NewMockClienteRepository().Clientes[0].ID.String()
+
+	// Marketing concedido e depois revogado, parceiros concedido
+	mockRepo := repository.NewMockConsentimentoRepository()
+	marketing, _ := entities.NewConsentimento(clienteID, "marketing", "consentimento", "web", "2025.1")
+	_ = mockRepo.AddConsentimento(context.Background(), marketing)
+	parceiros, _ := entities.NewConsentimento(clienteID, "compartilhamento_parceiros", "consentimento", "app", "2025.1")
+	_ = mockRepo.AddConsentimento(context.Background(), parceiros)
+	revogacao, _ := marketing.Revogar("telefone")
+	_ = mockRepo.AddConsentimento(context.Background(), revogacao)
+
+	tests := []struct {
+		name           string
+		repo           *repository.MockConsentimentoRepository
+		logger         *logger.MockILogger
+		clienteID      string
+		expectedStatus map[string]string
+		expectedErr    error
+		expectDebug    bool
+		expectError    bool
+	}{
+		{
+			name:      "Deve retornar o registro vigente de cada finalidade",
+			repo:      mockRepo,
+			logger:    logger.NewMockILogger(),
+			clienteID: clienteID,
+			expectedStatus: map[string]string{
+				"marketing":                  "revogado",
+				"compartilhamento_parceiros": "concedido",
+			},
+			expectedErr: nil,
+			expectDebug: true,
+			expectError: false,
+		},
+		{
+			name:        "Deve retornar erro quando o ID é inválido",
+			repo:        mockRepo,
+			logger:      logger.NewMockILogger(),
+			clienteID:   "invalid-uuid",
+			expectedErr: domainerr.ErrClienteIDInvalid,
+			expectDebug: true,
+			expectError: true,
+		},
+		{
+			name: "Deve retornar erro quando o repositório falha",
+			repo: func() *repository.MockConsentimentoRepository {
+				r := repository.NewMockConsentimentoRepository()
+				r.SetMockError(errors.New("erro no banco de dados"))
+				return r
+			}(),
+			logger:      logger.NewMockILogger(),
+			clienteID:   clienteID,
+			expectedErr: errors.New("erro no banco de dados"),
+			expectDebug: true,
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc := consentstatus.NewUseCase(tt.repo, tt.logger)
+
+			resp, err := uc.Execute(context.Background(), tt.clienteID)
+
+			if tt.expectedErr != nil {
+				assert.EqualError(t, err, tt.expectedErr.Error())
+			} else {
+				assert.Nil(t, err)
+				assert.Equal(t, tt.clienteID, resp.ClienteID)
+				assert.Len(t, resp.Consentimentos, len(tt.expectedStatus))
+				for _, c := range resp.Consentimentos {
+					assert.Equal(t, tt.expectedStatus[c.Finalidade], c.Status)
+				}
+			}
+
+			assert.Equal(t, tt.expectDebug, tt.logger.DebugCalled)
+			assert.Equal(t, tt.expectError, tt.logger.ErrorCalled)
+		})
+	}
+}
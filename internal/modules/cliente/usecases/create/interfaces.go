@@ -1,8 +1,20 @@
 package create
 
-import "github.com/valdinei-santos/cpf-backend/internal/modules/cliente/infra/dto"
+import (
+	"context"
+
+	"github.com/valdinei-santos/cpf-backend/internal/infra/masking"
+	"github.com/valdinei-santos/cpf-backend/internal/infra/rbac"
+	"github.com/valdinei-santos/cpf-backend/internal/modules/cliente/infra/dto"
+)
+
+// Permission - permissão exigida na rota do caso de uso
+const Permission rbac.Permission = "cliente:write"
+
+// PermissionBlock - permissão exigida para bloquear ou desbloquear o cliente
+const PermissionBlock rbac.Permission = "cliente:block"
 
 // IUsecase - ...
 type IUsecase interface {
-	Execute(p *dto.Request) (*dto.Response, error)
+	Execute(ctx context.Context, p *dto.Request, mode masking.Mode) (*dto.Response, error)
 }
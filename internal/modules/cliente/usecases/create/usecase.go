@@ -1,8 +1,15 @@
 package create
 
 import (
+	"context"
+	"errors"
+
 	"github.com/valdinei-santos/cpf-backend/internal/domain/globalerr"
 	"github.com/valdinei-santos/cpf-backend/internal/infra/logger"
+	"github.com/valdinei-santos/cpf-backend/internal/infra/masking"
+	"github.com/valdinei-santos/cpf-backend/internal/infra/rbac"
+	"github.com/valdinei-santos/cpf-backend/internal/infra/tracing"
+	"github.com/valdinei-santos/cpf-backend/internal/modules/cliente/domain/domainerr"
 	"github.com/valdinei-santos/cpf-backend/internal/modules/cliente/domain/entities"
 	"github.com/valdinei-santos/cpf-backend/internal/modules/cliente/infra/dto"
 	"github.com/valdinei-santos/cpf-backend/internal/modules/cliente/infra/repository"
@@ -10,15 +17,17 @@ import (
 
 // UseCase - Estrutura para o caso de uso de criação de cliente
 type UseCase struct {
-	repo repository.IClienteRepository
-	log  logger.ILogger
+	repo  repository.IClienteRepository
+	authz rbac.IAuthorizer
+	log   logger.ILogger
 }
 
 // NewUseCase - Construtor do caso de uso
-func NewUseCase(r repository.IClienteRepository, l logger.ILogger) *UseCase {
+func NewUseCase(r repository.IClienteRepository, a rbac.IAuthorizer, l logger.ILogger) *UseCase {
 	return &UseCase{
-		repo: r,
-		log:  l,
+		repo:  r,
+		authz: a,
+		log:   l,
 	}
 }
 
@@ -28,36 +37,59 @@ func NewUseCase(r repository.IClienteRepository, l logger.ILogger) *UseCase {
 // @Accept       json
 // @Produce      json
 // @Param        cliente body dto.Request true "Dados do cliente a ser criado"
+// @Param        unmask query bool false "Retorna documento e telefone completos (exige permissão e é auditado)"
+// @Param        Accept-Language header string false "Idioma das mensagens de erro: pt-BR (padrão) ou en-US" Enums(pt-BR, en-US)
 // @Success      201 {object} dto.Response
-// @Failure      400 {object} string "Erro na requisição"
-// @Router       / [post]
+// @Failure      400 {object} problem.Problem "Erro na requisição"
+// @Failure      403 {object} problem.Problem "Permissão insuficiente"
+// @Failure      409 {object} problem.Problem "Documento já cadastrado"
+// @Failure      422 {object} problem.Problem "Campos inválidos, todos listados em errors"
+// @Router       /cliente [post]
 // Execute - Executa a lógica de criação de um cliente
-func (u *UseCase) Execute(in *dto.Request) (*dto.Response, error) {
-	u.log.Debug("Entrou create.Execute")
+func (u *UseCase) Execute(ctx context.Context, in *dto.Request, mode masking.Mode) (*dto.Response, error) {
+	u.log.DebugContext(ctx, "Entrou create.Execute")
+	ctx, span := tracing.Start(ctx, "create.Execute")
+	defer span.End()
 
 	// Cria o objeto Cliente a partir do DTO de entrada
 	p, err := entities.NewCliente(in.Nome, in.Documento, in.Telefone, in.Bloqueado)
 	if err != nil {
-		u.log.Error(err.Error(), "mtd", "entities.NewCliente")
+		u.log.ErrorContext(ctx, err.Error(), "mtd", "entities.NewCliente")
+		return nil, err
+	}
+
+	// Criar o cliente já bloqueado exige a permissão de bloqueio
+	if p.Bloqueado.Bool() {
+		if err := u.authz.Check(ctx, PermissionBlock); err != nil {
+			u.log.ErrorContext(ctx, err.Error(), "mtd", "u.authz.Check")
+			return nil, err
+		}
+	}
+
+	// Verifica se já existe cliente com o mesmo documento (busca pelo blind index)
+	_, err = u.repo.GetClienteByDocumento(ctx, p.Documento.String())
+	if err == nil {
+		u.log.ErrorContext(ctx, domainerr.ErrDuplicatekey.Error(), "mtd", "u.repo.GetClienteByDocumento")
+		return nil, domainerr.ErrDuplicatekey
+	}
+	if !errors.Is(err, domainerr.ErrClienteNotFound) {
+		// Prazo esgotado e cancelamento seguem como vieram para a resposta 504/499
+		u.log.ErrorContext(ctx, err.Error(), "mtd", "u.repo.GetClienteByDocumento")
 		return nil, err
 	}
 
 	// Salva o cliente no repositório
-	err = u.repo.AddCliente(p)
+	err = u.repo.AddCliente(ctx, p)
 	if err != nil {
-		u.log.Error(err.Error(), "mtd", "u.repo.AddCliente")
-		return nil, globalerr.ErrInternal
+		u.log.ErrorContext(ctx, err.Error(), "mtd", "u.repo.AddCliente")
+		// O índice único recusa o documento gravado por outra requisição depois da verificação acima
+		if errors.Is(err, globalerr.ErrDuplicatekey) {
+			return nil, domainerr.ErrDuplicatekey
+		}
+		return nil, err
 	}
-	u.log.Debug("Cliente criado com sucesso", "id", p.ID)
+	u.log.DebugContext(ctx, "Cliente criado com sucesso", "id", p.ID)
 	// Retorna o DTO de saída
-	resp := &dto.Response{
-		ID:        p.ID.String(),
-		Nome:      p.Nome.String(),
-		Documento: p.Documento.String(),
-		Telefone:  p.Telefone.String(),
-		Bloqueado: p.Bloqueado.Bool(),
-		CreatedAt: p.CreatedAt.String(),
-		UpdatedAt: p.UpdatedAt.String(),
-	}
-	return resp, nil
+	resp := dto.NewResponse(p, mode)
+	return &resp, nil
 }
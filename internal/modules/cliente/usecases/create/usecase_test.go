@@ -1,11 +1,15 @@
 package create_test
 
 import (
+	"context"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/valdinei-santos/cpf-backend/internal/domain/globalerr"
+	"github.com/valdinei-santos/cpf-backend/internal/infra/identity"
 	"github.com/valdinei-santos/cpf-backend/internal/infra/logger"
+	"github.com/valdinei-santos/cpf-backend/internal/infra/masking"
+	"github.com/valdinei-santos/cpf-backend/internal/infra/rbac"
 	"github.com/valdinei-santos/cpf-backend/internal/modules/cliente/domain/domainerr"
 	"github.com/valdinei-santos/cpf-backend/internal/modules/cliente/infra/dto"
 	"github.com/valdinei-santos/cpf-backend/internal/modules/cliente/infra/repository"
@@ -19,14 +23,16 @@ func TestExecute(t *testing.T) {
 
 	// Tabela de teste para cenários
 	tests := []struct {
-		name         string
-		repo         *repository.MockClienteRepository
-		logger       *logger.MockILogger
-		input        *dto.Request
-		expectedResp *dto.Response
-		expectedErr  error
-		expectDebug  bool
-		expectError  bool
+		name          string
+		repo          *repository.MockClienteRepository
+		logger        *logger.MockILogger
+		roles         []string
+		input         *dto.Request
+		expectedResp  *dto.Response
+		expectedErr   error
+		invalidFields []string
+		expectDebug   bool
+		expectError   bool
 	}{
 		{
 			name:   "Deve retornar sucesso com dados válidos",
@@ -48,6 +54,22 @@ func TestExecute(t *testing.T) {
 			logger:      logger.NewMockILogger(),
 			input:       &dto.Request{Nome: ""}, // Nome vazio causa erro
 			expectedErr: domainerr.ErrClienteNomeInvalid,
+			// Todos os campos inválidos são retornados juntos
+			invalidFields: []string{"nome", "documento", "telefone"},
+			expectDebug:   true,
+			expectError:   true,
+		},
+		{
+			name:   "Deve retornar error quando o documento já está cadastrado",
+			repo:   repository.NewMockClienteRepository(),
+			logger: logger.NewMockILogger(),
+			input: &dto.Request{
+				Nome:      "Cliente Duplicado",
+				Documento: "12345678901", // Documento do primeiro cliente do mock
+				Telefone:  "11999999999",
+				Bloqueado: false,
+			},
+			expectedErr: domainerr.ErrDuplicatekey,
 			expectDebug: true,
 			expectError: true,
 		},
@@ -69,17 +91,67 @@ func TestExecute(t *testing.T) {
 			expectDebug: true,
 			expectError: true,
 		},
+		{
+			name: "Deve retornar o erro do repositório quando o prazo da busca esgota",
+			repo: func() *repository.MockClienteRepository {
+				r := repository.NewMockClienteRepository()
+				r.SetMockError(context.DeadlineExceeded)
+				return r
+			}(),
+			logger: logger.NewMockILogger(),
+			input: &dto.Request{
+				Nome:      "Cliente Teste 2",
+				Documento: "12345678900",
+				Telefone:  "11999999999",
+				Bloqueado: false,
+			},
+			expectedErr: context.DeadlineExceeded,
+			expectDebug: true,
+			expectError: true,
+		},
+		{
+			name:   "Deve retornar erro quando quem não é supervisor cria o cliente bloqueado",
+			repo:   repository.NewMockClienteRepository(),
+			logger: logger.NewMockILogger(),
+			roles:  []string{"operator"},
+			input: &dto.Request{
+				Nome:      "Cliente Bloqueado",
+				Documento: "55566677788",
+				Telefone:  "11999999999",
+				Bloqueado: true,
+			},
+			expectedErr: globalerr.ErrForbidden,
+			expectDebug: true,
+			expectError: true,
+		},
+		{
+			name:   "Deve criar o cliente bloqueado quando o chamador é supervisor",
+			repo:   repository.NewMockClienteRepository(),
+			logger: logger.NewMockILogger(),
+			roles:  []string{"supervisor"},
+			input: &dto.Request{
+				Nome:      "Cliente Bloqueado",
+				Documento: "55566677788",
+				Telefone:  "11999999999",
+				Bloqueado: true,
+			},
+			expectedErr: nil,
+			expectDebug: true,
+			expectError: false,
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			uc := create.NewUseCase(tt.repo, tt.logger)
+			authz := rbac.NewAuthorizer(rbac.DefaultPolicy(), true, logger.NewMockILogger())
+			uc := create.NewUseCase(tt.repo, authz, tt.logger)
+			ctx := identity.NewContext(context.Background(), &identity.Identity{Subject: "teste", Roles: tt.roles})
 
-			resp, err := uc.Execute(tt.input)
+			resp, err := uc.Execute(ctx, tt.input, masking.Unmasked)
 
 			//Verifique se não houve erro
 			if tt.expectedErr != nil {
-				assert.EqualError(t, err, tt.expectedErr.Error())
+				assert.ErrorIs(t, err, tt.expectedErr)
 			} else {
 				assert.Nil(t, err)
 				//Verifique somente os campos que não mudam
@@ -96,6 +168,17 @@ func TestExecute(t *testing.T) {
 				assert.NotEmpty(t, resp.UpdatedAt)
 			}
 
+			if tt.invalidFields != nil {
+				var validationErr *globalerr.ValidationError
+				if assert.ErrorAs(t, err, &validationErr) {
+					fields := make([]string, len(validationErr.Fields))
+					for i, f := range validationErr.Fields {
+						fields[i] = f.Field
+					}
+					assert.Equal(t, tt.invalidFields, fields)
+				}
+			}
+
 			assert.Equal(t, tt.expectDebug, tt.logger.DebugCalled)
 			assert.Equal(t, tt.expectError, tt.logger.ErrorCalled)
 		})
@@ -1,6 +1,15 @@
 package delete
 
+import (
+	"context"
+
+	"github.com/valdinei-santos/cpf-backend/internal/infra/rbac"
+)
+
+// Permission - permissão exigida na rota do caso de uso
+const Permission rbac.Permission = "cliente:delete"
+
 // IUsecase - ...
 type IUsecase interface {
-	Execute(id string) error
+	Execute(ctx context.Context, id string) error
 }
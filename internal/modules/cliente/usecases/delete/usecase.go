@@ -1,7 +1,10 @@
 package delete
 
 import (
+	"context"
+
 	"github.com/valdinei-santos/cpf-backend/internal/infra/logger"
+	"github.com/valdinei-santos/cpf-backend/internal/infra/tracing"
 	"github.com/valdinei-santos/cpf-backend/internal/modules/cliente/infra/repository"
 )
 
@@ -24,16 +27,19 @@ func NewUseCase(r repository.IClienteRepository, l logger.ILogger) *UseCase {
 // @Tags         clientes
 // @Produce      json
 // @Param        id path string true "ID do cliente a ser deletado"
-// @Success      200 {object} dto.OutputDefault "Cliente deletado com sucesso"}
-// @Failure      404 {string} string "cliente não encontrado"
-// @Router       /{id} [delete]
+// @Param        Accept-Language header string false "Idioma das mensagens de erro: pt-BR (padrão) ou en-US" Enums(pt-BR, en-US)
+// @Success      204 "Cliente deletado com sucesso"
+// @Failure      404 {object} problem.Problem "cliente não encontrado"
+// @Router       /cliente/{id} [delete]
 // Execute - Executa a lógica para deletar um cliente
-func (u *UseCase) Execute(id string) error {
-	u.log.Debug("Entrou delete.Execute")
+func (u *UseCase) Execute(ctx context.Context, id string) error {
+	u.log.DebugContext(ctx, "Entrou delete.Execute")
+	ctx, span := tracing.Start(ctx, "delete.Execute")
+	defer span.End()
 
-	err := u.repo.DeleteCliente(id)
+	err := u.repo.DeleteCliente(ctx, id)
 	if err != nil {
-		u.log.Error(err.Error(), "mtd", "u.repo.Delete")
+		u.log.ErrorContext(ctx, err.Error(), "mtd", "u.repo.Delete")
 		return err
 	}
 
@@ -1,6 +1,7 @@
 package delete_test
 
 import (
+	"context"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -56,7 +57,7 @@ func TestExecute(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			uc := delete.NewUseCase(tt.repo, tt.logger)
 
-			err := uc.Execute(tt.inputID)
+			err := uc.Execute(context.Background(), tt.inputID)
 
 			if tt.expectedErr != nil {
 				assert.EqualError(t, err, tt.expectedErr.Error())
@@ -1,8 +1,17 @@
 package get
 
-import "github.com/valdinei-santos/cpf-backend/internal/modules/cliente/infra/dto"
+import (
+	"context"
+
+	"github.com/valdinei-santos/cpf-backend/internal/infra/masking"
+	"github.com/valdinei-santos/cpf-backend/internal/infra/rbac"
+	"github.com/valdinei-santos/cpf-backend/internal/modules/cliente/infra/dto"
+)
+
+// Permission - permissão exigida na rota do caso de uso
+const Permission rbac.Permission = "cliente:read"
 
 // IUsecase - ...
 type IUsecase interface {
-	Execute(id string) (*dto.Response, error)
+	Execute(ctx context.Context, id string, mode masking.Mode) (*dto.Response, error)
 }
@@ -1,22 +1,31 @@
 package get
 
 import (
+	"context"
+
+	"github.com/valdinei-santos/cpf-backend/internal/domain/vo"
 	"github.com/valdinei-santos/cpf-backend/internal/infra/logger"
+	"github.com/valdinei-santos/cpf-backend/internal/infra/masking"
+	"github.com/valdinei-santos/cpf-backend/internal/infra/tracing"
+	"github.com/valdinei-santos/cpf-backend/internal/modules/cliente/domain/entities"
 	"github.com/valdinei-santos/cpf-backend/internal/modules/cliente/infra/dto"
 	"github.com/valdinei-santos/cpf-backend/internal/modules/cliente/infra/repository"
+	"github.com/valdinei-santos/cpf-backend/internal/modules/cliente/usecases/accessrecord"
 )
 
 // UseCase - Struct do  caso de uso
 type UseCase struct {
-	repo repository.IClienteRepository
-	log  logger.ILogger
+	repo   repository.IClienteRepository
+	access accessrecord.IUsecase
+	log    logger.ILogger
 }
 
 // NewUseCase - Construtor do caso de uso
-func NewUseCase(r repository.IClienteRepository, l logger.ILogger) *UseCase {
+func NewUseCase(r repository.IClienteRepository, a accessrecord.IUsecase, l logger.ILogger) *UseCase {
 	return &UseCase{
-		repo: r,
-		log:  l,
+		repo:   r,
+		access: a,
+		log:    l,
 	}
 }
 
@@ -26,29 +35,34 @@ func NewUseCase(r repository.IClienteRepository, l logger.ILogger) *UseCase {
 // @Accept       json
 // @Produce      json
 // @Param        id path string true "Cliente ID"
+// @Param        unmask query bool false "Retorna documento e telefone completos (exige permissão e é auditado)"
+// @Param        X-Access-Purpose header string false "Finalidade do acesso, registrada no log de acesso"
+// @Param        Accept-Language header string false "Idioma das mensagens de erro: pt-BR (padrão) ou en-US" Enums(pt-BR, en-US)
 // @Success      200 {object} dto.Response
-// @Failure      400 {object} dto.OutputDefault
-// @Router       /{id} [get]
+// @Failure      400 {object} problem.Problem
+// @Failure      403 {object} problem.Problem
+// @Router       /cliente/{id} [get]
 // Execute - Executa a lógica de busca de um cliente
-func (u *UseCase) Execute(id string) (*dto.Response, error) {
-	u.log.Debug("Entrou get.Execute")
+func (u *UseCase) Execute(ctx context.Context, id string, mode masking.Mode) (*dto.Response, error) {
+	u.log.DebugContext(ctx, "Entrou get.Execute")
+	ctx, span := tracing.Start(ctx, "get.Execute")
+	defer span.End()
 
 	// Pega o cliente no repositório pelo ID
-	p, err := u.repo.GetClienteByID(id)
+	p, err := u.repo.GetClienteByID(ctx, id)
 	if err != nil {
-		u.log.Error(err.Error(), "mtd", "u.repo.GetClienteByID")
+		u.log.ErrorContext(ctx, err.Error(), "mtd", "u.repo.GetClienteByID")
 		return nil, err
 	}
 
-	// Transforma a entidade Cliente no DTO Response
-	result := &dto.Response{
-		ID:        p.ID.String(),
-		Nome:      p.Nome.String(),
-		Documento: p.Documento.String(),
-		Telefone:  p.Telefone.String(),
-		Bloqueado: p.Bloqueado.Bool(),
-		CreatedAt: p.CreatedAt.String(),
-		UpdatedAt: p.UpdatedAt.String(),
+	// Registra a leitura dos dados pessoais (LGPD)
+	err = u.access.Execute(ctx, entities.AcessoConsulta, []vo.ID{p.ID}, mode)
+	if err != nil {
+		u.log.ErrorContext(ctx, err.Error(), "mtd", "u.access.Execute")
+		return nil, err
 	}
-	return result, nil
+
+	// Transforma a entidade Cliente no DTO Response
+	result := dto.NewResponse(p, mode)
+	return &result, nil
 }
@@ -1,15 +1,21 @@
 package get_test
 
 import (
+	"context"
 	"errors"
 	"testing"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
+	"github.com/valdinei-santos/cpf-backend/internal/domain/globalerr"
+	"github.com/valdinei-santos/cpf-backend/internal/infra/identity"
 	"github.com/valdinei-santos/cpf-backend/internal/infra/logger"
+	"github.com/valdinei-santos/cpf-backend/internal/infra/masking"
 	"github.com/valdinei-santos/cpf-backend/internal/modules/cliente/domain/domainerr"
+	"github.com/valdinei-santos/cpf-backend/internal/modules/cliente/domain/entities"
 	"github.com/valdinei-santos/cpf-backend/internal/modules/cliente/infra/dto"
 	"github.com/valdinei-santos/cpf-backend/internal/modules/cliente/infra/repository"
+	"github.com/valdinei-santos/cpf-backend/internal/modules/cliente/usecases/accessrecord"
 	"github.com/valdinei-santos/cpf-backend/internal/modules/cliente/usecases/get"
 )
 
@@ -23,8 +29,11 @@ func TestExecute(t *testing.T) {
 		repo         *repository.MockClienteRepository
 		logger       *logger.MockILogger
 		inputID      string
+		mode         masking.Mode
+		acessoErr    error // Erro do repositório do log de acesso
 		expectedResp *dto.Response
 		expectedErr  error
+		expectAcesso bool
 		expectDebug  bool
 		expectError  bool
 	}{
@@ -33,6 +42,7 @@ func TestExecute(t *testing.T) {
 			repo:    mockRepoWithCliente, // Usa o mock que tem o cliente
 			logger:  logger.NewMockILogger(),
 			inputID: validID,
+			mode:    masking.Unmasked,
 			expectedResp: &dto.Response{
 				ID:        mockRepoWithCliente.Clientes[0].ID.String(),
 				Nome:      mockRepoWithCliente.Clientes[0].Nome.String(),
@@ -42,9 +52,30 @@ func TestExecute(t *testing.T) {
 				CreatedAt: mockRepoWithCliente.Clientes[0].CreatedAt.String(),
 				UpdatedAt: mockRepoWithCliente.Clientes[0].UpdatedAt.String(),
 			},
-			expectedErr: nil,
-			expectDebug: true,
-			expectError: false,
+			expectedErr:  nil,
+			expectAcesso: true,
+			expectDebug:  true,
+			expectError:  false,
+		},
+		{
+			name:    "Deve retornar documento e telefone mascarados por padrão",
+			repo:    mockRepoWithCliente,
+			logger:  logger.NewMockILogger(),
+			inputID: validID,
+			mode:    masking.Masked,
+			expectedResp: &dto.Response{
+				ID:        mockRepoWithCliente.Clientes[0].ID.String(),
+				Nome:      mockRepoWithCliente.Clientes[0].Nome.String(),
+				Documento: "***.456.789-**", // 12345678901
+				Telefone:  "*******9999",    // 11999999999
+				Bloqueado: mockRepoWithCliente.Clientes[0].Bloqueado.Bool(),
+				CreatedAt: mockRepoWithCliente.Clientes[0].CreatedAt.String(),
+				UpdatedAt: mockRepoWithCliente.Clientes[0].UpdatedAt.String(),
+			},
+			expectedErr:  nil,
+			expectAcesso: true,
+			expectDebug:  true,
+			expectError:  false,
 		},
 		{
 			name:         "Deve retornar erro quando o ID não é encontrado",
@@ -66,6 +97,17 @@ func TestExecute(t *testing.T) {
 			expectDebug:  true,
 			expectError:  true,
 		},
+		{
+			name:         "Deve retornar erro e não retornar o cliente quando o registro de acesso falha",
+			repo:         mockRepoWithCliente,
+			logger:       logger.NewMockILogger(),
+			inputID:      validID,
+			acessoErr:    errors.New("erro de conexão com o banco de dados"),
+			expectedResp: nil,
+			expectedErr:  globalerr.ErrInternal,
+			expectDebug:  true,
+			expectError:  true,
+		},
 		{
 			name: "Deve retornar erro quando o repositório falha",
 			repo: func() *repository.MockClienteRepository {
@@ -84,9 +126,11 @@ func TestExecute(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			uc := get.NewUseCase(tt.repo, tt.logger)
+			repoAcesso := repository.NewMockAcessoRepository()
+			repoAcesso.SetMockError(tt.acessoErr)
+			uc := get.NewUseCase(tt.repo, accessrecord.NewUseCase(repoAcesso, logger.NewMockILogger()), tt.logger)
 
-			resp, err := uc.Execute(tt.inputID)
+			resp, err := uc.Execute(identity.NewContext(context.Background(), &identity.Identity{Subject: "atendente-1"}), tt.inputID, tt.mode)
 
 			assert.Equal(t, tt.expectedResp, resp)
 			if tt.expectedErr != nil {
@@ -94,6 +138,15 @@ func TestExecute(t *testing.T) {
 			} else {
 				assert.Nil(t, err)
 			}
+			if tt.expectAcesso {
+				assert.Len(t, repoAcesso.Acessos, 1)
+				assert.Equal(t, tt.inputID, repoAcesso.Acessos[0].ClienteID.String())
+				assert.Equal(t, entities.AcessoConsulta, repoAcesso.Acessos[0].Acao)
+				assert.Equal(t, "atendente-1", repoAcesso.Acessos[0].Operador)
+				assert.Equal(t, tt.mode == masking.Unmasked, repoAcesso.Acessos[0].Completo)
+			} else {
+				assert.Empty(t, repoAcesso.Acessos)
+			}
 			assert.Equal(t, tt.expectDebug, tt.logger.DebugCalled)
 			assert.Equal(t, tt.expectError, tt.logger.ErrorCalled)
 		})
@@ -1,8 +1,17 @@
 package getall
 
-import "github.com/valdinei-santos/cpf-backend/internal/modules/cliente/infra/dto"
+import (
+	"context"
+
+	"github.com/valdinei-santos/cpf-backend/internal/infra/masking"
+	"github.com/valdinei-santos/cpf-backend/internal/infra/rbac"
+	"github.com/valdinei-santos/cpf-backend/internal/modules/cliente/infra/dto"
+)
+
+// Permission - permissão exigida na rota do caso de uso
+const Permission rbac.Permission = "cliente:read"
 
 // IUsecase - ...
 type IUsecase interface {
-	Execute(page int64, size int64) (*dto.ResponseManyPaginated, error)
+	Execute(ctx context.Context, page int64, size int64, mode masking.Mode) (*dto.ResponseManyPaginated, error)
 }
@@ -1,24 +1,33 @@
 package getall
 
 import (
+	"context"
+
 	"math"
 
+	"github.com/valdinei-santos/cpf-backend/internal/domain/vo"
 	"github.com/valdinei-santos/cpf-backend/internal/infra/logger"
+	"github.com/valdinei-santos/cpf-backend/internal/infra/masking"
+	"github.com/valdinei-santos/cpf-backend/internal/infra/tracing"
+	"github.com/valdinei-santos/cpf-backend/internal/modules/cliente/domain/entities"
 	"github.com/valdinei-santos/cpf-backend/internal/modules/cliente/infra/dto"
 	"github.com/valdinei-santos/cpf-backend/internal/modules/cliente/infra/repository"
+	"github.com/valdinei-santos/cpf-backend/internal/modules/cliente/usecases/accessrecord"
 )
 
 // UseCase - Estrutura para o caso de uso de criação de cliente
 type UseCase struct {
-	repo repository.IClienteRepository
-	log  logger.ILogger
+	repo   repository.IClienteRepository
+	access accessrecord.IUsecase
+	log    logger.ILogger
 }
 
 // NewUseCase - Construtor do caso de uso
-func NewUseCase(r repository.IClienteRepository, l logger.ILogger) *UseCase {
+func NewUseCase(r repository.IClienteRepository, a accessrecord.IUsecase, l logger.ILogger) *UseCase {
 	return &UseCase{
-		repo: r,
-		log:  l,
+		repo:   r,
+		access: a,
+		log:    l,
 	}
 }
 
@@ -28,35 +37,41 @@ func NewUseCase(r repository.IClienteRepository, l logger.ILogger) *UseCase {
 // @Produce        json
 // @Param          page query int64 false "Numero da página a ser retornada"
 // @Param          size query int64 false "Quantidade de itens na página a ser retornada"
+// @Param          unmask query bool false "Retorna documento e telefone completos (exige permissão e é auditado)"
+// @Param          X-Access-Purpose header string false "Finalidade do acesso, registrada no log de acesso"
+// @Param        Accept-Language header string false "Idioma das mensagens de erro: pt-BR (padrão) ou en-US" Enums(pt-BR, en-US)
 // @Success        200 {array} dto.ResponseManyPaginated
-// @Failure        500 {string} string "Erro interno do servidor"
-// @Router         / [get]
+// @Failure        500 {object} problem.Problem "Erro interno do servidor"
+// @Router         /cliente [get]
 // Execute - Executa a lógica para buscar todos os clientes
-func (u *UseCase) Execute(page int64, size int64) (*dto.ResponseManyPaginated, error) {
-	u.log.Debug("Entrou getall.Execute")
+func (u *UseCase) Execute(ctx context.Context, page int64, size int64, mode masking.Mode) (*dto.ResponseManyPaginated, error) {
+	u.log.DebugContext(ctx, "Entrou getall.Execute")
+	ctx, span := tracing.Start(ctx, "getall.Execute")
+	defer span.End()
 
 	// Calcula o offset para o repositório
 	offset := (page - 1) * size
 
 	// Busca o subconjunto de clientes e o total de itens
-	paginatedClientes, totalItems, err := u.repo.GetAllClientes(offset, size)
+	paginatedClientes, totalItems, err := u.repo.GetAllClientes(ctx, offset, size)
 	if err != nil {
-		u.log.Error("Erro ao buscar clientes: ", err)
+		u.log.ErrorContext(ctx, "Erro ao buscar clientes: ", err)
 		return nil, err
 	}
 
 	// Converte as entidades para DTOs
 	clienteList := make([]dto.Response, len(paginatedClientes))
+	ids := make([]vo.ID, len(paginatedClientes))
 	for i, p := range paginatedClientes {
-		clienteList[i] = dto.Response{
-			ID:        p.ID.String(),
-			Nome:      p.Nome.String(),
-			Documento: p.Documento.String(),
-			Telefone:  p.Telefone.String(),
-			Bloqueado: p.Bloqueado.Bool(),
-			CreatedAt: p.CreatedAt.String(),
-			UpdatedAt: p.UpdatedAt.String(),
-		}
+		clienteList[i] = dto.NewResponse(p, mode)
+		ids[i] = p.ID
+	}
+
+	// Registra a leitura dos dados pessoais de cada cliente da página (LGPD)
+	err = u.access.Execute(ctx, entities.AcessoListagem, ids, mode)
+	if err != nil {
+		u.log.ErrorContext(ctx, err.Error(), "mtd", "u.access.Execute")
+		return nil, err
 	}
 
 	// Calcula o total de páginas
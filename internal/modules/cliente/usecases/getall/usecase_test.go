@@ -1,13 +1,17 @@
 package getall_test
 
 import (
+	"context"
 	"errors"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/valdinei-santos/cpf-backend/internal/domain/globalerr"
 	"github.com/valdinei-santos/cpf-backend/internal/infra/logger"
+	"github.com/valdinei-santos/cpf-backend/internal/infra/masking"
 	"github.com/valdinei-santos/cpf-backend/internal/modules/cliente/infra/dto"
 	"github.com/valdinei-santos/cpf-backend/internal/modules/cliente/infra/repository"
+	"github.com/valdinei-santos/cpf-backend/internal/modules/cliente/usecases/accessrecord"
 	"github.com/valdinei-santos/cpf-backend/internal/modules/cliente/usecases/getall"
 )
 
@@ -21,8 +25,10 @@ func TestExecute(t *testing.T) {
 		logger       *logger.MockILogger
 		page         int
 		size         int
+		acessoErr    error // Erro do repositório do log de acesso
 		expectedResp *dto.ResponseManyPaginated
 		expectedErr  error
+		expectAcesso int // Quantidade de acessos registrados
 		expectDebug  bool
 		expectError  bool
 	}{
@@ -58,9 +64,10 @@ func TestExecute(t *testing.T) {
 				CurrentPage:  1,
 				ItemsPerPage: 2,
 			},
-			expectedErr: nil,
-			expectDebug: true,
-			expectError: false,
+			expectedErr:  nil,
+			expectAcesso: 2,
+			expectDebug:  true,
+			expectError:  false,
 		},
 		{
 			name:   "Deve retornar a segunda página de clientes com sucesso",
@@ -85,9 +92,10 @@ func TestExecute(t *testing.T) {
 				CurrentPage:  2,
 				ItemsPerPage: 2,
 			},
-			expectedErr: nil,
-			expectDebug: true,
-			expectError: false,
+			expectedErr:  nil,
+			expectAcesso: 1,
+			expectDebug:  true,
+			expectError:  false,
 		},
 		{
 			name:   "Deve retornar página vazia para página que não existe",
@@ -102,9 +110,10 @@ func TestExecute(t *testing.T) {
 				CurrentPage:  10,
 				ItemsPerPage: 2,
 			},
-			expectedErr: nil,
-			expectDebug: true,
-			expectError: false,
+			expectedErr:  nil,
+			expectAcesso: 0,
+			expectDebug:  true,
+			expectError:  false,
 		},
 		{
 			name:   "Deve retornar todos os clientes quando o limite é maior que o total",
@@ -147,9 +156,22 @@ func TestExecute(t *testing.T) {
 				CurrentPage:  1,
 				ItemsPerPage: 10,
 			},
-			expectedErr: nil,
-			expectDebug: true,
-			expectError: false,
+			expectedErr:  nil,
+			expectAcesso: 3,
+			expectDebug:  true,
+			expectError:  false,
+		},
+		{
+			name:         "Deve retornar erro e não retornar a página quando o registro de acesso falha",
+			repo:         mockRepo,
+			logger:       logger.NewMockILogger(),
+			page:         1,
+			size:         2,
+			acessoErr:    errors.New("erro de conexão com o banco de dados"),
+			expectedResp: nil,
+			expectedErr:  globalerr.ErrInternal,
+			expectDebug:  true,
+			expectError:  true,
 		},
 		{
 			name: "Deve retornar erro quando o repositório falha",
@@ -170,12 +192,15 @@ func TestExecute(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			uc := getall.NewUseCase(tt.repo, tt.logger)
+			repoAcesso := repository.NewMockAcessoRepository()
+			repoAcesso.SetMockError(tt.acessoErr)
+			uc := getall.NewUseCase(tt.repo, accessrecord.NewUseCase(repoAcesso, logger.NewMockILogger()), tt.logger)
 
-			resp, err := uc.Execute(int64(tt.page), int64(tt.size))
+			resp, err := uc.Execute(context.Background(), int64(tt.page), int64(tt.size), masking.Unmasked)
 
 			assert.Equal(t, tt.expectedResp, resp)
 			assert.Equal(t, tt.expectedErr, err)
+			assert.Len(t, repoAcesso.Acessos, tt.expectAcesso)
 			assert.Equal(t, tt.expectDebug, tt.logger.DebugCalled)
 			assert.Equal(t, tt.expectError, tt.logger.ErrorCalled)
 		})
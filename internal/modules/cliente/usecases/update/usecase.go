@@ -1,7 +1,14 @@
 package update
 
 import (
+	"context"
+	"errors"
+
+	"github.com/valdinei-santos/cpf-backend/internal/domain/globalerr"
 	"github.com/valdinei-santos/cpf-backend/internal/infra/logger"
+	"github.com/valdinei-santos/cpf-backend/internal/infra/masking"
+	"github.com/valdinei-santos/cpf-backend/internal/infra/rbac"
+	"github.com/valdinei-santos/cpf-backend/internal/infra/tracing"
 	"github.com/valdinei-santos/cpf-backend/internal/modules/cliente/domain/domainerr"
 	"github.com/valdinei-santos/cpf-backend/internal/modules/cliente/domain/entities"
 	"github.com/valdinei-santos/cpf-backend/internal/modules/cliente/infra/dto"
@@ -10,15 +17,17 @@ import (
 
 // UseCase - Estrutura para o caso de uso de criação de cliente
 type UseCase struct {
-	repo repository.IClienteRepository
-	log  logger.ILogger
+	repo  repository.IClienteRepository
+	authz rbac.IAuthorizer
+	log   logger.ILogger
 }
 
 // NewUseCase - Construtor do caso de uso
-func NewUseCase(r repository.IClienteRepository, l logger.ILogger) *UseCase {
+func NewUseCase(r repository.IClienteRepository, a rbac.IAuthorizer, l logger.ILogger) *UseCase {
 	return &UseCase{
-		repo: r,
-		log:  l,
+		repo:  r,
+		authz: a,
+		log:   l,
 	}
 }
 
@@ -29,43 +38,66 @@ func NewUseCase(r repository.IClienteRepository, l logger.ILogger) *UseCase {
 // @Produce      json
 // @Param        id path string true "Cliente ID"
 // @Param        cliente body dto.Request  true  "Dados do cliente para atualização"
+// @Param        unmask query bool false "Retorna documento e telefone completos (exige permissão e é auditado)"
+// @Param        Accept-Language header string false "Idioma das mensagens de erro: pt-BR (padrão) ou en-US" Enums(pt-BR, en-US)
 // @Success      200 {object} dto.Response
-// @Failure      400 {object} dto.OutputDefault
-// @Router       /{id} [put]
+// @Failure      400 {object} problem.Problem
+// @Failure      403 {object} problem.Problem "Permissão insuficiente para bloquear ou desbloquear"
+// @Failure      409 {object} problem.Problem "Documento já cadastrado para outro cliente"
+// @Failure      422 {object} problem.Problem "Campos inválidos, todos listados em errors"
+// @Router       /cliente/{id} [put]
 // Execute - Executa a lógica de criação de um cliente
-func (u *UseCase) Execute(id string, in *dto.Request) (*dto.Response, error) {
-	u.log.Debug("Entrou update.Execute")
+func (u *UseCase) Execute(ctx context.Context, id string, in *dto.Request, mode masking.Mode) (*dto.Response, error) {
+	u.log.DebugContext(ctx, "Entrou update.Execute")
+	ctx, span := tracing.Start(ctx, "update.Execute")
+	defer span.End()
 
-	// Pega o cliente no repositório pelo ID
-	c, err := u.repo.GetClienteByID(id)
+	// Pega o cliente no repositório pelo ID. O erro segue como veio (não encontrado, prazo
+	// esgotado...) para que a resposta tenha o status correspondente
+	c, err := u.repo.GetClienteByID(ctx, id)
 	if err != nil {
-		u.log.Error(err.Error(), "mtd", "u.repo.GetClienteByID")
-		return nil, domainerr.ErrClienteNotFound
+		u.log.ErrorContext(ctx, err.Error(), "mtd", "u.repo.GetClienteByID")
+		return nil, err
 	}
 
 	// Altera o objeto Cliente a partir do Cliente enviado no DTO de entrada
 	pNew, err := entities.UpdateCliente(id, in.Nome, in.Documento, in.Telefone, in.Bloqueado, c.CreatedAt)
 	if err != nil {
-		u.log.Error(err.Error(), "mtd", "entities.UpdateCliente")
+		u.log.ErrorContext(ctx, err.Error(), "mtd", "entities.UpdateCliente")
+		return nil, err
+	}
+
+	// Só quem tem a permissão de bloqueio pode bloquear ou desbloquear
+	if pNew.Bloqueado != c.Bloqueado {
+		if err := u.authz.Check(ctx, PermissionBlock); err != nil {
+			u.log.ErrorContext(ctx, err.Error(), "mtd", "u.authz.Check")
+			return nil, err
+		}
+	}
+
+	// O documento não pode pertencer a outro cliente
+	outro, err := u.repo.GetClienteByDocumento(ctx, pNew.Documento.String())
+	if err == nil && outro.ID != pNew.ID {
+		u.log.ErrorContext(ctx, domainerr.ErrDuplicatekey.Error(), "mtd", "u.repo.GetClienteByDocumento")
+		return nil, domainerr.ErrDuplicatekey
+	}
+	if err != nil && !errors.Is(err, domainerr.ErrClienteNotFound) {
+		u.log.ErrorContext(ctx, err.Error(), "mtd", "u.repo.GetClienteByDocumento")
 		return nil, err
 	}
 
 	// Altera o cliente no repositório
-	err = u.repo.UpdateCliente(id, pNew)
+	err = u.repo.UpdateCliente(ctx, id, pNew)
 	if err != nil {
-		u.log.Error(err.Error(), "mtd", "u.repo.UpdateCliente")
+		u.log.ErrorContext(ctx, err.Error(), "mtd", "u.repo.UpdateCliente")
+		// O índice único recusa o documento gravado por outra requisição depois da verificação acima
+		if errors.Is(err, globalerr.ErrDuplicatekey) {
+			return nil, domainerr.ErrDuplicatekey
+		}
 		return nil, err
 	}
 
 	// Retorna o DTO de saída
-	resp := &dto.Response{
-		ID:        pNew.ID.String(),
-		Nome:      pNew.Nome.String(),
-		Documento: pNew.Documento.String(),
-		Telefone:  pNew.Telefone.String(),
-		Bloqueado: pNew.Bloqueado.Bool(),
-		CreatedAt: pNew.CreatedAt.String(),
-		UpdatedAt: pNew.UpdatedAt.String(),
-	}
-	return resp, nil
+	resp := dto.NewResponse(pNew, mode)
+	return &resp, nil
 }
@@ -1,10 +1,15 @@
 package update_test
 
 import (
+	"context"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/valdinei-santos/cpf-backend/internal/domain/globalerr"
+	"github.com/valdinei-santos/cpf-backend/internal/infra/identity"
 	"github.com/valdinei-santos/cpf-backend/internal/infra/logger"
+	"github.com/valdinei-santos/cpf-backend/internal/infra/masking"
+	"github.com/valdinei-santos/cpf-backend/internal/infra/rbac"
 	"github.com/valdinei-santos/cpf-backend/internal/modules/cliente/domain/domainerr"
 	"github.com/valdinei-santos/cpf-backend/internal/modules/cliente/infra/dto"
 	"github.com/valdinei-santos/cpf-backend/internal/modules/cliente/infra/repository"
@@ -20,6 +25,7 @@ func TestExecute(t *testing.T) {
 		id           string
 		repo         *repository.MockClienteRepository
 		logger       *logger.MockILogger
+		roles        []string
 		input        *dto.Request
 		expectedResp *dto.Response
 		expectedErr  error
@@ -52,7 +58,7 @@ func TestExecute(t *testing.T) {
 				Telefone:  "11999999999",
 				Bloqueado: false,
 			},
-			expectedErr: domainerr.ErrClienteNotFound,
+			expectedErr: domainerr.ErrClienteIDInvalid,
 			expectDebug: true,
 			expectError: true,
 		},
@@ -75,6 +81,25 @@ func TestExecute(t *testing.T) {
 			expectDebug: true,
 			expectError: true,
 		},
+		{
+			name: "Deve retornar o erro do repositório quando o prazo da busca esgota",
+			id:   validClienteID,
+			repo: func() *repository.MockClienteRepository {
+				r := repository.NewMockClienteRepository()
+				r.SetMockError(context.DeadlineExceeded)
+				return r
+			}(),
+			logger: logger.NewMockILogger(),
+			input: &dto.Request{
+				Nome:      "Cliente Atualizado",
+				Documento: "12345678900",
+				Telefone:  "11999999999",
+				Bloqueado: false,
+			},
+			expectedErr: context.DeadlineExceeded,
+			expectDebug: true,
+			expectError: true,
+		},
 		{
 			name:   "Deve retornar erro ao tentar atualizar com dados de entrada inválidos",
 			id:     validClienteID,
@@ -90,17 +115,66 @@ func TestExecute(t *testing.T) {
 			expectDebug: true,
 			expectError: true,
 		},
+		{
+			name:   "Deve retornar erro quando o documento pertence a outro cliente",
+			id:     validClienteID,
+			repo:   mockRepo,
+			logger: logger.NewMockILogger(),
+			input: &dto.Request{
+				Nome:      "Cliente Atualizado",
+				Documento: "10987654321", // Documento do segundo cliente do mock
+				Telefone:  "11999999999",
+				Bloqueado: false,
+			},
+			expectedErr: domainerr.ErrDuplicatekey,
+			expectDebug: true,
+			expectError: true,
+		},
+		{
+			name:   "Deve retornar erro quando quem não é supervisor bloqueia o cliente",
+			id:     validClienteID,
+			repo:   mockRepo,
+			logger: logger.NewMockILogger(),
+			roles:  []string{"operator"},
+			input: &dto.Request{
+				Nome:      "Cliente Atualizado",
+				Documento: "12345678900",
+				Telefone:  "11999999999",
+				Bloqueado: true,
+			},
+			expectedErr: globalerr.ErrForbidden,
+			expectDebug: true,
+			expectError: true,
+		},
+		{
+			name:   "Deve bloquear o cliente quando o chamador é supervisor",
+			id:     validClienteID,
+			repo:   mockRepo,
+			logger: logger.NewMockILogger(),
+			roles:  []string{"supervisor"},
+			input: &dto.Request{
+				Nome:      "Cliente Atualizado",
+				Documento: "12345678900",
+				Telefone:  "11999999999",
+				Bloqueado: true,
+			},
+			expectedErr: nil,
+			expectDebug: true,
+			expectError: false,
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			uc := update.NewUseCase(tt.repo, tt.logger)
+			authz := rbac.NewAuthorizer(rbac.DefaultPolicy(), true, logger.NewMockILogger())
+			uc := update.NewUseCase(tt.repo, authz, tt.logger)
+			ctx := identity.NewContext(context.Background(), &identity.Identity{Subject: "teste", Roles: tt.roles})
 
-			resp, err := uc.Execute(tt.id, tt.input)
+			resp, err := uc.Execute(ctx, tt.id, tt.input, masking.Unmasked)
 
 			//Verifique se não houve erro
 			if tt.expectedErr != nil {
-				assert.EqualError(t, err, tt.expectedErr.Error())
+				assert.ErrorIs(t, err, tt.expectedErr)
 			} else {
 				assert.Nil(t, err)
 				//Verifique somente os campos que não mudam